@@ -1,16 +1,23 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"net/http"
 	"os"
 
+	"github.com/averroes/backend-prabogo/internal/adapter/hargalogam"
+	"github.com/averroes/backend-prabogo/internal/adapter/hargapasar"
 	httphandler "github.com/averroes/backend-prabogo/internal/adapter/http"
+	"github.com/averroes/backend-prabogo/internal/adapter/impor"
+	"github.com/averroes/backend-prabogo/internal/adapter/kurs"
 	"github.com/averroes/backend-prabogo/internal/adapter/repo/mysql"
 	"github.com/averroes/backend-prabogo/internal/adapter/repo/postgres"
+	"github.com/averroes/backend-prabogo/internal/domain"
 	"github.com/averroes/backend-prabogo/internal/usecase"
 	"github.com/averroes/backend-prabogo/pkg/config"
+	"github.com/averroes/backend-prabogo/pkg/jadwal"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/gorilla/mux"
 	"github.com/joho/godotenv"
@@ -58,15 +65,38 @@ func main() {
 
 	authUC := usecase.NewAuthUsecase(mysqlRepo, cfg.JWT.Secret)
 	screenerUC := usecase.NewScreenerUsecase(mysqlRepo)
-	edukasiUC := usecase.NewEdukasiUsecase(mysqlRepo)
+	if cfg.Server.URLPublik == "" {
+		cfg.Server.URLPublik = "http://localhost:" + cfg.Server.Port
+		log.Printf("PERINGATAN: URL_PUBLIK belum diatur; tautan verifikasi sertifikat memakai %s dan tidak dapat dibuka dari luar server", cfg.Server.URLPublik)
+	}
+	edukasiUC := usecase.NewEdukasiUsecase(mysqlRepo, cfg.Server.URLPublik)
 	pustakaUC := usecase.NewPustakaUsecase(mysqlRepo)
 	beritaUC := usecase.NewBeritaUsecase(mysqlRepo)
 	diskusiUC := usecase.NewDiskusiUsecase(mysqlRepo)
-	portofolioUC := usecase.NewPortofolioUsecase(mysqlRepo)
-	zakatUC := usecase.NewZakatUsecase(mysqlRepo, mysqlRepo)
+	var penyediaKurs domain.PenyediaKurs
+	if cfg.Kurs.URL != "" {
+		penyediaKurs = kurs.NewPenyediaHTTP(cfg.Kurs.URL)
+	}
+	kursUC := usecase.NewKursUsecase(mysqlRepo, penyediaKurs, cfg.Kurs.Dasar, cfg.Kurs.MataUang)
+	portofolioUC := usecase.NewPortofolioUsecase(mysqlRepo, mysqlRepo, kursUC, cfg.Portofolio.BatasUsiaHarga)
+	kepatuhanUC := usecase.NewKepatuhanUsecase(portofolioUC, mysqlRepo)
+	analitikUC := usecase.NewAnalitikUsecase(portofolioUC, mysqlRepo)
+	imporUC := usecase.NewImporPortofolioUsecase(portofolioUC, mysqlRepo, impor.Semua())
+	var penyediaHargaLogam domain.PenyediaHargaLogam
+	if cfg.HargaLogam.URL != "" {
+		penyediaHargaLogam = hargalogam.NewPenyediaHTTP(cfg.HargaLogam.URL, cfg.HargaLogam.Token)
+	}
+	hargaLogamUC := usecase.NewHargaLogamUsecase(mysqlRepo, mysqlRepo, mysqlRepo, penyediaHargaLogam, cfg.HargaLogam.MataUang, cfg.HargaLogam.BatasUsia)
+	zakatUC := usecase.NewZakatUsecase(portofolioUC, mysqlRepo, mysqlRepo, mysqlRepo, kursUC, hargaLogamUC)
 	reelsUC := usecase.NewReelsUsecase(mysqlRepo)
 	tadabburUC := usecase.NewTadabburUsecase(mysqlRepo)
-	adminUC := usecase.NewAdminUsecase(mysqlRepo)
+	hubHarga := usecase.NewHubHarga(0)
+	adminUC := usecase.NewAdminUsecase(mysqlRepo, hubHarga)
+	var penyediaHargaPasar domain.PenyediaHargaPasar
+	if cfg.HargaPasar.URL != "" {
+		penyediaHargaPasar = hargapasar.NewPenyediaHTTP(cfg.HargaPasar.URL)
+	}
+	hargaPasarUC := usecase.NewHargaPasarUsecase(mysqlRepo, adminUC, penyediaHargaPasar)
 
 	// Suppress unused variable warning
 	_ = repo
@@ -79,14 +109,55 @@ func main() {
 		BeritaUsecase:     beritaUC,
 		DiskusiUsecase:    diskusiUC,
 		PortofolioUsecase: portofolioUC,
+		KepatuhanUsecase:  kepatuhanUC,
+		AnalitikUsecase:   analitikUC,
+		ImporUsecase:      imporUC,
 		ZakatUsecase:      zakatUC,
+		KursUsecase:       kursUC,
+		HargaLogamUsecase: hargaLogamUC,
+		HargaPasarUsecase: hargaPasarUC,
 		ReelsUsecase:      reelsUC,
 		TadabburUsecase:   tadabburUC,
 		AdminUsecase:      adminUC,
+		HubHarga:          hubHarga,
+		OriginStream:      cfg.Server.OriginStream,
 		JWTSecret:         cfg.JWT.Secret,
 		Versi:             "1.0.0",
 	}
 
+	if penyediaKurs != nil {
+		go jadwal.Setiap(context.Background(), cfg.Kurs.Interval, func(ctx context.Context) {
+			if _, err := kursUC.Sinkronkan(ctx); err != nil {
+				log.Println("Gagal sinkronisasi kurs: ", err)
+			}
+		})
+	}
+
+	if penyediaHargaLogam != nil {
+		go jadwal.Setiap(context.Background(), cfg.HargaLogam.Interval, func(ctx context.Context) {
+			if _, err := hargaLogamUC.Sinkronkan(ctx); err != nil {
+				log.Println("Gagal sinkronisasi harga logam: ", err)
+			}
+		})
+	}
+
+	// Setiap kuotasi yang masuk diterbitkan ke stream /pasar/stream dan /pasar/ws.
+	if penyediaHargaPasar != nil {
+		go jadwal.Setiap(context.Background(), cfg.HargaPasar.Interval, func(ctx context.Context) {
+			if _, err := hargaPasarUC.Sinkronkan(ctx); err != nil {
+				log.Println("Gagal sinkronisasi harga pasar: ", err)
+			}
+		})
+	}
+
+	// Snapshot dijalankan berkala; snapshot di hari yang sama ditimpa sehingga
+	// setiap hari menyimpan satu nilai penutupan per pengguna.
+	go jadwal.Setiap(context.Background(), cfg.Portofolio.IntervalSnapshot, func(ctx context.Context) {
+		if _, err := zakatUC.SnapshotSemua(ctx); err != nil {
+			log.Println("Gagal menyimpan snapshot portofolio: ", err)
+		}
+	})
+
 	router := mux.NewRouter()
 	handler.RegisterRoutes(router)
 
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"database/sql"
 	"fmt"
 	"log"
 	"os"
@@ -8,9 +9,11 @@ import (
 	"sort"
 
 	"github.com/averroes/backend-prabogo/internal/adapter/repo/mysql"
+	"github.com/averroes/backend-prabogo/internal/adapter/repo/postgres"
 	"github.com/averroes/backend-prabogo/pkg/config"
 	"github.com/joho/godotenv"
 	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
 )
 
 func main() {
@@ -19,6 +22,9 @@ func main() {
 	cfg := config.NewConfig()
 
 	migrationsDir := filepath.Join("..", "..", "migrations")
+	if cfg.DB.DBDriver == "postgres" {
+		migrationsDir = filepath.Join(migrationsDir, "postgres")
+	}
 	entries, err := os.ReadDir(migrationsDir)
 	if err != nil {
 		log.Fatal("Gagal membaca folder migrasi: ", err)
@@ -33,13 +39,50 @@ func main() {
 	}
 	sort.Strings(files)
 
-	db, err := mysql.Open(cfg.DB.MySQLDSN())
+	var db *sql.DB
+	tabelMigrasi := `CREATE TABLE IF NOT EXISTS migrasi_skema (
+  nama VARCHAR(255) PRIMARY KEY,
+  dijalankan_pada DATETIME NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`
+	catatMigrasi := `INSERT INTO migrasi_skema (nama, dijalankan_pada) VALUES (?, NOW())`
+	if cfg.DB.DBDriver == "postgres" {
+		db, err = postgres.Open(cfg.DB.PostgresDSN())
+		tabelMigrasi = `CREATE TABLE IF NOT EXISTS migrasi_skema (
+  nama VARCHAR(255) PRIMARY KEY,
+  dijalankan_pada TIMESTAMP NOT NULL
+)`
+		catatMigrasi = `INSERT INTO migrasi_skema (nama, dijalankan_pada) VALUES ($1, NOW())`
+	} else {
+		db, err = mysql.Open(cfg.DB.MySQLDSN())
+	}
 	if err != nil {
 		log.Fatal("Gagal koneksi database: ", err)
 	}
 	defer db.Close()
 
+	if _, err := db.Exec(tabelMigrasi); err != nil {
+		log.Fatal("Gagal menyiapkan tabel migrasi: ", err)
+	}
+
+	sudah := map[string]bool{}
+	rows, err := db.Query(`SELECT nama FROM migrasi_skema`)
+	if err != nil {
+		log.Fatal("Gagal membaca riwayat migrasi: ", err)
+	}
+	for rows.Next() {
+		var nama string
+		if err := rows.Scan(&nama); err != nil {
+			log.Fatal("Gagal membaca riwayat migrasi: ", err)
+		}
+		sudah[nama] = true
+	}
+	rows.Close()
+
 	for _, name := range files {
+		if sudah[name] {
+			fmt.Println("Migrasi dilewati:", name)
+			continue
+		}
 		path := filepath.Join(migrationsDir, name)
 		content, err := os.ReadFile(path)
 		if err != nil {
@@ -48,6 +91,9 @@ func main() {
 		if _, err := db.Exec(string(content)); err != nil {
 			log.Fatalf("Gagal menjalankan migrasi %s: %v", name, err)
 		}
+		if _, err := db.Exec(catatMigrasi, name); err != nil {
+			log.Fatalf("Gagal mencatat migrasi %s: %v", name, err)
+		}
 		fmt.Println("Migrasi berhasil:", name)
 	}
 }
@@ -0,0 +1,103 @@
+package hargalogam
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+// gramPerTroyOunce dipakai bila penyedia hanya mengembalikan harga per troy ounce.
+const gramPerTroyOunce = 31.1034768
+
+var simbolLogam = map[string]string{
+	domain.NisabEmas:  "XAU",
+	domain.NisabPerak: "XAG",
+}
+
+// PenyediaHTTP mengambil harga logam dari API bergaya goldapi.io yang
+// mengembalikan {"price": 2350.1, "price_gram_24k": 75.5}. Penanda {simbol}
+// (XAU/XAG) dan {mata_uang} pada URL diganti sesuai permintaan; token, bila
+// ada, dikirim sebagai header x-access-token.
+type PenyediaHTTP struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func NewPenyediaHTTP(url, token string) *PenyediaHTTP {
+	return &PenyediaHTTP{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+type responsHarga struct {
+	Price        float64 `json:"price"`
+	PriceGram24k float64 `json:"price_gram_24k"`
+	HargaPerGram float64 `json:"harga_per_gram"`
+	Currency     string  `json:"currency"`
+	Error        string  `json:"error"`
+}
+
+func (p *PenyediaHTTP) Nama() string {
+	u, err := url.Parse(p.url)
+	if err != nil || u.Host == "" {
+		return "http"
+	}
+	return u.Host
+}
+
+func (p *PenyediaHTTP) AmbilHargaLogam(ctx context.Context, logam, mataUang string) (domain.Uang, error) {
+	simbol, ok := simbolLogam[logam]
+	if !ok {
+		return 0, fmt.Errorf("logam %s tidak didukung", logam)
+	}
+	alamat := strings.NewReplacer("{simbol}", simbol, "{mata_uang}", url.PathEscape(mataUang)).Replace(p.url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, alamat, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.token != "" {
+		req.Header.Set("x-access-token", p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("gagal menghubungi penyedia harga logam: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("penyedia harga logam merespons status %d", resp.StatusCode)
+	}
+
+	var data responsHarga
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return 0, fmt.Errorf("respons harga logam tidak valid: %w", err)
+	}
+	if data.Error != "" {
+		return 0, fmt.Errorf("penyedia harga logam gagal: %s", data.Error)
+	}
+	if data.Currency != "" && !strings.EqualFold(data.Currency, mataUang) {
+		return 0, fmt.Errorf("mata uang harga %s tidak sesuai permintaan %s", data.Currency, mataUang)
+	}
+	perGram := data.HargaPerGram
+	if perGram <= 0 {
+		perGram = data.PriceGram24k
+	}
+	if perGram <= 0 && data.Price > 0 {
+		perGram = data.Price / gramPerTroyOunce
+	}
+	if perGram <= 0 {
+		return 0, errors.New("respons harga logam kosong")
+	}
+	return domain.UangDariFloat(perGram)
+}
@@ -0,0 +1,73 @@
+package hargapasar
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+// PenyediaHTTP mengambil kuotasi pasar dari API yang mengembalikan
+// {"data": [{"simbol": "AMAN", "harga": "1.25", "volume_24j": 1200,
+// "perubahan_24j": -0.5, "kapitalisasi_pasar": 0, "mata_uang": "USD"}]}.
+// Penanda {simbol} pada URL diganti dengan daftar simbol dipisah koma.
+type PenyediaHTTP struct {
+	url    string
+	client *http.Client
+}
+
+func NewPenyediaHTTP(url string) *PenyediaHTTP {
+	return &PenyediaHTTP{
+		url:    url,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+type responsHarga struct {
+	Data  []domain.Pasar `json:"data"`
+	Error string         `json:"error"`
+}
+
+func (p *PenyediaHTTP) Nama() string {
+	u, err := url.Parse(p.url)
+	if err != nil || u.Host == "" {
+		return "http"
+	}
+	return u.Host
+}
+
+func (p *PenyediaHTTP) AmbilHargaPasar(ctx context.Context, simbol []string) ([]domain.Pasar, error) {
+	alamat := strings.ReplaceAll(p.url, "{simbol}", url.QueryEscape(strings.Join(simbol, ",")))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, alamat, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menghubungi penyedia harga pasar: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("penyedia harga pasar merespons status %d", resp.StatusCode)
+	}
+
+	var data responsHarga
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("respons harga pasar tidak valid: %w", err)
+	}
+	if data.Error != "" {
+		return nil, fmt.Errorf("penyedia harga pasar gagal: %s", data.Error)
+	}
+	if len(data.Data) == 0 {
+		return nil, errors.New("respons harga pasar kosong")
+	}
+	return data.Data, nil
+}
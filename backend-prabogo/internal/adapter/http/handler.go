@@ -2,6 +2,7 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"os"
 	"strconv"
@@ -22,10 +23,18 @@ type Handler struct {
 	BeritaUsecase     *usecase.BeritaUsecase
 	DiskusiUsecase    *usecase.DiskusiUsecase
 	PortofolioUsecase *usecase.PortofolioUsecase
+	KepatuhanUsecase  *usecase.KepatuhanUsecase
+	AnalitikUsecase   *usecase.AnalitikUsecase
+	ImporUsecase      *usecase.ImporPortofolioUsecase
 	ZakatUsecase      *usecase.ZakatUsecase
+	KursUsecase       *usecase.KursUsecase
+	HargaLogamUsecase *usecase.HargaLogamUsecase
+	HargaPasarUsecase *usecase.HargaPasarUsecase
 	ReelsUsecase      *usecase.ReelsUsecase
 	TadabburUsecase   *usecase.TadabburUsecase
 	AdminUsecase      *usecase.AdminUsecase
+	HubHarga          *usecase.HubHarga
+	OriginStream      []string
 	JWTSecret         string
 	Versi             string
 }
@@ -45,19 +54,36 @@ func (h *Handler) RegisterRoutes(router *mux.Router) {
 	api.HandleFunc("/masuk", h.Masuk).Methods("POST")
 	api.Handle("/keluar", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.Keluar))).Methods("POST")
 	api.Handle("/profil", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.Profil))).Methods("GET")
+	api.Handle("/profil/mata-uang", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.PerbaruiMataUang))).Methods("PUT")
+	api.Handle("/profil/standar-nisab", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.PerbaruiStandarNisab))).Methods("PUT")
 
 	api.HandleFunc("/screener", h.DaftarScreener).Methods("GET")
 	api.HandleFunc("/screener/{id}", h.DetailScreener).Methods("GET")
 	api.HandleFunc("/screener/{id}/catatan", h.CatatanScreener).Methods("GET")
 	api.HandleFunc("/pasar", h.DaftarPasar).Methods("GET")
+	api.HandleFunc("/pasar/stream", h.StreamPasar).Methods("GET")
+	api.HandleFunc("/pasar/ws", h.StreamPasarWebSocket).Methods("GET")
 
 	api.HandleFunc("/kelas", h.DaftarKelas).Methods("GET")
+	api.HandleFunc("/kelas/pratinjau/{token}", h.PratinjauKelas).Methods("GET")
 	api.HandleFunc("/kelas/{id}", h.DetailKelas).Methods("GET")
+	api.Handle("/kelas/{id}/lengkap", OpsionalAuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.KelasLengkap))).Methods("GET")
 	api.HandleFunc("/kelas/{id}/modul", h.DaftarModul).Methods("GET")
 	api.HandleFunc("/modul/{id}/materi", h.DaftarMateri).Methods("GET")
+	api.Handle("/materi/{id}/selesai", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.TandaiMateriSelesai))).Methods("POST")
+	api.Handle("/materi/{id}/posisi", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.SimpanPosisiVideo))).Methods("PUT")
 	api.HandleFunc("/kelas/{id}/ujian", h.DaftarUjian).Methods("GET")
+	api.Handle("/ujian/{id}/mulai", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.MulaiUjian))).Methods("POST")
+	api.Handle("/ujian/{id}/jawaban", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.SimpanJawabanUjian))).Methods("PUT")
+	api.Handle("/ujian/{id}/kirim", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.KirimUjian))).Methods("POST")
+	api.Handle("/ujian/{id}/hasil", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.HasilUjian))).Methods("GET")
 	api.Handle("/kelas/{id}/mulai", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.MulaiKelas))).Methods("POST")
+	api.HandleFunc("/jalur", h.DaftarJalur).Methods("GET")
+	api.Handle("/jalur/{id}", OpsionalAuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.DetailJalur))).Methods("GET")
 	api.Handle("/progress", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.DaftarProgress))).Methods("GET")
+	api.Handle("/sertifikat/saya", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.SertifikatSaya))).Methods("GET")
+	api.HandleFunc("/sertifikat/verifikasi/{kode}", h.VerifikasiSertifikat).Methods("GET")
+	api.HandleFunc("/sertifikat/verifikasi/{kode}/pdf", h.PDFSertifikat).Methods("GET")
 
 	api.HandleFunc("/pustaka", h.DaftarPustaka).Methods("GET")
 	api.HandleFunc("/pustaka/{id}", h.DetailPustaka).Methods("GET")
@@ -73,12 +99,44 @@ func (h *Handler) RegisterRoutes(router *mux.Router) {
 
 	api.Handle("/portofolio", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.DaftarPortofolio))).Methods("GET")
 	api.Handle("/portofolio", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.TambahPortofolio))).Methods("POST")
+	api.Handle("/portofolio/analitik", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.AnalitikPortofolio))).Methods("GET")
+	api.Handle("/portofolio/riwayat-nilai", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.RiwayatNilaiPortofolio))).Methods("GET")
+	api.Handle("/portofolio/kepatuhan", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.KepatuhanPortofolio))).Methods("GET")
+	api.Handle("/portofolio/impor", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.ImporPortofolio))).Methods("POST")
+	api.Handle("/portofolio/impor/{id}", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.AmbilImporPortofolio))).Methods("GET")
+	api.Handle("/portofolio/impor/{id}", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.HapusImporPortofolio))).Methods("DELETE")
+	api.Handle("/portofolio/impor/{id}/terapkan", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.TerapkanImporPortofolio))).Methods("POST")
+	api.Handle("/portofolio/transaksi", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.DaftarTransaksiPortofolio))).Methods("GET")
+	api.Handle("/portofolio/transaksi", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.TambahTransaksiPortofolio))).Methods("POST")
+	api.Handle("/portofolio/transaksi/{id}", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.PerbaruiTransaksiPortofolio))).Methods("PUT")
+	api.Handle("/portofolio/transaksi/{id}", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.HapusTransaksiPortofolio))).Methods("DELETE")
 	api.Handle("/portofolio/{id}", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.PerbaruiPortofolio))).Methods("PUT")
 	api.Handle("/portofolio/{id}", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.HapusPortofolio))).Methods("DELETE")
+	api.Handle("/portofolio/{id}/nilai-manual", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.AturNilaiManualPortofolio))).Methods("PUT")
+	api.Handle("/portofolio/{id}/nilai-manual", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.HapusNilaiManualPortofolio))).Methods("DELETE")
 
 	api.Handle("/zakat/ringkasan", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.RingkasanZakat))).Methods("GET")
+	api.Handle("/zakat/haul", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.HaulZakat))).Methods("GET")
+	api.Handle("/zakat/hitung", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.SimpanPerhitunganZakat))).Methods("POST")
+	api.Handle("/zakat/penghasilan", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.HitungZakatPenghasilan))).Methods("POST")
+	api.Handle("/zakat/perdagangan", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.HitungZakatPerdagangan))).Methods("POST")
+	api.Handle("/zakat/fitrah", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.HitungZakatFitrah))).Methods("POST")
+	api.Handle("/zakat/emas-perak", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.HitungZakatEmasPerak))).Methods("POST")
 	api.Handle("/zakat/riwayat", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.RiwayatZakat))).Methods("GET")
+	api.Handle("/zakat/riwayat/{id}/pdf", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.PDFRiwayatZakat))).Methods("GET")
+	api.Handle("/zakat/harta", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.DaftarHartaZakat))).Methods("GET")
+	api.Handle("/zakat/harta", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.TambahHartaZakat))).Methods("POST")
+	api.Handle("/zakat/harta/{id}", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.PerbaruiHartaZakat))).Methods("PUT")
+	api.Handle("/zakat/harta/{id}", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.HapusHartaZakat))).Methods("DELETE")
+	api.Handle("/zakat/pembayaran", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.DaftarPembayaranZakat))).Methods("GET")
+	api.Handle("/zakat/pembayaran", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.TambahPembayaranZakat))).Methods("POST")
+	api.Handle("/zakat/pembayaran/status", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.StatusPembayaranZakat))).Methods("GET")
+	api.Handle("/zakat/pembayaran/{id}", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.PerbaruiPembayaranZakat))).Methods("PUT")
+	api.Handle("/zakat/pembayaran/{id}", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.HapusPembayaranZakat))).Methods("DELETE")
+	api.Handle("/zakat/laporan-tahunan", AuthMiddleware(h.JWTSecret)(http.HandlerFunc(h.LaporanZakatTahunan))).Methods("GET")
 	api.HandleFunc("/harga-emas", h.HargaEmas).Methods("GET")
+	api.HandleFunc("/harga-emas/riwayat", h.RiwayatHargaEmas).Methods("GET")
+	api.HandleFunc("/kurs", h.DaftarKurs).Methods("GET")
 
 	api.HandleFunc("/reels", h.DaftarReels).Methods("GET")
 	api.HandleFunc("/reels/{id}", h.DetailReels).Methods("GET")
@@ -97,6 +155,13 @@ func (h *Handler) RegisterRoutes(router *mux.Router) {
 	admin.HandleFunc("/kelas", h.AdminBuatKelas).Methods("POST")
 	admin.HandleFunc("/kelas/{id}", h.AdminPerbaruiKelas).Methods("PUT")
 	admin.HandleFunc("/kelas/{id}", h.AdminHapusKelas).Methods("DELETE")
+	admin.HandleFunc("/kelas/{id}/pratinjau", h.AdminBuatTokenPratinjau).Methods("POST")
+	admin.HandleFunc("/kelas/{id}/prasyarat", h.AdminDaftarPrasyaratKelas).Methods("GET")
+	admin.HandleFunc("/kelas/{id}/prasyarat", h.AdminSimpanPrasyaratKelas).Methods("PUT")
+	admin.HandleFunc("/jalur", h.AdminDaftarJalur).Methods("GET")
+	admin.HandleFunc("/jalur", h.AdminBuatJalur).Methods("POST")
+	admin.HandleFunc("/jalur/{id}", h.AdminPerbaruiJalur).Methods("PUT")
+	admin.HandleFunc("/jalur/{id}", h.AdminHapusJalur).Methods("DELETE")
 
 	admin.HandleFunc("/modul", h.AdminDaftarModul).Methods("GET")
 	admin.HandleFunc("/modul", h.AdminBuatModul).Methods("POST")
@@ -112,6 +177,10 @@ func (h *Handler) RegisterRoutes(router *mux.Router) {
 	admin.HandleFunc("/ujian", h.AdminBuatUjian).Methods("POST")
 	admin.HandleFunc("/ujian/{id}", h.AdminPerbaruiUjian).Methods("PUT")
 	admin.HandleFunc("/ujian/{id}", h.AdminHapusUjian).Methods("DELETE")
+	admin.HandleFunc("/ujian/{id}/soal", h.AdminDaftarSoalUjian).Methods("GET")
+	admin.HandleFunc("/ujian/{id}/soal", h.AdminBuatSoalUjian).Methods("POST")
+	admin.HandleFunc("/soal/{id}", h.AdminPerbaruiSoalUjian).Methods("PUT")
+	admin.HandleFunc("/soal/{id}", h.AdminHapusSoalUjian).Methods("DELETE")
 
 	admin.HandleFunc("/sertifikat", h.AdminDaftarSertifikat).Methods("GET")
 	admin.HandleFunc("/sertifikat", h.AdminBuatSertifikat).Methods("POST")
@@ -136,9 +205,21 @@ func (h *Handler) RegisterRoutes(router *mux.Router) {
 
 	admin.HandleFunc("/pasar", h.AdminDaftarPasar).Methods("GET")
 	admin.HandleFunc("/pasar", h.AdminBuatPasar).Methods("POST")
+	admin.HandleFunc("/pasar/sinkron", h.AdminSinkronPasar).Methods("POST")
 	admin.HandleFunc("/pasar/{id}", h.AdminPerbaruiPasar).Methods("PUT")
 	admin.HandleFunc("/pasar/{id}", h.AdminHapusPasar).Methods("DELETE")
 
+	admin.HandleFunc("/kurs", h.DaftarKurs).Methods("GET")
+	admin.HandleFunc("/kurs", h.AdminSimpanKurs).Methods("POST")
+	admin.HandleFunc("/kurs/sinkron", h.AdminSinkronKurs).Methods("POST")
+
+	admin.HandleFunc("/harga-emas", h.RiwayatHargaEmas).Methods("GET")
+	admin.HandleFunc("/harga-emas", h.AdminSimpanHargaEmas).Methods("POST")
+	admin.HandleFunc("/harga-emas/impor", h.AdminImporHargaEmas).Methods("POST")
+	admin.HandleFunc("/harga-emas/sinkron", h.AdminSinkronHargaEmas).Methods("POST")
+	admin.HandleFunc("/harga-emas/{id}", h.AdminPerbaruiHargaEmas).Methods("PUT")
+	admin.HandleFunc("/harga-emas/{id}", h.AdminHapusHargaEmas).Methods("DELETE")
+
 	admin.HandleFunc("/reels", h.AdminDaftarReels).Methods("GET")
 	admin.HandleFunc("/reels", h.AdminBuatReels).Methods("POST")
 	admin.HandleFunc("/reels/{id}", h.AdminPerbaruiReels).Methods("PUT")
@@ -373,6 +454,38 @@ func (h *Handler) DetailKelas(w http.ResponseWriter, r *http.Request) {
 	ResponSukses(w, http.StatusOK, "Detail kelas berhasil diambil", data)
 }
 
+func (h *Handler) KelasLengkap(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID kelas tidak valid", nil)
+		return
+	}
+	idPengguna, _ := r.Context().Value(ContextUserID).(int64)
+	data, err := h.EdukasiUsecase.KelasLengkap(r.Context(), id, idPengguna)
+	if err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil kelas", err.Error())
+		return
+	}
+	if data == nil {
+		ResponGagal(w, http.StatusNotFound, "Kelas tidak ditemukan", nil)
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Detail kelas lengkap berhasil diambil", data)
+}
+
+func (h *Handler) PratinjauKelas(w http.ResponseWriter, r *http.Request) {
+	data, err := h.EdukasiUsecase.PratinjauKelas(r.Context(), mux.Vars(r)["token"])
+	if err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil pratinjau kelas", err.Error())
+		return
+	}
+	if data == nil {
+		ResponGagal(w, http.StatusNotFound, "Token pratinjau tidak valid atau kedaluwarsa", nil)
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Pratinjau kelas berhasil diambil", data)
+}
+
 func (h *Handler) DaftarModul(w http.ResponseWriter, r *http.Request) {
 	id, err := parseID(mux.Vars(r)["id"])
 	if err != nil {
@@ -423,6 +536,10 @@ func (h *Handler) MulaiKelas(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := h.EdukasiUsecase.MulaiKelas(r.Context(), idPengguna, idKelas); err != nil {
+		if errors.Is(err, domain.ErrPrasyaratBelumSelesai) {
+			ResponGagal(w, http.StatusForbidden, "Selesaikan kelas prasyarat terlebih dahulu", err.Error())
+			return
+		}
 		ResponGagal(w, http.StatusInternalServerError, "Gagal menyimpan progress", err.Error())
 		return
 	}
@@ -439,6 +556,38 @@ func (h *Handler) DaftarProgress(w http.ResponseWriter, r *http.Request) {
 	ResponSukses(w, http.StatusOK, "Progress berhasil diambil", data)
 }
 
+func (h *Handler) SertifikatSaya(w http.ResponseWriter, r *http.Request) {
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.EdukasiUsecase.SertifikatSaya(r.Context(), idPengguna)
+	if err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil sertifikat", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Sertifikat berhasil diambil", data)
+}
+
+func (h *Handler) VerifikasiSertifikat(w http.ResponseWriter, r *http.Request) {
+	data, err := h.EdukasiUsecase.VerifikasiSertifikat(r.Context(), mux.Vars(r)["kode"])
+	if err != nil {
+		ResponGagal(w, http.StatusNotFound, "Sertifikat tidak valid", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Sertifikat valid", data)
+}
+
+func (h *Handler) PDFSertifikat(w http.ResponseWriter, r *http.Request) {
+	isi, nama, err := h.EdukasiUsecase.PDFSertifikat(r.Context(), mux.Vars(r)["kode"])
+	if err != nil {
+		ResponGagal(w, http.StatusNotFound, "Gagal membuat sertifikat", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+nama+`"`)
+	w.Header().Set("Content-Length", strconv.Itoa(len(isi)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(isi)
+}
+
 func (h *Handler) DaftarPustaka(w http.ResponseWriter, r *http.Request) {
 	data, err := h.PustakaUsecase.Daftar(r.Context())
 	if err != nil {
@@ -583,7 +732,8 @@ func (h *Handler) LaporDiskusi(w http.ResponseWriter, r *http.Request) {
 
 func (h *Handler) DaftarPortofolio(w http.ResponseWriter, r *http.Request) {
 	idPengguna := r.Context().Value(ContextUserID).(int64)
-	data, err := h.PortofolioUsecase.Daftar(r.Context(), idPengguna)
+	query := r.URL.Query()
+	data, err := h.PortofolioUsecase.Daftar(r.Context(), idPengguna, query.Get("mata_uang"), query.Get("metode"), query.Get("termasuk_tutup") == "true")
 	if err != nil {
 		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil portofolio", err.Error())
 		return
@@ -603,11 +753,12 @@ func (h *Handler) TambahPortofolio(w http.ResponseWriter, r *http.Request) {
 	}
 	idPengguna := r.Context().Value(ContextUserID).(int64)
 	req.IDPengguna = idPengguna
-	if err := h.PortofolioUsecase.Tambah(r.Context(), &req); err != nil {
-		ResponGagal(w, http.StatusInternalServerError, "Gagal menambah portofolio", err.Error())
+	data, err := h.PortofolioUsecase.Tambah(r.Context(), &req)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal menambah portofolio", err.Error())
 		return
 	}
-	ResponSukses(w, http.StatusCreated, "Portofolio berhasil ditambahkan", req)
+	ResponSukses(w, http.StatusCreated, "Portofolio berhasil ditambahkan", data)
 }
 
 func (h *Handler) PerbaruiPortofolio(w http.ResponseWriter, r *http.Request) {
@@ -624,11 +775,12 @@ func (h *Handler) PerbaruiPortofolio(w http.ResponseWriter, r *http.Request) {
 	}
 	req.ID = id
 	req.IDPengguna = idPengguna
-	if err := h.PortofolioUsecase.Perbarui(r.Context(), &req); err != nil {
-		ResponGagal(w, http.StatusInternalServerError, "Gagal memperbarui portofolio", err.Error())
+	data, err := h.PortofolioUsecase.Perbarui(r.Context(), &req)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal memperbarui portofolio", err.Error())
 		return
 	}
-	ResponSukses(w, http.StatusOK, "Portofolio berhasil diperbarui", req)
+	ResponSukses(w, http.StatusOK, "Portofolio berhasil diperbarui", data)
 }
 
 func (h *Handler) HapusPortofolio(w http.ResponseWriter, r *http.Request) {
@@ -647,9 +799,9 @@ func (h *Handler) HapusPortofolio(w http.ResponseWriter, r *http.Request) {
 
 func (h *Handler) RingkasanZakat(w http.ResponseWriter, r *http.Request) {
 	idPengguna := r.Context().Value(ContextUserID).(int64)
-	data, err := h.ZakatUsecase.Ringkasan(r.Context(), idPengguna)
+	data, err := h.ZakatUsecase.Ringkasan(r.Context(), idPengguna, r.URL.Query().Get("mata_uang"), r.URL.Query().Get("standar_nisab"))
 	if err != nil {
-		ResponGagal(w, http.StatusInternalServerError, "Gagal menghitung zakat", err.Error())
+		ResponGagal(w, http.StatusBadRequest, "Gagal menghitung zakat", err.Error())
 		return
 	}
 	ResponSukses(w, http.StatusOK, "Ringkasan zakat berhasil dihitung", data)
@@ -657,18 +809,26 @@ func (h *Handler) RingkasanZakat(w http.ResponseWriter, r *http.Request) {
 
 func (h *Handler) RiwayatZakat(w http.ResponseWriter, r *http.Request) {
 	idPengguna := r.Context().Value(ContextUserID).(int64)
-	data, err := h.ZakatUsecase.Riwayat(r.Context(), idPengguna)
+	data, err := h.ZakatUsecase.Riwayat(r.Context(), idPengguna, r.URL.Query().Get("jenis"))
 	if err != nil {
-		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil riwayat zakat", err.Error())
+		ResponGagal(w, http.StatusBadRequest, "Gagal mengambil riwayat zakat", err.Error())
 		return
 	}
 	ResponSukses(w, http.StatusOK, "Riwayat zakat berhasil diambil", data)
 }
 
 func (h *Handler) HargaEmas(w http.ResponseWriter, r *http.Request) {
-	data, err := h.ZakatUsecase.HargaEmas(r.Context())
-	if err != nil || data == nil {
-		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil harga emas", nil)
+	data, err := h.HargaLogamUsecase.Terkini(r.Context(), r.URL.Query().Get("logam"))
+	if err != nil {
+		if errors.Is(err, domain.ErrLogamTidakValid) {
+			ResponGagal(w, http.StatusBadRequest, "Logam tidak valid", err.Error())
+			return
+		}
+		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil harga emas", err.Error())
+		return
+	}
+	if data == nil {
+		ResponGagal(w, http.StatusNotFound, "Harga emas belum tersedia", nil)
 		return
 	}
 	ResponSukses(w, http.StatusOK, "Harga emas berhasil diambil", data)
@@ -718,7 +878,7 @@ func (h *Handler) AdminDaftarPengguna(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) AdminDaftarKelas(w http.ResponseWriter, r *http.Request) {
-	data, err := h.EdukasiUsecase.DaftarKelas(r.Context())
+	data, err := h.EdukasiUsecase.DaftarKelasSemua(r.Context())
 	if err != nil {
 		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil kelas", err.Error())
 		return
@@ -864,29 +1024,98 @@ func (h *Handler) AdminHapusPengguna(w http.ResponseWriter, r *http.Request) {
 	ResponSukses(w, http.StatusOK, "Pengguna berhasil dihapus", nil)
 }
 
+// kelasRequest, modulRequest dan ujianRequest adalah payload tulis admin.
+// Jumlah modul, durasi kelas dan modul serta jumlah soal dihitung dari isi
+// turunannya, sehingga tidak diterima dari klien.
+type kelasRequest struct {
+	Judul        string     `json:"judul"`
+	Deskripsi    string     `json:"deskripsi"`
+	Level        string     `json:"level"`
+	ThumbnailURL string     `json:"thumbnail_url"`
+	Status       string     `json:"status"`
+	TerbitPada   *time.Time `json:"terbit_pada"`
+}
+
+func (k kelasRequest) kelas() domain.Kelas {
+	return domain.Kelas{
+		Judul:        k.Judul,
+		Deskripsi:    k.Deskripsi,
+		Level:        k.Level,
+		ThumbnailURL: k.ThumbnailURL,
+		Status:       k.Status,
+		TerbitPada:   k.TerbitPada,
+	}
+}
+
+type modulRequest struct {
+	IDKelas    int64      `json:"id_kelas"`
+	Judul      string     `json:"judul"`
+	Urutan     int        `json:"urutan"`
+	Ringkasan  string     `json:"ringkasan"`
+	Status     string     `json:"status"`
+	TerbitPada *time.Time `json:"terbit_pada"`
+}
+
+func (m modulRequest) modul() domain.Modul {
+	return domain.Modul{
+		IDKelas:    m.IDKelas,
+		Judul:      m.Judul,
+		Urutan:     m.Urutan,
+		Ringkasan:  m.Ringkasan,
+		Status:     m.Status,
+		TerbitPada: m.TerbitPada,
+	}
+}
+
+type ujianRequest struct {
+	IDKelas          int64  `json:"id_kelas"`
+	Judul            string `json:"judul"`
+	Deskripsi        string `json:"deskripsi"`
+	DurasiMenit      int    `json:"durasi_menit"`
+	NilaiLulus       int    `json:"nilai_lulus"`
+	SoalPerPercobaan int    `json:"soal_per_percobaan"`
+	MaksPercobaan    int    `json:"maks_percobaan"`
+	JedaMenit        int    `json:"jeda_menit"`
+}
+
+func (u ujianRequest) ujian() domain.Ujian {
+	return domain.Ujian{
+		IDKelas:          u.IDKelas,
+		Judul:            u.Judul,
+		Deskripsi:        u.Deskripsi,
+		DurasiMenit:      u.DurasiMenit,
+		NilaiLulus:       u.NilaiLulus,
+		SoalPerPercobaan: u.SoalPerPercobaan,
+		MaksPercobaan:    u.MaksPercobaan,
+		JedaMenit:        u.JedaMenit,
+	}
+}
+
 func (h *Handler) AdminBuatKelas(w http.ResponseWriter, r *http.Request) {
-	var req domain.Kelas
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	var payload kelasRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
 		return
 	}
+	req := payload.kelas()
 	if strings.TrimSpace(req.Judul) == "" {
 		ResponGagal(w, http.StatusBadRequest, "Judul kelas wajib diisi", nil)
 		return
 	}
 	if err := h.AdminUsecase.BuatKelas(r.Context(), &req); err != nil {
-		ResponGagal(w, http.StatusInternalServerError, "Gagal membuat kelas", err.Error())
+		ResponGagal(w, http.StatusBadRequest, "Gagal membuat kelas", err.Error())
 		return
 	}
 	ResponSukses(w, http.StatusCreated, "Kelas berhasil dibuat", req)
 }
 
 func (h *Handler) AdminPerbaruiKelas(w http.ResponseWriter, r *http.Request) {
-	var req domain.Kelas
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	var payload kelasRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
 		return
 	}
+	req := payload.kelas()
 	id, err := parseID(mux.Vars(r)["id"])
 	if err != nil {
 		ResponGagal(w, http.StatusBadRequest, "ID kelas tidak valid", nil)
@@ -894,12 +1123,26 @@ func (h *Handler) AdminPerbaruiKelas(w http.ResponseWriter, r *http.Request) {
 	}
 	req.ID = id
 	if err := h.AdminUsecase.PerbaruiKelas(r.Context(), &req); err != nil {
-		ResponGagal(w, http.StatusInternalServerError, "Gagal memperbarui kelas", err.Error())
+		ResponGagal(w, http.StatusBadRequest, "Gagal memperbarui kelas", err.Error())
 		return
 	}
 	ResponSukses(w, http.StatusOK, "Kelas berhasil diperbarui", req)
 }
 
+func (h *Handler) AdminBuatTokenPratinjau(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID kelas tidak valid", nil)
+		return
+	}
+	data, err := h.AdminUsecase.BuatTokenPratinjau(r.Context(), id)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal membuat token pratinjau", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusCreated, "Token pratinjau berhasil dibuat", data)
+}
+
 func (h *Handler) AdminHapusKelas(w http.ResponseWriter, r *http.Request) {
 	id, err := parseID(mux.Vars(r)["id"])
 	if err != nil {
@@ -914,28 +1157,30 @@ func (h *Handler) AdminHapusKelas(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) AdminBuatModul(w http.ResponseWriter, r *http.Request) {
-	var req domain.Modul
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	var payload modulRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
 		return
 	}
+	req := payload.modul()
 	if req.IDKelas == 0 || strings.TrimSpace(req.Judul) == "" {
 		ResponGagal(w, http.StatusBadRequest, "ID kelas dan judul modul wajib diisi", nil)
 		return
 	}
 	if err := h.AdminUsecase.BuatModul(r.Context(), &req); err != nil {
-		ResponGagal(w, http.StatusInternalServerError, "Gagal membuat modul", err.Error())
+		ResponGagal(w, http.StatusBadRequest, "Gagal membuat modul", err.Error())
 		return
 	}
 	ResponSukses(w, http.StatusCreated, "Modul berhasil dibuat", req)
 }
 
 func (h *Handler) AdminPerbaruiModul(w http.ResponseWriter, r *http.Request) {
-	var req domain.Modul
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	var payload modulRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
 		return
 	}
+	req := payload.modul()
 	id, err := parseID(mux.Vars(r)["id"])
 	if err != nil {
 		ResponGagal(w, http.StatusBadRequest, "ID modul tidak valid", nil)
@@ -943,7 +1188,7 @@ func (h *Handler) AdminPerbaruiModul(w http.ResponseWriter, r *http.Request) {
 	}
 	req.ID = id
 	if err := h.AdminUsecase.PerbaruiModul(r.Context(), &req); err != nil {
-		ResponGagal(w, http.StatusInternalServerError, "Gagal memperbarui modul", err.Error())
+		ResponGagal(w, http.StatusBadRequest, "Gagal memperbarui modul", err.Error())
 		return
 	}
 	ResponSukses(w, http.StatusOK, "Modul berhasil diperbarui", req)
@@ -973,7 +1218,7 @@ func (h *Handler) AdminBuatMateri(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := h.AdminUsecase.BuatMateri(r.Context(), &req); err != nil {
-		ResponGagal(w, http.StatusInternalServerError, "Gagal membuat materi", err.Error())
+		ResponGagal(w, http.StatusBadRequest, "Gagal membuat materi", err.Error())
 		return
 	}
 	ResponSukses(w, http.StatusCreated, "Materi berhasil dibuat", req)
@@ -992,7 +1237,7 @@ func (h *Handler) AdminPerbaruiMateri(w http.ResponseWriter, r *http.Request) {
 	}
 	req.ID = id
 	if err := h.AdminUsecase.PerbaruiMateri(r.Context(), &req); err != nil {
-		ResponGagal(w, http.StatusInternalServerError, "Gagal memperbarui materi", err.Error())
+		ResponGagal(w, http.StatusBadRequest, "Gagal memperbarui materi", err.Error())
 		return
 	}
 	ResponSukses(w, http.StatusOK, "Materi berhasil diperbarui", req)
@@ -1012,15 +1257,20 @@ func (h *Handler) AdminHapusMateri(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) AdminBuatUjian(w http.ResponseWriter, r *http.Request) {
-	var req domain.Ujian
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	var payload ujianRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
 		return
 	}
+	req := payload.ujian()
 	if req.IDKelas == 0 || strings.TrimSpace(req.Judul) == "" {
 		ResponGagal(w, http.StatusBadRequest, "ID kelas dan judul ujian wajib diisi", nil)
 		return
 	}
+	if req.NilaiLulus < 0 || req.NilaiLulus > 100 || req.SoalPerPercobaan < 0 || req.MaksPercobaan < 0 || req.JedaMenit < 0 {
+		ResponGagal(w, http.StatusBadRequest, "Nilai lulus harus 0-100; soal per percobaan, maks percobaan dan jeda menit tidak boleh negatif", nil)
+		return
+	}
 	if err := h.AdminUsecase.BuatUjian(r.Context(), &req); err != nil {
 		ResponGagal(w, http.StatusInternalServerError, "Gagal membuat ujian", err.Error())
 		return
@@ -1029,17 +1279,22 @@ func (h *Handler) AdminBuatUjian(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) AdminPerbaruiUjian(w http.ResponseWriter, r *http.Request) {
-	var req domain.Ujian
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	var payload ujianRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
 		return
 	}
+	req := payload.ujian()
 	id, err := parseID(mux.Vars(r)["id"])
 	if err != nil {
 		ResponGagal(w, http.StatusBadRequest, "ID ujian tidak valid", nil)
 		return
 	}
 	req.ID = id
+	if req.NilaiLulus < 0 || req.NilaiLulus > 100 || req.SoalPerPercobaan < 0 || req.MaksPercobaan < 0 || req.JedaMenit < 0 {
+		ResponGagal(w, http.StatusBadRequest, "Nilai lulus harus 0-100; soal per percobaan, maks percobaan dan jeda menit tidak boleh negatif", nil)
+		return
+	}
 	if err := h.AdminUsecase.PerbaruiUjian(r.Context(), &req); err != nil {
 		ResponGagal(w, http.StatusInternalServerError, "Gagal memperbarui ujian", err.Error())
 		return
@@ -1066,8 +1321,8 @@ func (h *Handler) AdminBuatSertifikat(w http.ResponseWriter, r *http.Request) {
 		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
 		return
 	}
-	if req.IDPengguna == 0 || req.IDKelas == 0 || strings.TrimSpace(req.Kode) == "" {
-		ResponGagal(w, http.StatusBadRequest, "ID pengguna, ID kelas, dan kode sertifikat wajib diisi", nil)
+	if req.IDPengguna == 0 || req.IDKelas == 0 {
+		ResponGagal(w, http.StatusBadRequest, "ID pengguna dan ID kelas wajib diisi", nil)
 		return
 	}
 	if req.TanggalTerbit.IsZero() {
@@ -1292,6 +1547,17 @@ func (h *Handler) AdminHapusPasar(w http.ResponseWriter, r *http.Request) {
 	ResponSukses(w, http.StatusOK, "Data pasar berhasil dihapus", nil)
 }
 
+func (h *Handler) AdminSinkronPasar(w http.ResponseWriter, r *http.Request) {
+	jumlah, err := h.HargaPasarUsecase.Sinkronkan(r.Context())
+	if err != nil {
+		ResponGagal(w, http.StatusBadGateway, "Gagal sinkronisasi harga pasar", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Sinkronisasi harga pasar berhasil", map[string]interface{}{
+		"jumlah_pasar": jumlah,
+	})
+}
+
 func (h *Handler) AdminBuatReels(w http.ResponseWriter, r *http.Request) {
 	var req domain.Reels
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -1453,3 +1719,11 @@ func parseLimit(value string, fallback int) int {
 	}
 	return limit
 }
+
+// parseTanggal membaca tanggal berformat YYYY-MM-DD; nilai kosong menghasilkan waktu nol.
+func parseTanggal(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, time.Local)
+}
@@ -0,0 +1,172 @@
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) RiwayatHargaEmas(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	dari, err := parseTanggal(query.Get("dari"))
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Format tanggal harus YYYY-MM-DD", nil)
+		return
+	}
+	sampai, err := parseTanggal(query.Get("sampai"))
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Format tanggal harus YYYY-MM-DD", nil)
+		return
+	}
+	data, err := h.HargaLogamUsecase.Riwayat(r.Context(), query.Get("logam"), dari, sampai)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal mengambil riwayat harga emas", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Riwayat harga emas berhasil diambil", data)
+}
+
+func (h *Handler) AdminSimpanHargaEmas(w http.ResponseWriter, r *http.Request) {
+	var req domain.HargaEmas
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	if err := h.HargaLogamUsecase.Simpan(r.Context(), &req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal menyimpan harga emas", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusCreated, "Harga emas berhasil disimpan", req)
+}
+
+func (h *Handler) AdminPerbaruiHargaEmas(w http.ResponseWriter, r *http.Request) {
+	var req domain.HargaEmas
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID harga tidak valid", nil)
+		return
+	}
+	req.ID = id
+	if err := h.HargaLogamUsecase.Perbarui(r.Context(), &req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal memperbarui harga emas", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Harga emas berhasil diperbarui", req)
+}
+
+func (h *Handler) AdminHapusHargaEmas(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID harga tidak valid", nil)
+		return
+	}
+	if err := h.HargaLogamUsecase.Hapus(r.Context(), id); err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal menghapus harga emas", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Harga emas berhasil dihapus", nil)
+}
+
+// AdminImporHargaEmas menerima larik JSON harga atau CSV dengan kolom
+// tanggal, logam, harga_per_gram, mata_uang dan sumber (opsional).
+func (h *Handler) AdminImporHargaEmas(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, batasBerkasImpor)
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Berkas tidak dapat dibaca", err.Error())
+		return
+	}
+	var daftar []domain.HargaEmas
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		err = json.Unmarshal(data, &daftar)
+	} else {
+		daftar, err = bacaCSVHargaLogam(data)
+	}
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	hasil, err := h.HargaLogamUsecase.Impor(r.Context(), daftar)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal mengimpor harga emas", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Impor harga emas selesai", hasil)
+}
+
+func (h *Handler) AdminSinkronHargaEmas(w http.ResponseWriter, r *http.Request) {
+	jumlah, err := h.HargaLogamUsecase.Sinkronkan(r.Context())
+	if err != nil {
+		ResponGagal(w, http.StatusBadGateway, "Gagal sinkronisasi harga emas", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Sinkronisasi harga emas berhasil", map[string]interface{}{
+		"jumlah_harga": jumlah,
+	})
+}
+
+func bacaCSVHargaLogam(data []byte) ([]domain.HargaEmas, error) {
+	pembaca := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	pembaca.FieldsPerRecord = -1
+	pembaca.TrimLeadingSpace = true
+	baris, err := pembaca.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(baris) < 2 {
+		return nil, errors.New("berkas harus berisi header dan minimal satu baris")
+	}
+	kolom := map[string]int{}
+	for i, nama := range baris[0] {
+		kolom[strings.ToLower(strings.TrimSpace(nama))] = i
+	}
+	if _, ok := kolom["harga"]; ok {
+		if _, ada := kolom["harga_per_gram"]; !ada {
+			kolom["harga_per_gram"] = kolom["harga"]
+		}
+	}
+	for _, wajib := range []string{"tanggal", "harga_per_gram"} {
+		if _, ok := kolom[wajib]; !ok {
+			return nil, fmt.Errorf("kolom %s wajib ada", wajib)
+		}
+	}
+	nilai := func(rekam []string, nama string) string {
+		i, ok := kolom[nama]
+		if !ok || i >= len(rekam) {
+			return ""
+		}
+		return strings.TrimSpace(rekam[i])
+	}
+
+	daftar := make([]domain.HargaEmas, 0, len(baris)-1)
+	for i, rekam := range baris[1:] {
+		tanggal, err := parseTanggal(nilai(rekam, "tanggal"))
+		if err != nil || tanggal.IsZero() {
+			return nil, fmt.Errorf("baris %d: format tanggal harus YYYY-MM-DD", i+2)
+		}
+		harga, err := domain.UangDariString(nilai(rekam, "harga_per_gram"))
+		if err != nil {
+			return nil, fmt.Errorf("baris %d: harga tidak valid", i+2)
+		}
+		daftar = append(daftar, domain.HargaEmas{
+			Tanggal:      tanggal,
+			HargaPerGram: harga,
+			MataUang:     nilai(rekam, "mata_uang"),
+			Logam:        nilai(rekam, "logam"),
+			Sumber:       nilai(rekam, "sumber"),
+		})
+	}
+	return daftar, nil
+}
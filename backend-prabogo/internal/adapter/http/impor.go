@@ -0,0 +1,95 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// batasBerkasImpor membatasi ukuran berkas mutasi yang dibaca ke memori.
+const batasBerkasImpor = 5 << 20
+
+// ImporPortofolio menerima berkas mutasi sebagai field multipart "berkas"
+// atau langsung sebagai body permintaan, lalu mengembalikan pratinjau.
+func (h *Handler) ImporPortofolio(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, batasBerkasImpor)
+	var data []byte
+	var namaBerkas string
+	var err error
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		berkas, header, errBerkas := r.FormFile("berkas")
+		if errBerkas != nil {
+			ResponGagal(w, http.StatusBadRequest, "Berkas tidak valid", errBerkas.Error())
+			return
+		}
+		defer berkas.Close()
+		namaBerkas = header.Filename
+		data, err = io.ReadAll(berkas)
+	} else {
+		data, err = io.ReadAll(r.Body)
+	}
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Berkas tidak dapat dibaca", err.Error())
+		return
+	}
+	if namaBerkas == "" {
+		namaBerkas = r.URL.Query().Get("nama_berkas")
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	hasil, err := h.ImporUsecase.Pratinjau(r.Context(), idPengguna, r.URL.Query().Get("format"), namaBerkas, data)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal mengimpor berkas", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusCreated, "Pratinjau impor berhasil dibuat", hasil)
+}
+
+func (h *Handler) AmbilImporPortofolio(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID impor tidak valid", nil)
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.ImporUsecase.Ambil(r.Context(), id, idPengguna)
+	if err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil impor", err.Error())
+		return
+	}
+	if data == nil {
+		ResponGagal(w, http.StatusNotFound, "Impor tidak ditemukan", nil)
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Impor berhasil diambil", data)
+}
+
+func (h *Handler) TerapkanImporPortofolio(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID impor tidak valid", nil)
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.ImporUsecase.Terapkan(r.Context(), id, idPengguna)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal menerapkan impor", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Impor berhasil diterapkan", data)
+}
+
+func (h *Handler) HapusImporPortofolio(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID impor tidak valid", nil)
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	if err := h.ImporUsecase.Hapus(r.Context(), id, idPengguna); err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal menghapus impor", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Impor berhasil dihapus", nil)
+}
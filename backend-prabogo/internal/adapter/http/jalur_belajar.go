@@ -0,0 +1,134 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) DaftarJalur(w http.ResponseWriter, r *http.Request) {
+	data, err := h.EdukasiUsecase.DaftarJalur(r.Context())
+	if err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil jalur belajar", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Daftar jalur belajar berhasil diambil", data)
+}
+
+func (h *Handler) DetailJalur(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID jalur tidak valid", nil)
+		return
+	}
+	idPengguna, _ := r.Context().Value(ContextUserID).(int64)
+	data, err := h.EdukasiUsecase.DetailJalur(r.Context(), id, idPengguna)
+	if err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil jalur belajar", err.Error())
+		return
+	}
+	if data == nil {
+		ResponGagal(w, http.StatusNotFound, "Jalur belajar tidak ditemukan", nil)
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Detail jalur belajar berhasil diambil", data)
+}
+
+func (h *Handler) AdminDaftarJalur(w http.ResponseWriter, r *http.Request) {
+	data, err := h.AdminUsecase.DaftarJalur(r.Context())
+	if err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil jalur belajar", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Daftar jalur belajar berhasil diambil", data)
+}
+
+// AdminBuatJalur menerima kelas sebagai larik {"id_kelas": ...}; urutan larik
+// menjadi urutan kelas dalam jalur.
+func (h *Handler) AdminBuatJalur(w http.ResponseWriter, r *http.Request) {
+	var req domain.JalurBelajar
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	if err := h.AdminUsecase.BuatJalur(r.Context(), &req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal membuat jalur belajar", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusCreated, "Jalur belajar berhasil dibuat", req)
+}
+
+func (h *Handler) AdminPerbaruiJalur(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID jalur tidak valid", nil)
+		return
+	}
+	var req domain.JalurBelajar
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	req.ID = id
+	if err := h.AdminUsecase.PerbaruiJalur(r.Context(), &req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal memperbarui jalur belajar", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Jalur belajar berhasil diperbarui", req)
+}
+
+func (h *Handler) AdminHapusJalur(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID jalur tidak valid", nil)
+		return
+	}
+	if err := h.AdminUsecase.HapusJalur(r.Context(), id); err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal menghapus jalur belajar", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Jalur belajar berhasil dihapus", nil)
+}
+
+func (h *Handler) AdminDaftarPrasyaratKelas(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID kelas tidak valid", nil)
+		return
+	}
+	data, err := h.AdminUsecase.DaftarPrasyaratKelas(r.Context(), id)
+	if err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil prasyarat kelas", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Prasyarat kelas berhasil diambil", data)
+}
+
+// AdminSimpanPrasyaratKelas mengganti seluruh prasyarat kelas dengan
+// {"id_prasyarat": [...]}; larik kosong menghapus semua prasyarat.
+func (h *Handler) AdminSimpanPrasyaratKelas(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID kelas tidak valid", nil)
+		return
+	}
+	var req struct {
+		IDPrasyarat []int64 `json:"id_prasyarat"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	if err := h.AdminUsecase.SimpanPrasyaratKelas(r.Context(), id, req.IDPrasyarat); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal menyimpan prasyarat kelas", err.Error())
+		return
+	}
+	data, err := h.AdminUsecase.DaftarPrasyaratKelas(r.Context(), id)
+	if err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil prasyarat kelas", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Prasyarat kelas berhasil disimpan", data)
+}
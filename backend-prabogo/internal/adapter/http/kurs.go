@@ -0,0 +1,62 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func (h *Handler) DaftarKurs(w http.ResponseWriter, r *http.Request) {
+	data, err := h.KursUsecase.Daftar(r.Context())
+	if err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil kurs", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Daftar kurs berhasil diambil", data)
+}
+
+func (h *Handler) AdminSimpanKurs(w http.ResponseWriter, r *http.Request) {
+	var req domain.Kurs
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	if err := h.KursUsecase.Simpan(r.Context(), &req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal menyimpan kurs", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusCreated, "Kurs berhasil disimpan", req)
+}
+
+func (h *Handler) AdminSinkronKurs(w http.ResponseWriter, r *http.Request) {
+	jumlah, err := h.KursUsecase.Sinkronkan(r.Context())
+	if err != nil {
+		ResponGagal(w, http.StatusBadGateway, "Gagal sinkronisasi kurs", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Sinkronisasi kurs berhasil", map[string]interface{}{
+		"jumlah_kurs": jumlah,
+	})
+}
+
+type mataUangRequest struct {
+	MataUang string `json:"mata_uang"`
+}
+
+func (h *Handler) PerbaruiMataUang(w http.ResponseWriter, r *http.Request) {
+	var req mataUangRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	kode, err := h.AuthUsecase.PerbaruiMataUang(r.Context(), idPengguna, req.MataUang)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal memperbarui mata uang", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Mata uang tampilan berhasil diperbarui", map[string]interface{}{
+		"mata_uang": kode,
+	})
+}
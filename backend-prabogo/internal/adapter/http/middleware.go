@@ -66,3 +66,19 @@ func AuthMiddleware(secret string) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// OpsionalAuthMiddleware meneruskan permintaan tanpa token apa adanya, dan
+// memvalidasi token seperti AuthMiddleware bila header Authorization dikirim.
+func OpsionalAuthMiddleware(secret string) func(http.Handler) http.Handler {
+	wajib := AuthMiddleware(secret)
+	return func(next http.Handler) http.Handler {
+		auth := wajib(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			auth.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) DaftarTransaksiPortofolio(w http.ResponseWriter, r *http.Request) {
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.PortofolioUsecase.DaftarTransaksi(r.Context(), idPengguna, r.URL.Query().Get("simbol"))
+	if err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil transaksi portofolio", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Transaksi portofolio berhasil diambil", data)
+}
+
+func (h *Handler) TambahTransaksiPortofolio(w http.ResponseWriter, r *http.Request) {
+	var req domain.TransaksiPortofolio
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	req.IDPengguna = r.Context().Value(ContextUserID).(int64)
+	if err := h.PortofolioUsecase.TambahTransaksi(r.Context(), &req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal mencatat transaksi", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusCreated, "Transaksi berhasil dicatat", req)
+}
+
+func (h *Handler) PerbaruiTransaksiPortofolio(w http.ResponseWriter, r *http.Request) {
+	var req domain.TransaksiPortofolio
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID transaksi tidak valid", nil)
+		return
+	}
+	req.ID = id
+	req.IDPengguna = r.Context().Value(ContextUserID).(int64)
+	if err := h.PortofolioUsecase.PerbaruiTransaksi(r.Context(), &req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal memperbarui transaksi", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Transaksi berhasil diperbarui", req)
+}
+
+func (h *Handler) HapusTransaksiPortofolio(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID transaksi tidak valid", nil)
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	if err := h.PortofolioUsecase.HapusTransaksi(r.Context(), id, idPengguna); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal menghapus transaksi", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Transaksi berhasil dihapus", nil)
+}
+
+type nilaiManualRequest struct {
+	Harga        domain.Harga `json:"harga"`
+	NilaiSaatIni domain.Uang  `json:"nilai_saat_ini"`
+	Catatan      string       `json:"catatan"`
+}
+
+func (h *Handler) AturNilaiManualPortofolio(w http.ResponseWriter, r *http.Request) {
+	var req nilaiManualRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID portofolio tidak valid", nil)
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.PortofolioUsecase.AturNilaiManual(r.Context(), idPengguna, id, req.Harga, req.NilaiSaatIni, req.Catatan)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal menyimpan nilai manual", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Nilai manual berhasil disimpan", data)
+}
+
+func (h *Handler) HapusNilaiManualPortofolio(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID portofolio tidak valid", nil)
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	if err := h.PortofolioUsecase.HapusNilaiManual(r.Context(), idPengguna, id); err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal menghapus nilai manual", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Nilai manual berhasil dihapus", nil)
+}
+
+func (h *Handler) KepatuhanPortofolio(w http.ResponseWriter, r *http.Request) {
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.KepatuhanUsecase.Laporan(r.Context(), idPengguna, r.URL.Query().Get("mata_uang"))
+	if err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal menyusun laporan kepatuhan", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Laporan kepatuhan portofolio berhasil disusun", data)
+}
+
+func (h *Handler) AnalitikPortofolio(w http.ResponseWriter, r *http.Request) {
+	dari, err := parseTanggal(r.URL.Query().Get("dari"))
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Format tanggal harus YYYY-MM-DD", nil)
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.AnalitikUsecase.Analitik(r.Context(), idPengguna, r.URL.Query().Get("mata_uang"), dari)
+	if err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal menghitung analitik portofolio", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Analitik portofolio berhasil dihitung", data)
+}
+
+func (h *Handler) RiwayatNilaiPortofolio(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	dari, err := parseTanggal(query.Get("dari"))
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Format tanggal harus YYYY-MM-DD", nil)
+		return
+	}
+	sampai, err := parseTanggal(query.Get("sampai"))
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Format tanggal harus YYYY-MM-DD", nil)
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.PortofolioUsecase.RiwayatNilai(r.Context(), idPengguna, query.Get("mata_uang"), dari, sampai)
+	if err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil riwayat nilai portofolio", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Riwayat nilai portofolio berhasil diambil", data)
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) TandaiMateriSelesai(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID materi tidak valid", nil)
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.EdukasiUsecase.TandaiMateriSelesai(r.Context(), idPengguna, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrKelasBelumDimulai) {
+			ResponGagal(w, http.StatusForbidden, "Mulai kelas terlebih dahulu", err.Error())
+			return
+		}
+		ResponGagal(w, http.StatusBadRequest, "Gagal menandai materi selesai", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Materi ditandai selesai", data)
+}
+
+func (h *Handler) SimpanPosisiVideo(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID materi tidak valid", nil)
+		return
+	}
+	var req struct {
+		PosisiDetik int `json:"posisi_detik"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.EdukasiUsecase.SimpanPosisiVideo(r.Context(), idPengguna, id, req.PosisiDetik)
+	if err != nil {
+		if errors.Is(err, domain.ErrKelasBelumDimulai) {
+			ResponGagal(w, http.StatusForbidden, "Mulai kelas terlebih dahulu", err.Error())
+			return
+		}
+		ResponGagal(w, http.StatusBadRequest, "Gagal menyimpan posisi video", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Posisi video disimpan", data)
+}
@@ -0,0 +1,154 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+	"github.com/averroes/backend-prabogo/internal/usecase"
+)
+
+const intervalHeartbeat = 15 * time.Second
+
+type pesanStream struct {
+	Tipe     string        `json:"tipe"`
+	Data     *domain.Pasar `json:"data,omitempty"`
+	Terlewat int64         `json:"terlewat,omitempty"`
+	Waktu    time.Time     `json:"waktu"`
+}
+
+type permintaanLanggananStream struct {
+	Aksi   string   `json:"aksi"`
+	Simbol []string `json:"simbol"`
+}
+
+// StreamPasar mengirim pembaruan harga lewat Server-Sent Events.
+// Simbol dipilih dengan query ?simbol=AMAN,MZN; kosong berarti semua simbol.
+func (h *Handler) StreamPasar(w http.ResponseWriter, r *http.Request) {
+	if permintaanWebSocket(r) {
+		h.StreamPasarWebSocket(w, r)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		ResponGagal(w, http.StatusInternalServerError, "Streaming tidak didukung", nil)
+		return
+	}
+
+	pelanggan := h.HubHarga.Langganan(parseDaftarSimbol(r.URL.Query().Get("simbol")))
+	defer h.HubHarga.Berhenti(pelanggan)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", (5 * time.Second).Milliseconds())
+
+	kirim := func(pesan pesanStream) error {
+		data, err := json.Marshal(pesan)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", pesan.Tipe, data); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+
+	h.alirkanHarga(r.Context(), pelanggan, kirim)
+}
+
+// StreamPasarWebSocket melayani stream harga yang sama lewat WebSocket. Klien dapat
+// mengganti langganan dengan mengirim {"aksi":"langganan","simbol":["AMAN"]}.
+// Origin lintas situs ditolak kecuali tercantum di STREAM_ORIGIN.
+func (h *Handler) StreamPasarWebSocket(w http.ResponseWriter, r *http.Request) {
+	if !originDiizinkan(r, h.OriginStream) {
+		ResponGagal(w, http.StatusForbidden, "Origin tidak diizinkan", nil)
+		return
+	}
+	conn, err := upgradeWebSocket(w, r)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Permintaan websocket tidak valid", err.Error())
+		return
+	}
+	defer conn.Tutup()
+
+	pelanggan := h.HubHarga.Langganan(parseDaftarSimbol(r.URL.Query().Get("simbol")))
+	defer h.HubHarga.Berhenti(pelanggan)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		defer cancel()
+		for {
+			data, err := conn.BacaPesan()
+			if err != nil {
+				return
+			}
+			var req permintaanLanggananStream
+			if err := json.Unmarshal(data, &req); err != nil {
+				continue
+			}
+			if req.Aksi == "langganan" {
+				pelanggan.AturSimbol(req.Simbol)
+			}
+		}
+	}()
+
+	kirim := func(pesan pesanStream) error {
+		data, err := json.Marshal(pesan)
+		if err != nil {
+			return err
+		}
+		return conn.TulisTeks(data)
+	}
+
+	h.alirkanHarga(ctx, pelanggan, kirim)
+}
+
+func (h *Handler) alirkanHarga(ctx context.Context, pelanggan *usecase.PelangganHarga, kirim func(pesanStream) error) {
+	awal, err := h.ScreenerUsecase.Pasar(ctx)
+	if err == nil {
+		for i := range awal {
+			if !pelanggan.Mengikuti(awal[i].Simbol) {
+				continue
+			}
+			if err := kirim(pesanStream{Tipe: "harga", Data: &awal[i], Waktu: time.Now()}); err != nil {
+				return
+			}
+		}
+	}
+
+	heartbeat := time.NewTicker(intervalHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if err := kirim(pesanStream{Tipe: "heartbeat", Waktu: time.Now()}); err != nil {
+				return
+			}
+		case pasar := <-pelanggan.Kanal():
+			pesan := pesanStream{Tipe: "harga", Data: &pasar, Terlewat: pelanggan.Terlewat(), Waktu: time.Now()}
+			if err := kirim(pesan); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func parseDaftarSimbol(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
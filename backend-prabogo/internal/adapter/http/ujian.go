@@ -0,0 +1,157 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+type permintaanJawaban struct {
+	Jawaban []domain.JawabanSoal `json:"jawaban"`
+}
+
+func (h *Handler) MulaiUjian(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID ujian tidak valid", nil)
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.EdukasiUsecase.MulaiUjian(r.Context(), id, idPengguna)
+	if err != nil {
+		if errors.Is(err, domain.ErrPercobaanDibatasi) {
+			ResponGagal(w, http.StatusForbidden, "Percobaan ujian belum dapat dimulai", err.Error())
+			return
+		}
+		if errors.Is(err, domain.ErrKelasBelumDimulai) {
+			ResponGagal(w, http.StatusForbidden, "Mulai kelas terlebih dahulu", err.Error())
+			return
+		}
+		ResponGagal(w, http.StatusBadRequest, "Gagal memulai ujian", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Ujian dimulai", data)
+}
+
+func (h *Handler) SimpanJawabanUjian(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID ujian tidak valid", nil)
+		return
+	}
+	var req permintaanJawaban
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.EdukasiUsecase.SimpanJawaban(r.Context(), id, idPengguna, req.Jawaban)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal menyimpan jawaban", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Jawaban berhasil disimpan", data)
+}
+
+func (h *Handler) KirimUjian(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID ujian tidak valid", nil)
+		return
+	}
+	var req permintaanJawaban
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.EdukasiUsecase.KirimUjian(r.Context(), id, idPengguna, req.Jawaban)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal mengirim ujian", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Ujian berhasil dinilai", data)
+}
+
+func (h *Handler) HasilUjian(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID ujian tidak valid", nil)
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.EdukasiUsecase.HasilUjian(r.Context(), id, idPengguna)
+	if err != nil {
+		ResponGagal(w, http.StatusNotFound, "Hasil ujian belum tersedia", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Hasil ujian berhasil diambil", data)
+}
+
+func (h *Handler) AdminDaftarSoalUjian(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID ujian tidak valid", nil)
+		return
+	}
+	data, err := h.EdukasiUsecase.DaftarSoalUjian(r.Context(), id)
+	if err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil soal", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Daftar soal berhasil diambil", data)
+}
+
+func (h *Handler) AdminBuatSoalUjian(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID ujian tidak valid", nil)
+		return
+	}
+	var req domain.SoalUjian
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	req.IDUjian = id
+	if err := h.AdminUsecase.BuatSoalUjian(r.Context(), &req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal membuat soal", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusCreated, "Soal berhasil dibuat", req)
+}
+
+func (h *Handler) AdminPerbaruiSoalUjian(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID soal tidak valid", nil)
+		return
+	}
+	var req domain.SoalUjian
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	req.ID = id
+	if err := h.AdminUsecase.PerbaruiSoalUjian(r.Context(), &req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal memperbarui soal", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Soal berhasil diperbarui", req)
+}
+
+func (h *Handler) AdminHapusSoalUjian(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID soal tidak valid", nil)
+		return
+	}
+	if err := h.AdminUsecase.HapusSoalUjian(r.Context(), id); err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal menghapus soal", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Soal berhasil dihapus", nil)
+}
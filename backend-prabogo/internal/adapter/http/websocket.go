@@ -0,0 +1,209 @@
+package http
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Implementasi WebSocket (RFC 6455) minimal untuk kebutuhan stream harga:
+// hanya sisi server, tanpa ekstensi kompresi.
+
+const (
+	websocketGUID        = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	websocketMaksPayload = 64 * 1024
+
+	opLanjutan = 0x0
+	opTeks     = 0x1
+	opBiner    = 0x2
+	opTutup    = 0x8
+	opPing     = 0x9
+	opPong     = 0xA
+)
+
+var errWebSocketDitutup = errors.New("koneksi websocket ditutup")
+
+type koneksiWebSocket struct {
+	conn    net.Conn
+	rw      *bufio.ReadWriter
+	tulisMu sync.Mutex
+}
+
+func permintaanWebSocket(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
+}
+
+// originDiizinkan mencegah situs lain membuka WebSocket atas nama pengguna.
+// Browser selalu mengirim Origin, sehingga permintaan tanpa Origin (klien
+// non-browser) dan dari host server sendiri diterima; origin lain harus ada
+// di daftar izin. "*" mengizinkan semua origin.
+func originDiizinkan(r *http.Request, izin []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	for _, o := range izin {
+		if o == "*" || strings.EqualFold(strings.TrimSuffix(o, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request) (*koneksiWebSocket, error) {
+	if r.Method != http.MethodGet || !permintaanWebSocket(r) {
+		return nil, errors.New("bukan permintaan websocket")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		return nil, errors.New("versi websocket tidak didukung")
+	}
+	kunci := strings.TrimSpace(r.Header.Get("Sec-WebSocket-Key"))
+	if kunci == "" {
+		return nil, errors.New("Sec-WebSocket-Key tidak ditemukan")
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, errors.New("server tidak mendukung hijack koneksi")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha1.New()
+	h.Write([]byte(kunci + websocketGUID))
+	terima := base64.StdEncoding.EncodeToString(h.Sum(nil))
+
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n")
+	rw.WriteString("Upgrade: websocket\r\n")
+	rw.WriteString("Connection: Upgrade\r\n")
+	rw.WriteString("Sec-WebSocket-Accept: " + terima + "\r\n\r\n")
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &koneksiWebSocket{conn: conn, rw: rw}, nil
+}
+
+func (c *koneksiWebSocket) TulisTeks(data []byte) error {
+	return c.tulisFrame(opTeks, data)
+}
+
+func (c *koneksiWebSocket) tulisFrame(opcode byte, data []byte) error {
+	c.tulisMu.Lock()
+	defer c.tulisMu.Unlock()
+
+	header := make([]byte, 0, 10)
+	header = append(header, 0x80|opcode)
+	switch n := len(data); {
+	case n <= 125:
+		header = append(header, byte(n))
+	case n <= 0xFFFF:
+		header = append(header, 126, byte(n>>8), byte(n))
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+
+	c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	if _, err := c.rw.Write(header); err != nil {
+		return err
+	}
+	if _, err := c.rw.Write(data); err != nil {
+		return err
+	}
+	return c.rw.Flush()
+}
+
+// BacaPesan membaca satu pesan data dari klien. Frame ping dijawab otomatis
+// dan frame tutup menghasilkan errWebSocketDitutup.
+func (c *koneksiWebSocket) BacaPesan() ([]byte, error) {
+	var pesan []byte
+	for {
+		var kepala [2]byte
+		if _, err := io.ReadFull(c.rw, kepala[:]); err != nil {
+			return nil, err
+		}
+		fin := kepala[0]&0x80 != 0
+		opcode := kepala[0] & 0x0F
+		bertopeng := kepala[1]&0x80 != 0
+		panjang := uint64(kepala[1] & 0x7F)
+
+		switch panjang {
+		case 126:
+			var b [2]byte
+			if _, err := io.ReadFull(c.rw, b[:]); err != nil {
+				return nil, err
+			}
+			panjang = uint64(binary.BigEndian.Uint16(b[:]))
+		case 127:
+			var b [8]byte
+			if _, err := io.ReadFull(c.rw, b[:]); err != nil {
+				return nil, err
+			}
+			panjang = binary.BigEndian.Uint64(b[:])
+		}
+		if !bertopeng {
+			return nil, errors.New("frame klien wajib bertopeng")
+		}
+		if panjang > websocketMaksPayload || uint64(len(pesan))+panjang > websocketMaksPayload {
+			c.tulisFrame(opTutup, []byte{0x03, 0xF1})
+			return nil, errors.New("pesan websocket terlalu besar")
+		}
+
+		var topeng [4]byte
+		if _, err := io.ReadFull(c.rw, topeng[:]); err != nil {
+			return nil, err
+		}
+		payload := make([]byte, panjang)
+		if _, err := io.ReadFull(c.rw, payload); err != nil {
+			return nil, err
+		}
+		for i := range payload {
+			payload[i] ^= topeng[i%4]
+		}
+
+		switch opcode {
+		case opPing:
+			if err := c.tulisFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case opPong:
+			continue
+		case opTutup:
+			c.tulisFrame(opTutup, nil)
+			return nil, errWebSocketDitutup
+		case opTeks, opBiner, opLanjutan:
+			pesan = append(pesan, payload...)
+			if fin {
+				return pesan, nil
+			}
+		default:
+			return nil, errors.New("opcode websocket tidak dikenal")
+		}
+	}
+}
+
+func (c *koneksiWebSocket) Tutup() error {
+	c.tulisFrame(opTutup, []byte{0x03, 0xE8})
+	return c.conn.Close()
+}
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOriginDiizinkan(t *testing.T) {
+	tests := []struct {
+		nama   string
+		origin string
+		izin   []string
+		ingin  bool
+	}{
+		{"tanpa origin", "", nil, true},
+		{"origin server sendiri", "https://api.averroes.id", nil, true},
+		{"origin lain ditolak", "https://jahat.example", nil, false},
+		{"origin tercantum", "https://app.averroes.id", []string{"https://app.averroes.id/"}, true},
+		{"skema berbeda ditolak", "http://app.averroes.id", []string{"https://app.averroes.id"}, false},
+		{"wildcard", "https://mana.saja", []string{"*"}, true},
+		{"origin null ditolak", "null", []string{"https://app.averroes.id"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "https://api.averroes.id/api/v1/pasar/ws", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if got := originDiizinkan(r, tt.izin); got != tt.ingin {
+				t.Errorf("originDiizinkan(%q) = %v, ingin %v", tt.origin, got, tt.ingin)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) SimpanPerhitunganZakat(w http.ResponseWriter, r *http.Request) {
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.ZakatUsecase.Simpan(r.Context(), idPengguna, r.URL.Query().Get("mata_uang"), r.URL.Query().Get("standar_nisab"))
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal menyimpan perhitungan zakat", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Perhitungan zakat berhasil disimpan", data)
+}
+
+func (h *Handler) PDFRiwayatZakat(w http.ResponseWriter, r *http.Request) {
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID riwayat tidak valid", nil)
+		return
+	}
+	isi, nama, err := h.ZakatUsecase.PDFRiwayat(r.Context(), id, idPengguna)
+	if err != nil {
+		ResponGagal(w, http.StatusNotFound, "Gagal membuat laporan zakat", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+nama+`"`)
+	w.Header().Set("Content-Length", strconv.Itoa(len(isi)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(isi)
+}
+
+func (h *Handler) HaulZakat(w http.ResponseWriter, r *http.Request) {
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.ZakatUsecase.Haul(r.Context(), idPengguna, r.URL.Query().Get("mata_uang"), r.URL.Query().Get("standar_nisab"))
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal menghitung haul", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Status haul berhasil dihitung", data)
+}
+
+func (h *Handler) HitungZakatPenghasilan(w http.ResponseWriter, r *http.Request) {
+	var req domain.MasukanZakatPenghasilan
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.ZakatUsecase.HitungPenghasilan(r.Context(), idPengguna, req)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal menghitung zakat penghasilan", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Zakat penghasilan berhasil dihitung dan disimpan", data)
+}
+
+func (h *Handler) HitungZakatPerdagangan(w http.ResponseWriter, r *http.Request) {
+	var req domain.MasukanZakatPerdagangan
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.ZakatUsecase.HitungPerdagangan(r.Context(), idPengguna, req)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal menghitung zakat perdagangan", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Zakat perdagangan berhasil dihitung dan disimpan", data)
+}
+
+func (h *Handler) HitungZakatFitrah(w http.ResponseWriter, r *http.Request) {
+	var req domain.MasukanZakatFitrah
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.ZakatUsecase.HitungFitrah(r.Context(), idPengguna, req)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal menghitung zakat fitrah", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Zakat fitrah berhasil dihitung dan disimpan", data)
+}
+
+func (h *Handler) HitungZakatEmasPerak(w http.ResponseWriter, r *http.Request) {
+	var req domain.MasukanZakatEmasPerak
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.ZakatUsecase.HitungEmasPerak(r.Context(), idPengguna, req)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal menghitung zakat emas dan perak", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Zakat emas dan perak berhasil dihitung dan disimpan", data)
+}
+
+type standarNisabRequest struct {
+	StandarNisab string `json:"standar_nisab"`
+}
+
+func (h *Handler) PerbaruiStandarNisab(w http.ResponseWriter, r *http.Request) {
+	var req standarNisabRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	standar, err := h.AuthUsecase.PerbaruiStandarNisab(r.Context(), idPengguna, req.StandarNisab)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal memperbarui standar nisab", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Standar nisab berhasil diperbarui", map[string]interface{}{
+		"standar_nisab": standar,
+	})
+}
+
+func (h *Handler) DaftarHartaZakat(w http.ResponseWriter, r *http.Request) {
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.ZakatUsecase.DaftarHarta(r.Context(), idPengguna)
+	if err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil harta zakat", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Harta zakat berhasil diambil", data)
+}
+
+func (h *Handler) TambahHartaZakat(w http.ResponseWriter, r *http.Request) {
+	var req domain.HartaZakat
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	req.IDPengguna = r.Context().Value(ContextUserID).(int64)
+	data, err := h.ZakatUsecase.TambahHarta(r.Context(), &req)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal menambah harta zakat", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusCreated, "Harta zakat berhasil ditambahkan", data)
+}
+
+func (h *Handler) PerbaruiHartaZakat(w http.ResponseWriter, r *http.Request) {
+	var req domain.HartaZakat
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID harta tidak valid", nil)
+		return
+	}
+	req.ID = id
+	req.IDPengguna = r.Context().Value(ContextUserID).(int64)
+	data, err := h.ZakatUsecase.PerbaruiHarta(r.Context(), &req)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal memperbarui harta zakat", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Harta zakat berhasil diperbarui", data)
+}
+
+func (h *Handler) HapusHartaZakat(w http.ResponseWriter, r *http.Request) {
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID harta tidak valid", nil)
+		return
+	}
+	if err := h.ZakatUsecase.HapusHarta(r.Context(), id, idPengguna); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal menghapus harta zakat", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Harta zakat berhasil dihapus", nil)
+}
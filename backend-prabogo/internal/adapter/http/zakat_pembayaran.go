@@ -0,0 +1,110 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) DaftarPembayaranZakat(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	dari, err := parseTanggal(query.Get("dari"))
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Format tanggal harus YYYY-MM-DD", nil)
+		return
+	}
+	sampai, err := parseTanggal(query.Get("sampai"))
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Format tanggal harus YYYY-MM-DD", nil)
+		return
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.ZakatUsecase.DaftarPembayaran(r.Context(), idPengguna, dari, sampai)
+	if err != nil {
+		ResponGagal(w, http.StatusInternalServerError, "Gagal mengambil pembayaran zakat", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Pembayaran zakat berhasil diambil", data)
+}
+
+func (h *Handler) TambahPembayaranZakat(w http.ResponseWriter, r *http.Request) {
+	var req domain.PembayaranZakat
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	req.IDPengguna = r.Context().Value(ContextUserID).(int64)
+	data, err := h.ZakatUsecase.TambahPembayaran(r.Context(), &req)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal mencatat pembayaran zakat", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusCreated, "Pembayaran zakat berhasil dicatat", data)
+}
+
+func (h *Handler) PerbaruiPembayaranZakat(w http.ResponseWriter, r *http.Request) {
+	var req domain.PembayaranZakat
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Data tidak valid", err.Error())
+		return
+	}
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID pembayaran tidak valid", nil)
+		return
+	}
+	req.ID = id
+	req.IDPengguna = r.Context().Value(ContextUserID).(int64)
+	data, err := h.ZakatUsecase.PerbaruiPembayaran(r.Context(), &req)
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal memperbarui pembayaran zakat", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Pembayaran zakat berhasil diperbarui", data)
+}
+
+func (h *Handler) HapusPembayaranZakat(w http.ResponseWriter, r *http.Request) {
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	id, err := parseID(mux.Vars(r)["id"])
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "ID pembayaran tidak valid", nil)
+		return
+	}
+	if err := h.ZakatUsecase.HapusPembayaran(r.Context(), id, idPengguna); err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal menghapus pembayaran zakat", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Pembayaran zakat berhasil dihapus", nil)
+}
+
+func (h *Handler) StatusPembayaranZakat(w http.ResponseWriter, r *http.Request) {
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.ZakatUsecase.StatusPembayaran(r.Context(), idPengguna, r.URL.Query().Get("mata_uang"), r.URL.Query().Get("standar_nisab"))
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal menghitung status pembayaran zakat", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Status pembayaran zakat berhasil dihitung", data)
+}
+
+func (h *Handler) LaporanZakatTahunan(w http.ResponseWriter, r *http.Request) {
+	var tahun int
+	if nilai := r.URL.Query().Get("tahun"); nilai != "" {
+		var err error
+		tahun, err = strconv.Atoi(nilai)
+		if err != nil {
+			ResponGagal(w, http.StatusBadRequest, "Tahun tidak valid", nil)
+			return
+		}
+	}
+	idPengguna := r.Context().Value(ContextUserID).(int64)
+	data, err := h.ZakatUsecase.LaporanTahunan(r.Context(), idPengguna, tahun, r.URL.Query().Get("mata_uang"))
+	if err != nil {
+		ResponGagal(w, http.StatusBadRequest, "Gagal menyusun laporan zakat tahunan", err.Error())
+		return
+	}
+	ResponSukses(w, http.StatusOK, "Laporan zakat tahunan berhasil disusun", data)
+}
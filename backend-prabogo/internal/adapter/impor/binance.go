@@ -0,0 +1,123 @@
+package impor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+// kuotasiBinance diurutkan dari yang terpanjang agar "FDUSD" tidak terbaca "USD".
+var kuotasiBinance = []string{"FDUSD", "USDT", "USDC", "BUSD", "TUSD", "BIDR", "IDR", "USD", "DAI", "BTC", "ETH", "BNB"}
+
+// Binance membaca ekspor riwayat trade Binance (Date(UTC), Pair, Side,
+// Price, Executed, Amount, Fee). Waktu pada ekspor Binance selalu UTC.
+type Binance struct{}
+
+func (Binance) Format() string { return "binance" }
+
+func (Binance) Urai(data []byte) ([]domain.BarisImpor, error) {
+	t, err := bacaTabel(data)
+	if err != nil {
+		return nil, err
+	}
+	kTanggal := t.indeks("dateutc", "date_utc", "time")
+	kPair := t.indeks("pair", "market", "symbol")
+	kSisi := t.indeks("side", "type")
+	kHarga := t.indeks("price")
+	kJumlah := t.indeks("executed", "filled")
+	kBiaya := t.indeks("fee")
+	if kTanggal < 0 || kPair < 0 || kSisi < 0 || kHarga < 0 || kJumlah < 0 {
+		return nil, domain.ErrFormatTidakCocok
+	}
+
+	var hasil []domain.BarisImpor
+	for i, b := range t.baris {
+		nomor := i + 2
+		tanggal, err := parseWaktu(nilaiKolom(b, kTanggal), time.UTC)
+		if err != nil {
+			hasil = append(hasil, barisGagal(nomor, err))
+			continue
+		}
+		jenis := jenisDariSisi(nilaiKolom(b, kSisi))
+		if jenis != domain.TransaksiBeli && jenis != domain.TransaksiJual {
+			hasil = append(hasil, barisGagal(nomor, errors.New("sisi transaksi harus BUY atau SELL")))
+			continue
+		}
+		dasar, kuotasi := pisahPairBinance(nilaiKolom(b, kPair))
+		mataUang := mataUangKuotasi(kuotasi)
+		if dasar == "" || mataUang == "" {
+			hasil = append(hasil, barisGagal(nomor, fmt.Errorf("pair %q tidak didukung", nilaiKolom(b, kPair))))
+			continue
+		}
+		harga, err := parseHarga(nilaiKolom(b, kHarga))
+		if err != nil {
+			hasil = append(hasil, barisGagal(nomor, err))
+			continue
+		}
+		angkaJumlah, _ := pisahSatuan(nilaiKolom(b, kJumlah))
+		jumlah, err := parseJumlah(angkaJumlah)
+		if err != nil {
+			hasil = append(hasil, barisGagal(nomor, err))
+			continue
+		}
+		angkaBiaya, asetBiaya := pisahSatuan(nilaiKolom(b, kBiaya))
+		nilaiBiaya, err := parseJumlah(angkaBiaya)
+		if err != nil {
+			hasil = append(hasil, barisGagal(nomor, err))
+			continue
+		}
+		biaya, err := domain.UangDariFloat(nilaiBiaya)
+		if err != nil {
+			hasil = append(hasil, barisGagal(nomor, err))
+			continue
+		}
+		var pesan string
+		switch asetBiaya {
+		case "", kuotasi:
+		case dasar:
+			// Biaya dalam aset dasar dikonversi ke mata uang kuotasi pada harga trade.
+			if biaya, err = harga.Kali(nilaiBiaya); err != nil {
+				hasil = append(hasil, barisGagal(nomor, err))
+				continue
+			}
+		default:
+			// Biaya dalam token lain (mis. BNB) tidak punya harga di baris ini,
+			// jadi baris tetap diimpor tanpa biaya dan pengguna diberi tahu.
+			if nilaiBiaya > 0 {
+				pesan = fmt.Sprintf("biaya %s %s tidak dicatat karena dibayar di luar pair; tambahkan manual bila perlu", angkaBiaya, asetBiaya)
+			}
+			biaya = 0
+		}
+		hasil = append(hasil, domain.BarisImpor{
+			Nomor:  nomor,
+			Status: domain.BarisBaru,
+			Pesan:  pesan,
+			Transaksi: domain.TransaksiPortofolio{
+				Jenis:    jenis,
+				NamaAset: dasar,
+				Simbol:   dasar,
+				Kategori: "kripto",
+				Jumlah:   jumlah,
+				Harga:    harga,
+				Biaya:    biaya,
+				MataUang: mataUang,
+				Tanggal:  tanggal,
+				Catatan:  "Impor binance",
+			},
+		})
+	}
+	return hasil, nil
+}
+
+func pisahPairBinance(pair string) (string, string) {
+	pair = strings.ToUpper(strings.NewReplacer("/", "", "-", "", "_", "").Replace(strings.TrimSpace(pair)))
+	for _, k := range kuotasiBinance {
+		if strings.HasSuffix(pair, k) && len(pair) > len(k) {
+			return strings.TrimSuffix(pair, k), k
+		}
+	}
+	return "", ""
+}
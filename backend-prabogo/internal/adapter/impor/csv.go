@@ -0,0 +1,212 @@
+package impor
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+// Semua mengembalikan pengurai yang didukung, format khusus lebih dulu agar
+// deteksi otomatis tidak jatuh ke CSV umum.
+func Semua() []domain.PenguraiMutasi {
+	return []domain.PenguraiMutasi{Binance{}, Indodax{}, CSVUmum{}}
+}
+
+type tabel struct {
+	kolom map[string]int
+	baris [][]string
+}
+
+// bacaTabel membaca CSV berpemisah koma atau titik koma (ekspor Excel
+// berlokal Indonesia) dan memetakan nama kolom yang sudah dinormalisasi.
+func bacaTabel(data []byte) (*tabel, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	barisPertama := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		barisPertama = data[:i]
+	}
+	r := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(barisPertama, []byte(";")) > bytes.Count(barisPertama, []byte(",")) {
+		r.Comma = ';'
+	}
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	semua, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("berkas CSV tidak valid: %w", err)
+	}
+	if len(semua) == 0 {
+		return nil, errors.New("berkas kosong")
+	}
+	t := &tabel{kolom: map[string]int{}}
+	for i, nama := range semua[0] {
+		t.kolom[normalisasiKolom(nama)] = i
+	}
+	for _, b := range semua[1:] {
+		if len(b) == 1 && strings.TrimSpace(b[0]) == "" {
+			continue
+		}
+		t.baris = append(t.baris, b)
+	}
+	return t, nil
+}
+
+func normalisasiKolom(nama string) string {
+	nama = strings.ToLower(strings.TrimSpace(nama))
+	var sb strings.Builder
+	for _, c := range nama {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+			sb.WriteRune(c)
+		case c == ' ' || c == '_' || c == '-':
+			sb.WriteRune('_')
+		}
+	}
+	return strings.Trim(sb.String(), "_")
+}
+
+// indeks mengembalikan posisi kolom pertama yang cocok dengan salah satu alias, atau -1.
+func (t *tabel) indeks(alias ...string) int {
+	for _, a := range alias {
+		if i, ok := t.kolom[a]; ok {
+			return i
+		}
+	}
+	return -1
+}
+
+func nilaiKolom(baris []string, i int) string {
+	if i < 0 || i >= len(baris) {
+		return ""
+	}
+	return strings.TrimSpace(baris[i])
+}
+
+// normalisasiAngka menerima titik sebagai desimal dan mengabaikan pemisah ribuan koma.
+// Bila hanya ada koma (gaya Indonesia), koma dianggap desimal dan titik sebagai ribuan.
+func normalisasiAngka(s string) string {
+	s = strings.TrimSpace(strings.ReplaceAll(s, " ", ""))
+	switch {
+	case strings.Contains(s, ",") && !strings.Contains(s, "."):
+		s = strings.ReplaceAll(s, ",", ".")
+	case strings.Contains(s, ",") && strings.LastIndex(s, ",") > strings.LastIndex(s, "."):
+		s = strings.ReplaceAll(s, ".", "")
+		s = strings.ReplaceAll(s, ",", ".")
+	case strings.Count(s, ".") > 1:
+		s = strings.ReplaceAll(s, ".", "")
+	default:
+		s = strings.ReplaceAll(s, ",", "")
+	}
+	return s
+}
+
+func parseAngka(s string) (domain.Uang, error) {
+	s = normalisasiAngka(s)
+	if s == "" {
+		return 0, nil
+	}
+	return domain.UangDariString(s)
+}
+
+// parseHarga mengurai harga per unit dengan skala harga agar harga token yang
+// sangat kecil tidak terbulatkan menjadi nol.
+func parseHarga(s string) (domain.Harga, error) {
+	s = normalisasiAngka(s)
+	if s == "" {
+		return 0, nil
+	}
+	return domain.HargaDariString(s)
+}
+
+// parseJumlah mengurai jumlah unit sebagai float64 karena jumlah kripto bisa
+// lebih presisi dari empat digit desimal Uang.
+func parseJumlah(s string) (float64, error) {
+	s = normalisasiAngka(s)
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("jumlah %q tidak valid", s)
+	}
+	return n, nil
+}
+
+// pisahSatuan memisahkan angka dan kode aset seperti "0.0010BTC" atau "30.5 USDT".
+func pisahSatuan(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	i := len(s)
+	for i > 0 {
+		c := s[i-1]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') {
+			i--
+			continue
+		}
+		break
+	}
+	return strings.TrimSpace(s[:i]), strings.ToUpper(s[i:])
+}
+
+var formatWaktu = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"02/01/2006 15:04:05",
+	"02/01/2006 15:04",
+	"02/01/2006",
+	"02-01-2006 15:04:05",
+	"02-01-2006",
+}
+
+func parseWaktu(s string, lokasi *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range formatWaktu {
+		if t, err := time.ParseInLocation(layout, s, lokasi); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("tanggal %q tidak dikenali", s)
+}
+
+// jenisDariSisi memetakan istilah sisi transaksi berbagai platform ke jenis transaksi.
+func jenisDariSisi(s string) string {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "beli", "buy", "b":
+		return domain.TransaksiBeli
+	case "jual", "sell", "s":
+		return domain.TransaksiJual
+	case "dividen", "dividend", "bagi_hasil", "staking", "reward":
+		return domain.TransaksiDividen
+	case "transfer_masuk", "deposit", "masuk":
+		return domain.TransaksiTransferMasuk
+	case "transfer_keluar", "withdraw", "withdrawal", "keluar":
+		return domain.TransaksiTransferKeluar
+	}
+	return ""
+}
+
+// mataUangKuotasi memetakan aset kuotasi ke kode mata uang; stablecoin dolar dianggap USD.
+func mataUangKuotasi(kode string) string {
+	switch strings.ToUpper(kode) {
+	case "IDR", "BIDR":
+		return domain.MataUangIDR
+	case "USD", "USDT", "USDC", "BUSD", "FDUSD", "TUSD", "DAI":
+		return domain.MataUangUSD
+	case "BTC", "ETH", "BNB", "SOL", "XRP", "TRX":
+		// Pasangan berkuotasi kripto tidak punya kurs fiat di tabel kurs.
+		return ""
+	}
+	return domain.NormalisasiMataUang(kode, "")
+}
+
+func barisGagal(nomor int, err error) domain.BarisImpor {
+	return domain.BarisImpor{Nomor: nomor, Status: domain.BarisGagal, Pesan: err.Error()}
+}
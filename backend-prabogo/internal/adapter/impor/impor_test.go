@@ -0,0 +1,149 @@
+package impor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func bacaFixture(t *testing.T, nama string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", nama))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func hargaUji(t *testing.T, s string) domain.Harga {
+	t.Helper()
+	h, err := domain.HargaDariString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func uangUji(t *testing.T, s string) domain.Uang {
+	t.Helper()
+	u, err := domain.UangDariString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+// barisUji adalah harapan satu baris hasil urai; Pesan dicocokkan sebagai
+// potongan teks.
+type barisUji struct {
+	nomor    int
+	status   string
+	pesan    string
+	jenis    string
+	simbol   string
+	mataUang string
+	jumlah   float64
+	harga    string
+	biaya    string
+	tanggal  time.Time
+}
+
+func cekBaris(t *testing.T, hasil []domain.BarisImpor, ingin []barisUji) {
+	t.Helper()
+	if len(hasil) != len(ingin) {
+		t.Fatalf("jumlah baris = %d, ingin %d", len(hasil), len(ingin))
+	}
+	for i, w := range ingin {
+		b := hasil[i]
+		if b.Nomor != w.nomor || b.Status != w.status {
+			t.Errorf("baris %d: nomor %d status %q, ingin %d %q (%s)", i, b.Nomor, b.Status, w.nomor, w.status, b.Pesan)
+			continue
+		}
+		if w.pesan == "" && b.Pesan != "" || !strings.Contains(b.Pesan, w.pesan) {
+			t.Errorf("baris %d: pesan %q, ingin memuat %q", b.Nomor, b.Pesan, w.pesan)
+		}
+		if w.status == domain.BarisGagal {
+			continue
+		}
+		tr := b.Transaksi
+		if tr.Jenis != w.jenis || tr.Simbol != w.simbol || tr.MataUang != w.mataUang || tr.Jumlah != w.jumlah {
+			t.Errorf("baris %d: %s %s %s %v, ingin %s %s %s %v", b.Nomor, tr.Jenis, tr.Simbol, tr.MataUang, tr.Jumlah, w.jenis, w.simbol, w.mataUang, w.jumlah)
+		}
+		if tr.Harga != hargaUji(t, w.harga) {
+			t.Errorf("baris %d: harga %s, ingin %s", b.Nomor, tr.Harga, w.harga)
+		}
+		if tr.Biaya != uangUji(t, w.biaya) {
+			t.Errorf("baris %d: biaya %s, ingin %s", b.Nomor, tr.Biaya, w.biaya)
+		}
+		if !tr.Tanggal.Equal(w.tanggal) {
+			t.Errorf("baris %d: tanggal %s, ingin %s", b.Nomor, tr.Tanggal, w.tanggal)
+		}
+	}
+}
+
+func TestBinanceUrai(t *testing.T) {
+	hasil, err := Binance{}.Urai(bacaFixture(t, "binance.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cekBaris(t, hasil, []barisUji{
+		// Biaya dalam aset dasar dikonversi pada harga trade: 0.000001 x 42000.5.
+		{nomor: 2, status: domain.BarisBaru, jenis: domain.TransaksiBeli, simbol: "BTC", mataUang: "USD", jumlah: 0.001, harga: "42000.5", biaya: "0.042", tanggal: time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC)},
+		// Biaya BNB tidak punya harga di baris ini; baris tetap masuk dengan peringatan.
+		{nomor: 3, status: domain.BarisBaru, pesan: "0.00075 BNB", jenis: domain.TransaksiBeli, simbol: "PEPE", mataUang: "USD", jumlah: 1000000, harga: "0.00000123", biaya: "0", tanggal: time.Date(2024, 1, 6, 11, 30, 0, 0, time.UTC)},
+		{nomor: 4, status: domain.BarisGagal, pesan: "tidak didukung"},
+		{nomor: 5, status: domain.BarisBaru, jenis: domain.TransaksiJual, simbol: "BTC", mataUang: "USD", jumlah: 0.0005, harga: "43000", biaya: "0.0215", tanggal: time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC)},
+	})
+}
+
+func TestIndodaxUrai(t *testing.T) {
+	hasil, err := Indodax{}.Urai(bacaFixture(t, "indodax.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cekBaris(t, hasil, []barisUji{
+		{nomor: 2, status: domain.BarisBaru, jenis: domain.TransaksiBeli, simbol: "BTC", mataUang: "IDR", jumlah: 0.01, harga: "650000000", biaya: "19500", tanggal: time.Date(2024, 2, 1, 1, 0, 0, 0, time.UTC)},
+		{nomor: 3, status: domain.BarisBaru, jenis: domain.TransaksiJual, simbol: "ETH", mataUang: "IDR", jumlah: 0.5, harga: "40000000", biaya: "6000", tanggal: time.Date(2024, 2, 2, 2, 15, 0, 0, time.UTC)},
+		{nomor: 4, status: domain.BarisGagal, pesan: "buy atau sell"},
+	})
+}
+
+func TestCSVUmumUrai(t *testing.T) {
+	hasil, err := CSVUmum{}.Urai(bacaFixture(t, "umum.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cekBaris(t, hasil, []barisUji{
+		{nomor: 2, status: domain.BarisBaru, jenis: domain.TransaksiBeli, simbol: "BBCA", mataUang: "IDR", jumlah: 100, harga: "9500.5", biaya: "1250", tanggal: time.Date(2024, 2, 29, 17, 0, 0, 0, time.UTC)},
+		{nomor: 3, status: domain.BarisBaru, jenis: domain.TransaksiDividen, simbol: "BBCA", mataUang: "IDR", jumlah: 0, harga: "17500", biaya: "0", tanggal: time.Date(2024, 3, 4, 17, 0, 0, 0, time.UTC)},
+		{nomor: 4, status: domain.BarisGagal, pesan: "jenis transaksi"},
+		{nomor: 5, status: domain.BarisGagal, pesan: "tidak dikenali"},
+	})
+}
+
+// Deteksi otomatis memakai pengurai pertama yang tidak menolak header.
+func TestDeteksiFormat(t *testing.T) {
+	for berkas, ingin := range map[string]string{
+		"binance.csv": "binance",
+		"indodax.csv": "indodax",
+		"umum.csv":    "csv",
+	} {
+		data := bacaFixture(t, berkas)
+		var format string
+		for _, p := range Semua() {
+			if _, err := p.Urai(data); errors.Is(err, domain.ErrFormatTidakCocok) {
+				continue
+			}
+			format = p.Format()
+			break
+		}
+		if format != ingin {
+			t.Errorf("%s terdeteksi sebagai %q, ingin %q", berkas, format, ingin)
+		}
+	}
+}
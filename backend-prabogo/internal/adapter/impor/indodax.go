@@ -0,0 +1,102 @@
+package impor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+// Indodax membaca ekspor riwayat trade Indodax dengan pair berformat
+// "btc_idr". Waktu pada ekspor Indodax dalam WIB.
+type Indodax struct{}
+
+func (Indodax) Format() string { return "indodax" }
+
+func (Indodax) Urai(data []byte) ([]domain.BarisImpor, error) {
+	t, err := bacaTabel(data)
+	if err != nil {
+		return nil, err
+	}
+	kTanggal := t.indeks("time", "date", "tanggal", "waktu")
+	kPair := t.indeks("pair", "market")
+	kSisi := t.indeks("type", "side", "tipe")
+	kHarga := t.indeks("price", "harga")
+	kJumlah := t.indeks("amount", "jumlah")
+	kBiaya := t.indeks("fee", "biaya")
+	if kTanggal < 0 || kPair < 0 || kSisi < 0 || kHarga < 0 || kJumlah < 0 {
+		return nil, domain.ErrFormatTidakCocok
+	}
+	// Pair berformat dasar_kuotasi adalah ciri khas Indodax; tanpanya berkas
+	// diserahkan ke pengurai lain.
+	if len(t.baris) > 0 && !strings.Contains(nilaiKolom(t.baris[0], kPair), "_") {
+		return nil, domain.ErrFormatTidakCocok
+	}
+
+	var hasil []domain.BarisImpor
+	for i, b := range t.baris {
+		nomor := i + 2
+		tanggal, err := parseWaktu(nilaiKolom(b, kTanggal), zonaWIB)
+		if err != nil {
+			hasil = append(hasil, barisGagal(nomor, err))
+			continue
+		}
+		jenis := jenisDariSisi(nilaiKolom(b, kSisi))
+		if jenis != domain.TransaksiBeli && jenis != domain.TransaksiJual {
+			hasil = append(hasil, barisGagal(nomor, errors.New("tipe transaksi harus buy atau sell")))
+			continue
+		}
+		dasar, kuotasi, _ := strings.Cut(strings.ToUpper(nilaiKolom(b, kPair)), "_")
+		mataUang := mataUangKuotasi(kuotasi)
+		if dasar == "" || mataUang == "" {
+			hasil = append(hasil, barisGagal(nomor, fmt.Errorf("pair %q tidak didukung", nilaiKolom(b, kPair))))
+			continue
+		}
+		harga, err := parseHarga(nilaiKolom(b, kHarga))
+		if err != nil {
+			hasil = append(hasil, barisGagal(nomor, err))
+			continue
+		}
+		angkaJumlah, _ := pisahSatuan(nilaiKolom(b, kJumlah))
+		jumlah, err := parseJumlah(angkaJumlah)
+		if err != nil {
+			hasil = append(hasil, barisGagal(nomor, err))
+			continue
+		}
+		angkaBiaya, asetBiaya := pisahSatuan(nilaiKolom(b, kBiaya))
+		nilaiBiaya, err := parseJumlah(angkaBiaya)
+		if err != nil {
+			hasil = append(hasil, barisGagal(nomor, err))
+			continue
+		}
+		biaya, err := domain.UangDariFloat(nilaiBiaya)
+		if err != nil {
+			hasil = append(hasil, barisGagal(nomor, err))
+			continue
+		}
+		if asetBiaya == dasar {
+			if biaya, err = harga.Kali(nilaiBiaya); err != nil {
+				hasil = append(hasil, barisGagal(nomor, err))
+				continue
+			}
+		}
+		hasil = append(hasil, domain.BarisImpor{
+			Nomor:  nomor,
+			Status: domain.BarisBaru,
+			Transaksi: domain.TransaksiPortofolio{
+				Jenis:    jenis,
+				NamaAset: dasar,
+				Simbol:   dasar,
+				Kategori: "kripto",
+				Jumlah:   jumlah,
+				Harga:    harga,
+				Biaya:    biaya,
+				MataUang: mataUang,
+				Tanggal:  tanggal,
+				Catatan:  "Impor indodax",
+			},
+		})
+	}
+	return hasil, nil
+}
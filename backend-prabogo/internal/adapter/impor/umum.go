@@ -0,0 +1,86 @@
+package impor
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+// CSVUmum membaca CSV dengan kolom yang sama dengan transaksi portofolio:
+// tanggal, jenis, simbol, jumlah, harga serta opsional nama_aset, kategori,
+// biaya, mata_uang dan catatan. Tanggal tanpa zona waktu dianggap WIB.
+type CSVUmum struct{}
+
+func (CSVUmum) Format() string { return "csv" }
+
+func (CSVUmum) Urai(data []byte) ([]domain.BarisImpor, error) {
+	t, err := bacaTabel(data)
+	if err != nil {
+		return nil, err
+	}
+	kTanggal := t.indeks("tanggal", "date", "waktu", "time")
+	kJenis := t.indeks("jenis", "type", "side", "tipe")
+	kSimbol := t.indeks("simbol", "symbol", "kode", "ticker")
+	kJumlah := t.indeks("jumlah", "quantity", "qty", "amount", "lot")
+	kHarga := t.indeks("harga", "price")
+	if kTanggal < 0 || kJenis < 0 || kSimbol < 0 || kJumlah < 0 || kHarga < 0 {
+		return nil, domain.ErrFormatTidakCocok
+	}
+	kNama := t.indeks("nama_aset", "nama", "name")
+	kKategori := t.indeks("kategori", "category")
+	kBiaya := t.indeks("biaya", "fee", "komisi")
+	kMataUang := t.indeks("mata_uang", "currency", "kurs")
+	kCatatan := t.indeks("catatan", "note", "keterangan")
+
+	var hasil []domain.BarisImpor
+	for i, b := range t.baris {
+		nomor := i + 2
+		tanggal, err := parseWaktu(nilaiKolom(b, kTanggal), zonaWIB)
+		if err != nil {
+			hasil = append(hasil, barisGagal(nomor, err))
+			continue
+		}
+		jenis := jenisDariSisi(nilaiKolom(b, kJenis))
+		if jenis == "" {
+			hasil = append(hasil, barisGagal(nomor, errors.New("jenis transaksi tidak dikenali")))
+			continue
+		}
+		jumlah, err := parseJumlah(nilaiKolom(b, kJumlah))
+		if err != nil {
+			hasil = append(hasil, barisGagal(nomor, err))
+			continue
+		}
+		harga, err := parseHarga(nilaiKolom(b, kHarga))
+		if err != nil {
+			hasil = append(hasil, barisGagal(nomor, err))
+			continue
+		}
+		biaya, err := parseAngka(nilaiKolom(b, kBiaya))
+		if err != nil {
+			hasil = append(hasil, barisGagal(nomor, err))
+			continue
+		}
+		simbol := strings.ToUpper(nilaiKolom(b, kSimbol))
+		hasil = append(hasil, domain.BarisImpor{
+			Nomor:  nomor,
+			Status: domain.BarisBaru,
+			Transaksi: domain.TransaksiPortofolio{
+				Jenis:    jenis,
+				NamaAset: nilaiKolom(b, kNama),
+				Simbol:   simbol,
+				Kategori: nilaiKolom(b, kKategori),
+				Jumlah:   jumlah,
+				Harga:    harga,
+				Biaya:    biaya,
+				MataUang: domain.NormalisasiMataUang(nilaiKolom(b, kMataUang), domain.MataUangIDR),
+				Tanggal:  tanggal,
+				Catatan:  nilaiKolom(b, kCatatan),
+			},
+		})
+	}
+	return hasil, nil
+}
+
+var zonaWIB = time.FixedZone("WIB", 7*60*60)
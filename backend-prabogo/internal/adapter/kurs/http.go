@@ -0,0 +1,84 @@
+package kurs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// PenyediaHTTP mengambil kurs dari API publik yang mengembalikan objek
+// {"base": "USD", "rates": {"IDR": 16250}}. Penanda {dasar} pada URL diganti
+// dengan kode mata uang dasar.
+type PenyediaHTTP struct {
+	url    string
+	client *http.Client
+}
+
+func NewPenyediaHTTP(url string) *PenyediaHTTP {
+	return &PenyediaHTTP{
+		url:    url,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+type responsKurs struct {
+	Result          string             `json:"result"`
+	Base            string             `json:"base"`
+	BaseCode        string             `json:"base_code"`
+	Rates           map[string]float64 `json:"rates"`
+	ConversionRates map[string]float64 `json:"conversion_rates"`
+}
+
+func (p *PenyediaHTTP) Nama() string {
+	u, err := url.Parse(p.url)
+	if err != nil || u.Host == "" {
+		return "http"
+	}
+	return u.Host
+}
+
+func (p *PenyediaHTTP) AmbilKurs(ctx context.Context, dasar string) (map[string]float64, error) {
+	alamat := strings.ReplaceAll(p.url, "{dasar}", url.PathEscape(dasar))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, alamat, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menghubungi penyedia kurs: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("penyedia kurs merespons status %d", resp.StatusCode)
+	}
+
+	var data responsKurs
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("respons kurs tidak valid: %w", err)
+	}
+	if data.Result != "" && data.Result != "success" {
+		return nil, fmt.Errorf("penyedia kurs gagal: %s", data.Result)
+	}
+	base := data.BaseCode
+	if base == "" {
+		base = data.Base
+	}
+	if base != "" && !strings.EqualFold(base, dasar) {
+		return nil, fmt.Errorf("kurs dasar %s tidak sesuai permintaan %s", base, dasar)
+	}
+	rates := data.Rates
+	if len(rates) == 0 {
+		rates = data.ConversionRates
+	}
+	if len(rates) == 0 {
+		return nil, errors.New("respons kurs kosong")
+	}
+	return rates, nil
+}
@@ -7,7 +7,7 @@ import (
 )
 
 func (r *Repository) DaftarPengguna(ctx context.Context) ([]domain.Pengguna, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT id, nama, email, kata_sandi_hash, peran, status, sudah_verifikasi, dibuat_pada, diubah_pada FROM pengguna ORDER BY dibuat_pada DESC`)
+	rows, err := r.db.QueryContext(ctx, `SELECT id, nama, email, kata_sandi_hash, peran, status, sudah_verifikasi, mata_uang, dibuat_pada, diubah_pada FROM pengguna ORDER BY dibuat_pada DESC`)
 	if err != nil {
 		return nil, err
 	}
@@ -15,7 +15,7 @@ func (r *Repository) DaftarPengguna(ctx context.Context) ([]domain.Pengguna, err
 	var items []domain.Pengguna
 	for rows.Next() {
 		var item domain.Pengguna
-		if err := rows.Scan(&item.ID, &item.Nama, &item.Email, &item.KataSandiHash, &item.Peran, &item.Status, &item.SudahVerifikasi, &item.DibuatPada, &item.DiubahPada); err != nil {
+		if err := rows.Scan(&item.ID, &item.Nama, &item.Email, &item.KataSandiHash, &item.Peran, &item.Status, &item.SudahVerifikasi, &item.MataUang, &item.DibuatPada, &item.DiubahPada); err != nil {
 			return nil, err
 		}
 		items = append(items, item)
@@ -19,11 +19,11 @@ func (r *Repository) BuatPengguna(ctx context.Context, pengguna *domain.Pengguna
 }
 
 func (r *Repository) CariPenggunaByEmail(ctx context.Context, email string) (*domain.Pengguna, error) {
-	query := `SELECT id, nama, email, kata_sandi_hash, peran, status, sudah_verifikasi, dibuat_pada, diubah_pada
+	query := `SELECT id, nama, email, kata_sandi_hash, peran, status, sudah_verifikasi, mata_uang, standar_nisab, dibuat_pada, diubah_pada
 		FROM pengguna WHERE email = ? LIMIT 1`
 	row := r.db.QueryRowContext(ctx, query, email)
 	pengguna := &domain.Pengguna{}
-	if err := row.Scan(&pengguna.ID, &pengguna.Nama, &pengguna.Email, &pengguna.KataSandiHash, &pengguna.Peran, &pengguna.Status, &pengguna.SudahVerifikasi, &pengguna.DibuatPada, &pengguna.DiubahPada); err != nil {
+	if err := row.Scan(&pengguna.ID, &pengguna.Nama, &pengguna.Email, &pengguna.KataSandiHash, &pengguna.Peran, &pengguna.Status, &pengguna.SudahVerifikasi, &pengguna.MataUang, &pengguna.StandarNisab, &pengguna.DibuatPada, &pengguna.DiubahPada); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
@@ -33,11 +33,11 @@ func (r *Repository) CariPenggunaByEmail(ctx context.Context, email string) (*do
 }
 
 func (r *Repository) AmbilPenggunaByID(ctx context.Context, id int64) (*domain.Pengguna, error) {
-	query := `SELECT id, nama, email, kata_sandi_hash, peran, status, sudah_verifikasi, dibuat_pada, diubah_pada
+	query := `SELECT id, nama, email, kata_sandi_hash, peran, status, sudah_verifikasi, mata_uang, standar_nisab, dibuat_pada, diubah_pada
 		FROM pengguna WHERE id = ? LIMIT 1`
 	row := r.db.QueryRowContext(ctx, query, id)
 	pengguna := &domain.Pengguna{}
-	if err := row.Scan(&pengguna.ID, &pengguna.Nama, &pengguna.Email, &pengguna.KataSandiHash, &pengguna.Peran, &pengguna.Status, &pengguna.SudahVerifikasi, &pengguna.DibuatPada, &pengguna.DiubahPada); err != nil {
+	if err := row.Scan(&pengguna.ID, &pengguna.Nama, &pengguna.Email, &pengguna.KataSandiHash, &pengguna.Peran, &pengguna.Status, &pengguna.SudahVerifikasi, &pengguna.MataUang, &pengguna.StandarNisab, &pengguna.DibuatPada, &pengguna.DiubahPada); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
@@ -77,3 +77,13 @@ func (r *Repository) PerbaruiOTP(ctx context.Context, otp *domain.OTPVerifikasi)
 	_, err := r.db.ExecContext(ctx, query, otp.Kode, otp.KadaluarsaPada, otp.TerakhirKirimPada, otp.JumlahKirim, otp.ID)
 	return err
 }
+
+func (r *Repository) PerbaruiMataUangPengguna(ctx context.Context, id int64, mataUang string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE pengguna SET mata_uang = ?, diubah_pada = NOW() WHERE id = ?`, mataUang, id)
+	return err
+}
+
+func (r *Repository) PerbaruiStandarNisabPengguna(ctx context.Context, id int64, standar string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE pengguna SET standar_nisab = ?, diubah_pada = NOW() WHERE id = ?`, standar, id)
+	return err
+}
@@ -9,7 +9,7 @@ import (
 )
 
 func (r *Repository) DaftarKelas(ctx context.Context) ([]domain.Kelas, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT id, judul, deskripsi, level, jumlah_modul, durasi_menit, thumbnail_url, status, dibuat_pada FROM kelas ORDER BY dibuat_pada DESC`)
+	rows, err := r.db.QueryContext(ctx, `SELECT id, judul, deskripsi, level, `+jumlahModulKelas("kelas", syaratTerbit)+`, `+durasiKelas("kelas", syaratTerbit)+`, thumbnail_url, status, terbit_pada, dibuat_pada FROM kelas WHERE `+syaratTerbit("kelas")+` ORDER BY dibuat_pada DESC`)
 	if err != nil {
 		return nil, err
 	}
@@ -17,28 +17,32 @@ func (r *Repository) DaftarKelas(ctx context.Context) ([]domain.Kelas, error) {
 	var items []domain.Kelas
 	for rows.Next() {
 		var item domain.Kelas
-		if err := rows.Scan(&item.ID, &item.Judul, &item.Deskripsi, &item.Level, &item.JumlahModul, &item.DurasiMenit, &item.ThumbnailURL, &item.Status, &item.DibuatPada); err != nil {
+		var terbitPada sql.NullTime
+		if err := rows.Scan(&item.ID, &item.Judul, &item.Deskripsi, &item.Level, &item.JumlahModul, &item.DurasiMenit, &item.ThumbnailURL, &item.Status, &terbitPada, &item.DibuatPada); err != nil {
 			return nil, err
 		}
+		item.TerbitPada = waktuOpsional(terbitPada)
 		items = append(items, item)
 	}
 	return items, nil
 }
 
 func (r *Repository) DetailKelas(ctx context.Context, id int64) (*domain.Kelas, error) {
-	row := r.db.QueryRowContext(ctx, `SELECT id, judul, deskripsi, level, jumlah_modul, durasi_menit, thumbnail_url, status, dibuat_pada FROM kelas WHERE id = ?`, id)
+	row := r.db.QueryRowContext(ctx, `SELECT id, judul, deskripsi, level, `+jumlahModulKelas("kelas", syaratTerbit)+`, `+durasiKelas("kelas", syaratTerbit)+`, thumbnail_url, status, terbit_pada, dibuat_pada FROM kelas WHERE id = ? AND `+syaratTerbit("kelas"), id)
 	var item domain.Kelas
-	if err := row.Scan(&item.ID, &item.Judul, &item.Deskripsi, &item.Level, &item.JumlahModul, &item.DurasiMenit, &item.ThumbnailURL, &item.Status, &item.DibuatPada); err != nil {
+	var terbitPada sql.NullTime
+	if err := row.Scan(&item.ID, &item.Judul, &item.Deskripsi, &item.Level, &item.JumlahModul, &item.DurasiMenit, &item.ThumbnailURL, &item.Status, &terbitPada, &item.DibuatPada); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
 	}
+	item.TerbitPada = waktuOpsional(terbitPada)
 	return &item, nil
 }
 
 func (r *Repository) DaftarModulByKelas(ctx context.Context, idKelas int64) ([]domain.Modul, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT id, id_kelas, judul, urutan, ringkasan, durasi_menit, dibuat_pada FROM modul WHERE id_kelas = ? ORDER BY urutan ASC`, idKelas)
+	rows, err := r.db.QueryContext(ctx, `SELECT id, id_kelas, judul, urutan, ringkasan, `+durasiModul("modul", syaratTerbit)+`, status, terbit_pada, dibuat_pada FROM modul WHERE id_kelas = ? AND `+syaratTerbit("modul")+` ORDER BY urutan ASC`, idKelas)
 	if err != nil {
 		return nil, err
 	}
@@ -46,16 +50,18 @@ func (r *Repository) DaftarModulByKelas(ctx context.Context, idKelas int64) ([]d
 	var items []domain.Modul
 	for rows.Next() {
 		var item domain.Modul
-		if err := rows.Scan(&item.ID, &item.IDKelas, &item.Judul, &item.Urutan, &item.Ringkasan, &item.DurasiMenit, &item.DibuatPada); err != nil {
+		var terbitPada sql.NullTime
+		if err := rows.Scan(&item.ID, &item.IDKelas, &item.Judul, &item.Urutan, &item.Ringkasan, &item.DurasiMenit, &item.Status, &terbitPada, &item.DibuatPada); err != nil {
 			return nil, err
 		}
+		item.TerbitPada = waktuOpsional(terbitPada)
 		items = append(items, item)
 	}
 	return items, nil
 }
 
 func (r *Repository) DaftarMateriByModul(ctx context.Context, idModul int64) ([]domain.Materi, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT id, id_modul, judul, tipe, konten, url_video, durasi_menit, dibuat_pada FROM materi WHERE id_modul = ? ORDER BY id ASC`, idModul)
+	rows, err := r.db.QueryContext(ctx, `SELECT id, id_modul, judul, tipe, konten, url_video, durasi_menit, status, terbit_pada, dibuat_pada FROM materi WHERE id_modul = ? AND `+syaratTerbit("materi")+` ORDER BY id ASC`, idModul)
 	if err != nil {
 		return nil, err
 	}
@@ -63,16 +69,18 @@ func (r *Repository) DaftarMateriByModul(ctx context.Context, idModul int64) ([]
 	var items []domain.Materi
 	for rows.Next() {
 		var item domain.Materi
-		if err := rows.Scan(&item.ID, &item.IDModul, &item.Judul, &item.Tipe, &item.Konten, &item.URLVideo, &item.DurasiMenit, &item.DibuatPada); err != nil {
+		var terbitPada sql.NullTime
+		if err := rows.Scan(&item.ID, &item.IDModul, &item.Judul, &item.Tipe, &item.Konten, &item.URLVideo, &item.DurasiMenit, &item.Status, &terbitPada, &item.DibuatPada); err != nil {
 			return nil, err
 		}
+		item.TerbitPada = waktuOpsional(terbitPada)
 		items = append(items, item)
 	}
 	return items, nil
 }
 
 func (r *Repository) DaftarUjianByKelas(ctx context.Context, idKelas int64) ([]domain.Ujian, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT id, id_kelas, judul, deskripsi, durasi_menit, jumlah_soal, dibuat_pada FROM ujian WHERE id_kelas = ? ORDER BY id ASC`, idKelas)
+	rows, err := r.db.QueryContext(ctx, `SELECT id, id_kelas, judul, deskripsi, durasi_menit, `+jumlahSoalUjian("ujian")+`, nilai_lulus, soal_per_percobaan, maks_percobaan, jeda_menit, dibuat_pada FROM ujian WHERE id_kelas = ? ORDER BY id ASC`, idKelas)
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +88,7 @@ func (r *Repository) DaftarUjianByKelas(ctx context.Context, idKelas int64) ([]d
 	var items []domain.Ujian
 	for rows.Next() {
 		var item domain.Ujian
-		if err := rows.Scan(&item.ID, &item.IDKelas, &item.Judul, &item.Deskripsi, &item.DurasiMenit, &item.JumlahSoal, &item.DibuatPada); err != nil {
+		if err := rows.Scan(&item.ID, &item.IDKelas, &item.Judul, &item.Deskripsi, &item.DurasiMenit, &item.JumlahSoal, &item.NilaiLulus, &item.SoalPerPercobaan, &item.MaksPercobaan, &item.JedaMenit, &item.DibuatPada); err != nil {
 			return nil, err
 		}
 		items = append(items, item)
@@ -89,7 +97,7 @@ func (r *Repository) DaftarUjianByKelas(ctx context.Context, idKelas int64) ([]d
 }
 
 func (r *Repository) DaftarModulSemua(ctx context.Context) ([]domain.Modul, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT id, id_kelas, judul, urutan, ringkasan, durasi_menit, dibuat_pada FROM modul ORDER BY id DESC`)
+	rows, err := r.db.QueryContext(ctx, `SELECT id, id_kelas, judul, urutan, ringkasan, `+durasiModul("modul", syaratBukanArsip)+`, status, terbit_pada, dibuat_pada FROM modul ORDER BY id DESC`)
 	if err != nil {
 		return nil, err
 	}
@@ -97,16 +105,18 @@ func (r *Repository) DaftarModulSemua(ctx context.Context) ([]domain.Modul, erro
 	var items []domain.Modul
 	for rows.Next() {
 		var item domain.Modul
-		if err := rows.Scan(&item.ID, &item.IDKelas, &item.Judul, &item.Urutan, &item.Ringkasan, &item.DurasiMenit, &item.DibuatPada); err != nil {
+		var terbitPada sql.NullTime
+		if err := rows.Scan(&item.ID, &item.IDKelas, &item.Judul, &item.Urutan, &item.Ringkasan, &item.DurasiMenit, &item.Status, &terbitPada, &item.DibuatPada); err != nil {
 			return nil, err
 		}
+		item.TerbitPada = waktuOpsional(terbitPada)
 		items = append(items, item)
 	}
 	return items, nil
 }
 
 func (r *Repository) DaftarMateriSemua(ctx context.Context) ([]domain.Materi, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT id, id_modul, judul, tipe, konten, url_video, durasi_menit, dibuat_pada FROM materi ORDER BY id DESC`)
+	rows, err := r.db.QueryContext(ctx, `SELECT id, id_modul, judul, tipe, konten, url_video, durasi_menit, status, terbit_pada, dibuat_pada FROM materi ORDER BY id DESC`)
 	if err != nil {
 		return nil, err
 	}
@@ -114,16 +124,18 @@ func (r *Repository) DaftarMateriSemua(ctx context.Context) ([]domain.Materi, er
 	var items []domain.Materi
 	for rows.Next() {
 		var item domain.Materi
-		if err := rows.Scan(&item.ID, &item.IDModul, &item.Judul, &item.Tipe, &item.Konten, &item.URLVideo, &item.DurasiMenit, &item.DibuatPada); err != nil {
+		var terbitPada sql.NullTime
+		if err := rows.Scan(&item.ID, &item.IDModul, &item.Judul, &item.Tipe, &item.Konten, &item.URLVideo, &item.DurasiMenit, &item.Status, &terbitPada, &item.DibuatPada); err != nil {
 			return nil, err
 		}
+		item.TerbitPada = waktuOpsional(terbitPada)
 		items = append(items, item)
 	}
 	return items, nil
 }
 
 func (r *Repository) DaftarUjianSemua(ctx context.Context) ([]domain.Ujian, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT id, id_kelas, judul, deskripsi, durasi_menit, jumlah_soal, dibuat_pada FROM ujian ORDER BY id DESC`)
+	rows, err := r.db.QueryContext(ctx, `SELECT id, id_kelas, judul, deskripsi, durasi_menit, `+jumlahSoalUjian("ujian")+`, nilai_lulus, soal_per_percobaan, maks_percobaan, jeda_menit, dibuat_pada FROM ujian ORDER BY id DESC`)
 	if err != nil {
 		return nil, err
 	}
@@ -131,7 +143,7 @@ func (r *Repository) DaftarUjianSemua(ctx context.Context) ([]domain.Ujian, erro
 	var items []domain.Ujian
 	for rows.Next() {
 		var item domain.Ujian
-		if err := rows.Scan(&item.ID, &item.IDKelas, &item.Judul, &item.Deskripsi, &item.DurasiMenit, &item.JumlahSoal, &item.DibuatPada); err != nil {
+		if err := rows.Scan(&item.ID, &item.IDKelas, &item.Judul, &item.Deskripsi, &item.DurasiMenit, &item.JumlahSoal, &item.NilaiLulus, &item.SoalPerPercobaan, &item.MaksPercobaan, &item.JedaMenit, &item.DibuatPada); err != nil {
 			return nil, err
 		}
 		items = append(items, item)
@@ -180,15 +192,27 @@ func (r *Repository) DaftarProgress(ctx context.Context, idPengguna int64) ([]do
 	return items, nil
 }
 
+func (r *Repository) AmbilProgressKelas(ctx context.Context, idPengguna, idKelas int64) (*domain.ProgressKelas, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT id, id_pengguna, id_kelas, persentase, status, terakhir_diakses_pada FROM progress_kelas WHERE id_pengguna = ? AND id_kelas = ?`, idPengguna, idKelas)
+	var item domain.ProgressKelas
+	if err := row.Scan(&item.ID, &item.IDPengguna, &item.IDKelas, &item.Persentase, &item.Status, &item.TerakhirDiaksesPada); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
 func (r *Repository) BuatKelas(ctx context.Context, kelas *domain.Kelas) error {
-	query := `INSERT INTO kelas (judul, deskripsi, level, jumlah_modul, durasi_menit, thumbnail_url, status, dibuat_pada) VALUES (?, ?, ?, ?, ?, ?, ?, NOW())`
-	_, err := r.db.ExecContext(ctx, query, kelas.Judul, kelas.Deskripsi, kelas.Level, kelas.JumlahModul, kelas.DurasiMenit, kelas.ThumbnailURL, kelas.Status)
+	query := `INSERT INTO kelas (judul, deskripsi, level, thumbnail_url, status, terbit_pada, dibuat_pada) VALUES (?, ?, ?, ?, ?, ?, NOW())`
+	_, err := r.db.ExecContext(ctx, query, kelas.Judul, kelas.Deskripsi, kelas.Level, kelas.ThumbnailURL, kelas.Status, kelas.TerbitPada)
 	return err
 }
 
 func (r *Repository) PerbaruiKelas(ctx context.Context, kelas *domain.Kelas) error {
-	query := `UPDATE kelas SET judul = ?, deskripsi = ?, level = ?, jumlah_modul = ?, durasi_menit = ?, thumbnail_url = ?, status = ? WHERE id = ?`
-	_, err := r.db.ExecContext(ctx, query, kelas.Judul, kelas.Deskripsi, kelas.Level, kelas.JumlahModul, kelas.DurasiMenit, kelas.ThumbnailURL, kelas.Status, kelas.ID)
+	query := `UPDATE kelas SET judul = ?, deskripsi = ?, level = ?, thumbnail_url = ?, status = COALESCE(NULLIF(?, ''), status), terbit_pada = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, kelas.Judul, kelas.Deskripsi, kelas.Level, kelas.ThumbnailURL, kelas.Status, kelas.TerbitPada, kelas.ID)
 	return err
 }
 
@@ -198,14 +222,14 @@ func (r *Repository) HapusKelas(ctx context.Context, id int64) error {
 }
 
 func (r *Repository) BuatModul(ctx context.Context, modul *domain.Modul) error {
-	query := `INSERT INTO modul (id_kelas, judul, urutan, ringkasan, durasi_menit, dibuat_pada) VALUES (?, ?, ?, ?, ?, NOW())`
-	_, err := r.db.ExecContext(ctx, query, modul.IDKelas, modul.Judul, modul.Urutan, modul.Ringkasan, modul.DurasiMenit)
+	query := `INSERT INTO modul (id_kelas, judul, urutan, ringkasan, status, terbit_pada, dibuat_pada) VALUES (?, ?, ?, ?, ?, ?, NOW())`
+	_, err := r.db.ExecContext(ctx, query, modul.IDKelas, modul.Judul, modul.Urutan, modul.Ringkasan, modul.Status, modul.TerbitPada)
 	return err
 }
 
 func (r *Repository) PerbaruiModul(ctx context.Context, modul *domain.Modul) error {
-	query := `UPDATE modul SET id_kelas = ?, judul = ?, urutan = ?, ringkasan = ?, durasi_menit = ? WHERE id = ?`
-	_, err := r.db.ExecContext(ctx, query, modul.IDKelas, modul.Judul, modul.Urutan, modul.Ringkasan, modul.DurasiMenit, modul.ID)
+	query := `UPDATE modul SET id_kelas = ?, judul = ?, urutan = ?, ringkasan = ?, status = COALESCE(NULLIF(?, ''), status), terbit_pada = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, modul.IDKelas, modul.Judul, modul.Urutan, modul.Ringkasan, modul.Status, modul.TerbitPada, modul.ID)
 	return err
 }
 
@@ -215,14 +239,14 @@ func (r *Repository) HapusModul(ctx context.Context, id int64) error {
 }
 
 func (r *Repository) BuatMateri(ctx context.Context, materi *domain.Materi) error {
-	query := `INSERT INTO materi (id_modul, judul, tipe, konten, url_video, durasi_menit, dibuat_pada) VALUES (?, ?, ?, ?, ?, ?, NOW())`
-	_, err := r.db.ExecContext(ctx, query, materi.IDModul, materi.Judul, materi.Tipe, materi.Konten, materi.URLVideo, materi.DurasiMenit)
+	query := `INSERT INTO materi (id_modul, judul, tipe, konten, url_video, durasi_menit, status, terbit_pada, dibuat_pada) VALUES (?, ?, ?, ?, ?, ?, ?, ?, NOW())`
+	_, err := r.db.ExecContext(ctx, query, materi.IDModul, materi.Judul, materi.Tipe, materi.Konten, materi.URLVideo, materi.DurasiMenit, materi.Status, materi.TerbitPada)
 	return err
 }
 
 func (r *Repository) PerbaruiMateri(ctx context.Context, materi *domain.Materi) error {
-	query := `UPDATE materi SET id_modul = ?, judul = ?, tipe = ?, konten = ?, url_video = ?, durasi_menit = ? WHERE id = ?`
-	_, err := r.db.ExecContext(ctx, query, materi.IDModul, materi.Judul, materi.Tipe, materi.Konten, materi.URLVideo, materi.DurasiMenit, materi.ID)
+	query := `UPDATE materi SET id_modul = ?, judul = ?, tipe = ?, konten = ?, url_video = ?, durasi_menit = ?, status = COALESCE(NULLIF(?, ''), status), terbit_pada = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, materi.IDModul, materi.Judul, materi.Tipe, materi.Konten, materi.URLVideo, materi.DurasiMenit, materi.Status, materi.TerbitPada, materi.ID)
 	return err
 }
 
@@ -232,14 +256,14 @@ func (r *Repository) HapusMateri(ctx context.Context, id int64) error {
 }
 
 func (r *Repository) BuatUjian(ctx context.Context, ujian *domain.Ujian) error {
-	query := `INSERT INTO ujian (id_kelas, judul, deskripsi, durasi_menit, jumlah_soal, dibuat_pada) VALUES (?, ?, ?, ?, ?, NOW())`
-	_, err := r.db.ExecContext(ctx, query, ujian.IDKelas, ujian.Judul, ujian.Deskripsi, ujian.DurasiMenit, ujian.JumlahSoal)
+	query := `INSERT INTO ujian (id_kelas, judul, deskripsi, durasi_menit, nilai_lulus, soal_per_percobaan, maks_percobaan, jeda_menit, dibuat_pada) VALUES (?, ?, ?, ?, ?, ?, ?, ?, NOW())`
+	_, err := r.db.ExecContext(ctx, query, ujian.IDKelas, ujian.Judul, ujian.Deskripsi, ujian.DurasiMenit, ujian.NilaiLulus, ujian.SoalPerPercobaan, ujian.MaksPercobaan, ujian.JedaMenit)
 	return err
 }
 
 func (r *Repository) PerbaruiUjian(ctx context.Context, ujian *domain.Ujian) error {
-	query := `UPDATE ujian SET id_kelas = ?, judul = ?, deskripsi = ?, durasi_menit = ?, jumlah_soal = ? WHERE id = ?`
-	_, err := r.db.ExecContext(ctx, query, ujian.IDKelas, ujian.Judul, ujian.Deskripsi, ujian.DurasiMenit, ujian.JumlahSoal, ujian.ID)
+	query := `UPDATE ujian SET id_kelas = ?, judul = ?, deskripsi = ?, durasi_menit = ?, nilai_lulus = ?, soal_per_percobaan = ?, maks_percobaan = ?, jeda_menit = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, ujian.IDKelas, ujian.Judul, ujian.Deskripsi, ujian.DurasiMenit, ujian.NilaiLulus, ujian.SoalPerPercobaan, ujian.MaksPercobaan, ujian.JedaMenit, ujian.ID)
 	return err
 }
 
@@ -0,0 +1,99 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func (r *Repository) SimpanImporPortofolio(ctx context.Context, impor *domain.ImporPortofolio) error {
+	baris, err := json.Marshal(impor.Baris)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO portofolio_impor (id_pengguna, format, nama_berkas, status, jumlah_baru, jumlah_duplikat, jumlah_gagal, baris, dibuat_pada, diterapkan_pada) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, impor.IDPengguna, impor.Format, impor.NamaBerkas, impor.Status, impor.JumlahBaru, impor.JumlahDuplikat, impor.JumlahGagal, string(baris), impor.DibuatPada, impor.DiterapkanPada)
+	if err != nil {
+		return err
+	}
+	impor.ID, err = result.LastInsertId()
+	return err
+}
+
+func (r *Repository) AmbilImporPortofolio(ctx context.Context, id int64, idPengguna int64) (*domain.ImporPortofolio, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT id, id_pengguna, format, nama_berkas, status, jumlah_baru, jumlah_duplikat, jumlah_gagal, baris, dibuat_pada, diterapkan_pada FROM portofolio_impor WHERE id = ? AND id_pengguna = ?`, id, idPengguna)
+	var item domain.ImporPortofolio
+	var baris string
+	var diterapkan sql.NullTime
+	if err := row.Scan(&item.ID, &item.IDPengguna, &item.Format, &item.NamaBerkas, &item.Status, &item.JumlahBaru, &item.JumlahDuplikat, &item.JumlahGagal, &baris, &item.DibuatPada, &diterapkan); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(baris), &item.Baris); err != nil {
+		return nil, err
+	}
+	if diterapkan.Valid {
+		item.DiterapkanPada = &diterapkan.Time
+	}
+	return &item, nil
+}
+
+func (r *Repository) PerbaruiImporPortofolio(ctx context.Context, impor *domain.ImporPortofolio) error {
+	baris, err := json.Marshal(impor.Baris)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE portofolio_impor SET status = ?, jumlah_baru = ?, jumlah_duplikat = ?, jumlah_gagal = ?, baris = ?, diterapkan_pada = ? WHERE id = ? AND id_pengguna = ?`
+	_, err = r.db.ExecContext(ctx, query, impor.Status, impor.JumlahBaru, impor.JumlahDuplikat, impor.JumlahGagal, string(baris), impor.DiterapkanPada, impor.ID, impor.IDPengguna)
+	return err
+}
+
+// UbahStatusImporPortofolio mengganti status hanya bila status saat ini masih
+// dari, sehingga dua permintaan terapkan tidak dapat mengklaim impor yang sama.
+func (r *Repository) UbahStatusImporPortofolio(ctx context.Context, id int64, idPengguna int64, dari, ke string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `UPDATE portofolio_impor SET status = ? WHERE id = ? AND id_pengguna = ? AND status = ?`, ke, id, idPengguna, dari)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n == 1, err
+}
+
+// TerapkanImporPortofolio menyimpan transaksi impor dan hasil impor dalam satu
+// transaksi; kegagalan di tengah tidak meninggalkan sebagian transaksi.
+func (r *Repository) TerapkanImporPortofolio(ctx context.Context, impor *domain.ImporPortofolio, transaksi []*domain.TransaksiPortofolio) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	query := `INSERT INTO portofolio_transaksi (id_pengguna, jenis, nama_aset, simbol, kategori, jumlah, harga, biaya, mata_uang, tanggal, catatan, dibuat_pada) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	for _, t := range transaksi {
+		result, err := tx.ExecContext(ctx, query, t.IDPengguna, t.Jenis, t.NamaAset, t.Simbol, t.Kategori, t.Jumlah, t.Harga, t.Biaya, t.MataUang, t.Tanggal, t.Catatan, t.DibuatPada)
+		if err != nil {
+			return err
+		}
+		if t.ID, err = result.LastInsertId(); err != nil {
+			return err
+		}
+	}
+	baris, err := json.Marshal(impor.Baris)
+	if err != nil {
+		return err
+	}
+	update := `UPDATE portofolio_impor SET status = ?, jumlah_baru = ?, jumlah_duplikat = ?, jumlah_gagal = ?, baris = ?, diterapkan_pada = ? WHERE id = ? AND id_pengguna = ?`
+	if _, err := tx.ExecContext(ctx, update, impor.Status, impor.JumlahBaru, impor.JumlahDuplikat, impor.JumlahGagal, string(baris), impor.DiterapkanPada, impor.ID, impor.IDPengguna); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *Repository) HapusImporPortofolio(ctx context.Context, id int64, idPengguna int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM portofolio_impor WHERE id = ? AND id_pengguna = ?`, id, idPengguna)
+	return err
+}
@@ -0,0 +1,190 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+// DaftarPrasyaratKelas mengambil prasyarat kelas yang sudah terbit; prasyarat
+// yang belum atau tidak lagi terbit tidak bisa diambil peserta sehingga
+// diabaikan.
+func (r *Repository) DaftarPrasyaratKelas(ctx context.Context, idKelas int64) ([]domain.PrasyaratKelas, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT p.id_kelas, p.id_prasyarat, k.judul FROM kelas_prasyarat p JOIN kelas k ON k.id = p.id_prasyarat WHERE p.id_kelas = ? AND `+syaratTerbit("k")+` ORDER BY p.id ASC`, idKelas)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []domain.PrasyaratKelas
+	for rows.Next() {
+		var item domain.PrasyaratKelas
+		if err := rows.Scan(&item.IDKelas, &item.IDPrasyarat, &item.JudulPrasyarat); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func (r *Repository) DaftarPrasyaratSemua(ctx context.Context) ([]domain.PrasyaratKelas, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT p.id_kelas, p.id_prasyarat, k.judul FROM kelas_prasyarat p JOIN kelas k ON k.id = p.id_prasyarat ORDER BY p.id_kelas ASC, p.id ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []domain.PrasyaratKelas
+	for rows.Next() {
+		var item domain.PrasyaratKelas
+		if err := rows.Scan(&item.IDKelas, &item.IDPrasyarat, &item.JudulPrasyarat); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// SimpanPrasyaratKelas mengganti seluruh prasyarat kelas dalam satu transaksi.
+func (r *Repository) SimpanPrasyaratKelas(ctx context.Context, idKelas int64, idPrasyarat []int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `DELETE FROM kelas_prasyarat WHERE id_kelas = ?`, idKelas); err != nil {
+		return err
+	}
+	for _, id := range idPrasyarat {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO kelas_prasyarat (id_kelas, id_prasyarat) VALUES (?, ?)`, idKelas, id); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// DaftarJalur mengambil jalur terbit beserta kelas terbitnya dalam dua kueri.
+func (r *Repository) DaftarJalur(ctx context.Context) ([]domain.JalurBelajar, error) {
+	items, err := r.ambilJalur(ctx, `WHERE `+syaratTerbit("j")+` ORDER BY j.dibuat_pada DESC`)
+	if err != nil || len(items) == 0 {
+		return items, err
+	}
+	return items, r.isiKelasJalur(ctx, items, `JOIN jalur_belajar j ON j.id = jk.id_jalur WHERE `+syaratTerbit("j")+` AND `+syaratTerbit("k"))
+}
+
+func (r *Repository) DetailJalur(ctx context.Context, id int64) (*domain.JalurBelajar, error) {
+	items, err := r.ambilJalur(ctx, `WHERE j.id = ? AND `+syaratTerbit("j"), id)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	if err := r.isiKelasJalur(ctx, items, `WHERE jk.id_jalur = ? AND `+syaratTerbit("k"), id); err != nil {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+// DaftarJalurSemua mengambil seluruh jalur dan kelasnya tanpa memandang status untuk admin.
+func (r *Repository) DaftarJalurSemua(ctx context.Context) ([]domain.JalurBelajar, error) {
+	items, err := r.ambilJalur(ctx, `ORDER BY j.dibuat_pada DESC`)
+	if err != nil || len(items) == 0 {
+		return items, err
+	}
+	return items, r.isiKelasJalur(ctx, items, ``)
+}
+
+func (r *Repository) ambilJalur(ctx context.Context, syarat string, args ...any) ([]domain.JalurBelajar, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT j.id, j.judul, j.deskripsi, j.status, j.terbit_pada, j.dibuat_pada FROM jalur_belajar j `+syarat, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []domain.JalurBelajar
+	for rows.Next() {
+		item := domain.JalurBelajar{Kelas: []domain.KelasJalur{}}
+		var terbitPada sql.NullTime
+		if err := rows.Scan(&item.ID, &item.Judul, &item.Deskripsi, &item.Status, &terbitPada, &item.DibuatPada); err != nil {
+			return nil, err
+		}
+		item.TerbitPada = waktuOpsional(terbitPada)
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// isiKelasJalur mengisi kelas setiap jalur dari satu kueri jalur_kelas.
+func (r *Repository) isiKelasJalur(ctx context.Context, jalur []domain.JalurBelajar, syarat string, args ...any) error {
+	rows, err := r.db.QueryContext(ctx, `SELECT jk.id_jalur, jk.urutan, k.id, k.judul, k.level, `+durasiKelas("k", syaratTerbit)+`, k.thumbnail_url FROM jalur_kelas jk JOIN kelas k ON k.id = jk.id_kelas `+syarat+` ORDER BY jk.id_jalur ASC, jk.urutan ASC`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	indeks := make(map[int64]int, len(jalur))
+	for i := range jalur {
+		indeks[jalur[i].ID] = i
+	}
+	for rows.Next() {
+		var idJalur int64
+		var item domain.KelasJalur
+		if err := rows.Scan(&idJalur, &item.Urutan, &item.IDKelas, &item.Judul, &item.Level, &item.DurasiMenit, &item.ThumbnailURL); err != nil {
+			return err
+		}
+		if i, ok := indeks[idJalur]; ok {
+			jalur[i].Kelas = append(jalur[i].Kelas, item)
+		}
+	}
+	return rows.Err()
+}
+
+// BuatJalur menyimpan jalur dan urutan kelasnya dalam satu transaksi.
+func (r *Repository) BuatJalur(ctx context.Context, jalur *domain.JalurBelajar) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	query := `INSERT INTO jalur_belajar (judul, deskripsi, status, terbit_pada, dibuat_pada) VALUES (?, ?, ?, ?, NOW())`
+	result, err := tx.ExecContext(ctx, query, jalur.Judul, jalur.Deskripsi, jalur.Status, jalur.TerbitPada)
+	if err != nil {
+		return err
+	}
+	if jalur.ID, err = result.LastInsertId(); err != nil {
+		return err
+	}
+	if err := simpanKelasJalur(ctx, tx, jalur); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// PerbaruiJalur memperbarui jalur dan mengganti seluruh urutan kelasnya.
+func (r *Repository) PerbaruiJalur(ctx context.Context, jalur *domain.JalurBelajar) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	query := `UPDATE jalur_belajar SET judul = ?, deskripsi = ?, status = COALESCE(NULLIF(?, ''), status), terbit_pada = ? WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, query, jalur.Judul, jalur.Deskripsi, jalur.Status, jalur.TerbitPada, jalur.ID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM jalur_kelas WHERE id_jalur = ?`, jalur.ID); err != nil {
+		return err
+	}
+	if err := simpanKelasJalur(ctx, tx, jalur); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func simpanKelasJalur(ctx context.Context, tx *sql.Tx, jalur *domain.JalurBelajar) error {
+	for _, k := range jalur.Kelas {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO jalur_kelas (id_jalur, id_kelas, urutan) VALUES (?, ?, ?)`, jalur.ID, k.IDKelas, k.Urutan); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Repository) HapusJalur(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM jalur_belajar WHERE id = ?`, id)
+	return err
+}
@@ -0,0 +1,52 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func (r *Repository) SimpanKurs(ctx context.Context, kurs *domain.Kurs) error {
+	query := `INSERT INTO kurs (mata_uang_asal, mata_uang_tujuan, nilai, sumber, tanggal, diperbarui_pada)
+		VALUES (?, ?, ?, ?, ?, NOW()) ON DUPLICATE KEY UPDATE nilai = VALUES(nilai), sumber = VALUES(sumber), diperbarui_pada = VALUES(diperbarui_pada)`
+	_, err := r.db.ExecContext(ctx, query, kurs.MataUangAsal, kurs.MataUangTujuan, kurs.Nilai, kurs.Sumber, kurs.Tanggal)
+	return err
+}
+
+func (r *Repository) DaftarKursTerbaru(ctx context.Context) ([]domain.Kurs, error) {
+	query := `SELECT k.id, k.mata_uang_asal, k.mata_uang_tujuan, k.nilai, k.sumber, k.tanggal, k.diperbarui_pada
+		FROM kurs k
+		JOIN (SELECT mata_uang_asal, mata_uang_tujuan, MAX(tanggal) AS tanggal FROM kurs GROUP BY mata_uang_asal, mata_uang_tujuan) t
+		ON t.mata_uang_asal = k.mata_uang_asal AND t.mata_uang_tujuan = k.mata_uang_tujuan AND t.tanggal = k.tanggal
+		ORDER BY k.mata_uang_asal ASC, k.mata_uang_tujuan ASC`
+	return r.daftarKurs(ctx, query)
+}
+
+// DaftarKursPada mengembalikan kurs terakhir setiap pasangan mata uang yang
+// tercatat pada atau sebelum tanggal.
+func (r *Repository) DaftarKursPada(ctx context.Context, tanggal time.Time) ([]domain.Kurs, error) {
+	query := `SELECT k.id, k.mata_uang_asal, k.mata_uang_tujuan, k.nilai, k.sumber, k.tanggal, k.diperbarui_pada
+		FROM kurs k
+		JOIN (SELECT mata_uang_asal, mata_uang_tujuan, MAX(tanggal) AS tanggal FROM kurs WHERE tanggal <= ? GROUP BY mata_uang_asal, mata_uang_tujuan) t
+		ON t.mata_uang_asal = k.mata_uang_asal AND t.mata_uang_tujuan = k.mata_uang_tujuan AND t.tanggal = k.tanggal
+		ORDER BY k.mata_uang_asal ASC, k.mata_uang_tujuan ASC`
+	return r.daftarKurs(ctx, query, tanggal.Format("2006-01-02"))
+}
+
+func (r *Repository) daftarKurs(ctx context.Context, query string, args ...interface{}) ([]domain.Kurs, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []domain.Kurs
+	for rows.Next() {
+		var item domain.Kurs
+		if err := rows.Scan(&item.ID, &item.MataUangAsal, &item.MataUangTujuan, &item.Nilai, &item.Sumber, &item.Tanggal, &item.DiperbaruiPada); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -3,21 +3,90 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 
 	"github.com/averroes/backend-prabogo/internal/domain"
 )
 
-func (r *Repository) DaftarPortofolio(ctx context.Context, idPengguna int64) ([]domain.Portofolio, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT id, id_pengguna, nama_aset, simbol, jumlah, harga_beli, nilai_saat_ini, kategori, dibuat_pada FROM portofolio WHERE id_pengguna = ? ORDER BY dibuat_pada DESC`, idPengguna)
+func (r *Repository) DaftarTransaksiPortofolio(ctx context.Context, idPengguna int64, simbol string) ([]domain.TransaksiPortofolio, error) {
+	query := `SELECT id, id_pengguna, jenis, nama_aset, simbol, kategori, jumlah, harga, biaya, mata_uang, tanggal, catatan, dibuat_pada FROM portofolio_transaksi WHERE id_pengguna = ?`
+	args := []interface{}{idPengguna}
+	if simbol != "" {
+		query += ` AND simbol = ?`
+		args = append(args, simbol)
+	}
+	query += ` ORDER BY tanggal ASC, id ASC`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []domain.TransaksiPortofolio
+	for rows.Next() {
+		var item domain.TransaksiPortofolio
+		if err := rows.Scan(&item.ID, &item.IDPengguna, &item.Jenis, &item.NamaAset, &item.Simbol, &item.Kategori, &item.Jumlah, &item.Harga, &item.Biaya, &item.MataUang, &item.Tanggal, &item.Catatan, &item.DibuatPada); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *Repository) AmbilTransaksiPortofolio(ctx context.Context, id int64, idPengguna int64) (*domain.TransaksiPortofolio, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT id, id_pengguna, jenis, nama_aset, simbol, kategori, jumlah, harga, biaya, mata_uang, tanggal, catatan, dibuat_pada FROM portofolio_transaksi WHERE id = ? AND id_pengguna = ?`, id, idPengguna)
+	var item domain.TransaksiPortofolio
+	if err := row.Scan(&item.ID, &item.IDPengguna, &item.Jenis, &item.NamaAset, &item.Simbol, &item.Kategori, &item.Jumlah, &item.Harga, &item.Biaya, &item.MataUang, &item.Tanggal, &item.Catatan, &item.DibuatPada); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *Repository) SimpanTransaksiPortofolio(ctx context.Context, transaksi *domain.TransaksiPortofolio) error {
+	query := `INSERT INTO portofolio_transaksi (id_pengguna, jenis, nama_aset, simbol, kategori, jumlah, harga, biaya, mata_uang, tanggal, catatan, dibuat_pada) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, transaksi.IDPengguna, transaksi.Jenis, transaksi.NamaAset, transaksi.Simbol, transaksi.Kategori, transaksi.Jumlah, transaksi.Harga, transaksi.Biaya, transaksi.MataUang, transaksi.Tanggal, transaksi.Catatan, transaksi.DibuatPada)
+	if err != nil {
+		return err
+	}
+	transaksi.ID, err = result.LastInsertId()
+	return err
+}
+
+func (r *Repository) PerbaruiTransaksiPortofolio(ctx context.Context, transaksi *domain.TransaksiPortofolio) error {
+	query := `UPDATE portofolio_transaksi SET jenis = ?, nama_aset = ?, simbol = ?, kategori = ?, jumlah = ?, harga = ?, biaya = ?, mata_uang = ?, tanggal = ?, catatan = ? WHERE id = ? AND id_pengguna = ?`
+	_, err := r.db.ExecContext(ctx, query, transaksi.Jenis, transaksi.NamaAset, transaksi.Simbol, transaksi.Kategori, transaksi.Jumlah, transaksi.Harga, transaksi.Biaya, transaksi.MataUang, transaksi.Tanggal, transaksi.Catatan, transaksi.ID, transaksi.IDPengguna)
+	return err
+}
+
+func (r *Repository) HapusTransaksiPortofolio(ctx context.Context, id int64, idPengguna int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM portofolio_transaksi WHERE id = ? AND id_pengguna = ?`, id, idPengguna)
+	return err
+}
+
+func (r *Repository) PerbaruiAsetPortofolio(ctx context.Context, idPengguna int64, simbol string, mataUang string, namaAset string, kategori string) error {
+	query := `UPDATE portofolio_transaksi SET nama_aset = ?, kategori = ? WHERE id_pengguna = ? AND simbol = ? AND mata_uang = ?`
+	_, err := r.db.ExecContext(ctx, query, namaAset, kategori, idPengguna, simbol, mataUang)
+	return err
+}
+
+func (r *Repository) HapusPosisiPortofolio(ctx context.Context, idPengguna int64, simbol string, mataUang string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM portofolio_transaksi WHERE id_pengguna = ? AND simbol = ? AND mata_uang = ?`, idPengguna, simbol, mataUang)
+	return err
+}
+
+func (r *Repository) DaftarKunciPosisiPortofolio(ctx context.Context, idPengguna int64) ([]domain.KunciPosisiPortofolio, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, id_pengguna, simbol, mata_uang, dibuat_pada FROM portofolio_posisi WHERE id_pengguna = ? ORDER BY id ASC`, idPengguna)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []domain.Portofolio
+	var items []domain.KunciPosisiPortofolio
 	for rows.Next() {
-		var item domain.Portofolio
-		if err := rows.Scan(&item.ID, &item.IDPengguna, &item.NamaAset, &item.Simbol, &item.Jumlah, &item.HargaBeli, &item.NilaiSaatIni, &item.Kategori, &item.DibuatPada); err != nil {
+		var item domain.KunciPosisiPortofolio
+		if err := rows.Scan(&item.ID, &item.IDPengguna, &item.Simbol, &item.MataUang, &item.DibuatPada); err != nil {
 			return nil, err
 		}
 		items = append(items, item)
@@ -25,27 +94,53 @@ func (r *Repository) DaftarPortofolio(ctx context.Context, idPengguna int64) ([]
 	return items, nil
 }
 
-func (r *Repository) SimpanPortofolio(ctx context.Context, portofolio *domain.Portofolio) error {
-	query := `INSERT INTO portofolio (id_pengguna, nama_aset, simbol, jumlah, harga_beli, nilai_saat_ini, kategori, dibuat_pada) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
-	_, err := r.db.ExecContext(ctx, query, portofolio.IDPengguna, portofolio.NamaAset, portofolio.Simbol, portofolio.Jumlah, portofolio.HargaBeli, portofolio.NilaiSaatIni, portofolio.Kategori, portofolio.DibuatPada)
+func (r *Repository) SimpanKunciPosisiPortofolio(ctx context.Context, kunci *domain.KunciPosisiPortofolio) error {
+	query := `INSERT INTO portofolio_posisi (id_pengguna, simbol, mata_uang, dibuat_pada) VALUES (?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, kunci.IDPengguna, kunci.Simbol, kunci.MataUang, kunci.DibuatPada)
+	if err != nil {
+		return err
+	}
+	kunci.ID, err = result.LastInsertId()
 	return err
 }
 
-func (r *Repository) PerbaruiPortofolio(ctx context.Context, portofolio *domain.Portofolio) error {
-	query := `UPDATE portofolio SET nama_aset = ?, simbol = ?, jumlah = ?, harga_beli = ?, nilai_saat_ini = ?, kategori = ? WHERE id = ? AND id_pengguna = ?`
-	_, err := r.db.ExecContext(ctx, query, portofolio.NamaAset, portofolio.Simbol, portofolio.Jumlah, portofolio.HargaBeli, portofolio.NilaiSaatIni, portofolio.Kategori, portofolio.ID, portofolio.IDPengguna)
+func (r *Repository) DaftarNilaiManual(ctx context.Context, idPengguna int64) ([]domain.NilaiManualPortofolio, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, id_pengguna, simbol, mata_uang, harga, catatan, diperbarui_pada FROM portofolio_nilai_manual WHERE id_pengguna = ?`, idPengguna)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []domain.NilaiManualPortofolio
+	for rows.Next() {
+		var item domain.NilaiManualPortofolio
+		if err := rows.Scan(&item.ID, &item.IDPengguna, &item.Simbol, &item.MataUang, &item.Harga, &item.Catatan, &item.DiperbaruiPada); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *Repository) SimpanNilaiManual(ctx context.Context, nilai *domain.NilaiManualPortofolio) error {
+	query := `INSERT INTO portofolio_nilai_manual (id_pengguna, simbol, mata_uang, harga, catatan, diperbarui_pada)
+		VALUES (?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE harga = VALUES(harga), catatan = VALUES(catatan), diperbarui_pada = VALUES(diperbarui_pada)`
+	_, err := r.db.ExecContext(ctx, query, nilai.IDPengguna, nilai.Simbol, nilai.MataUang, nilai.Harga, nilai.Catatan, nilai.DiperbaruiPada)
 	return err
 }
 
-func (r *Repository) HapusPortofolio(ctx context.Context, id int64, idPengguna int64) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM portofolio WHERE id = ? AND id_pengguna = ?`, id, idPengguna)
+func (r *Repository) HapusNilaiManual(ctx context.Context, idPengguna int64, simbol string, mataUang string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM portofolio_nilai_manual WHERE id_pengguna = ? AND simbol = ? AND mata_uang = ?`, idPengguna, simbol, mataUang)
 	return err
 }
 
 func (r *Repository) HargaEmasTerbaru(ctx context.Context) (*domain.HargaEmas, error) {
-	row := r.db.QueryRowContext(ctx, `SELECT id, tanggal, harga_per_gram FROM harga_emas ORDER BY tanggal DESC LIMIT 1`)
+	return r.HargaLogamTerbaru(ctx, domain.NisabEmas)
+}
+
+func (r *Repository) HargaLogamTerbaru(ctx context.Context, logam string) (*domain.HargaEmas, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT id, tanggal, harga_per_gram, mata_uang, logam, sumber FROM harga_emas WHERE logam = ? ORDER BY tanggal DESC, id DESC LIMIT 1`, logam)
 	var item domain.HargaEmas
-	if err := row.Scan(&item.ID, &item.Tanggal, &item.HargaPerGram); err != nil {
+	if err := row.Scan(&item.ID, &item.Tanggal, &item.HargaPerGram, &item.MataUang, &item.Logam, &item.Sumber); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
@@ -54,8 +149,15 @@ func (r *Repository) HargaEmasTerbaru(ctx context.Context) (*domain.HargaEmas, e
 	return &item, nil
 }
 
-func (r *Repository) DaftarRiwayatZakat(ctx context.Context, idPengguna int64) ([]domain.ZakatRiwayat, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT id, id_pengguna, total_nilai, nisab, persen_zakat, zakat_terhitung, dibuat_pada FROM zakat_riwayat WHERE id_pengguna = ? ORDER BY dibuat_pada DESC`, idPengguna)
+func (r *Repository) DaftarRiwayatZakat(ctx context.Context, idPengguna int64, jenis string) ([]domain.ZakatRiwayat, error) {
+	query := `SELECT id, id_pengguna, jenis, total_nilai, nisab, persen_zakat, zakat_terhitung, mata_uang, tanggal, wajib_zakat, harga_emas_per_gram, rincian, masukan, parameter, dibuat_pada FROM zakat_riwayat WHERE id_pengguna = ?`
+	args := []interface{}{idPengguna}
+	if jenis != "" {
+		query += " AND jenis = ?"
+		args = append(args, jenis)
+	}
+	query += " ORDER BY tanggal DESC, id DESC"
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -63,16 +165,80 @@ func (r *Repository) DaftarRiwayatZakat(ctx context.Context, idPengguna int64) (
 	var items []domain.ZakatRiwayat
 	for rows.Next() {
 		var item domain.ZakatRiwayat
-		if err := rows.Scan(&item.ID, &item.IDPengguna, &item.TotalNilai, &item.Nisab, &item.PersenZakat, &item.ZakatTerhitung, &item.DibuatPada); err != nil {
+		var rincian, masukan, parameter sql.NullString
+		if err := rows.Scan(&item.ID, &item.IDPengguna, &item.Jenis, &item.TotalNilai, &item.Nisab, &item.PersenZakat, &item.ZakatTerhitung, &item.MataUang, &item.Tanggal, &item.WajibZakat, &item.HargaEmasPerGram, &rincian, &masukan, &parameter, &item.DibuatPada); err != nil {
 			return nil, err
 		}
+		if rincian.Valid && rincian.String != "" {
+			if err := json.Unmarshal([]byte(rincian.String), &item.Rincian); err != nil {
+				return nil, err
+			}
+		}
+		if masukan.Valid && masukan.String != "" {
+			item.Masukan = json.RawMessage(masukan.String)
+		}
+		if parameter.Valid && parameter.String != "" {
+			if err := json.Unmarshal([]byte(parameter.String), &item.Parameter); err != nil {
+				return nil, err
+			}
+		}
 		items = append(items, item)
 	}
 	return items, nil
 }
 
+func (r *Repository) AmbilRiwayatZakat(ctx context.Context, id int64, idPengguna int64) (*domain.ZakatRiwayat, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT id, id_pengguna, jenis, total_nilai, nisab, persen_zakat, zakat_terhitung, mata_uang, tanggal, wajib_zakat, harga_emas_per_gram, rincian, masukan, parameter, dibuat_pada FROM zakat_riwayat WHERE id = ? AND id_pengguna = ?`, id, idPengguna)
+	var item domain.ZakatRiwayat
+	var rincian, masukan, parameter sql.NullString
+	if err := row.Scan(&item.ID, &item.IDPengguna, &item.Jenis, &item.TotalNilai, &item.Nisab, &item.PersenZakat, &item.ZakatTerhitung, &item.MataUang, &item.Tanggal, &item.WajibZakat, &item.HargaEmasPerGram, &rincian, &masukan, &parameter, &item.DibuatPada); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if rincian.Valid && rincian.String != "" {
+		if err := json.Unmarshal([]byte(rincian.String), &item.Rincian); err != nil {
+			return nil, err
+		}
+	}
+	if masukan.Valid && masukan.String != "" {
+		item.Masukan = json.RawMessage(masukan.String)
+	}
+	if parameter.Valid && parameter.String != "" {
+		if err := json.Unmarshal([]byte(parameter.String), &item.Parameter); err != nil {
+			return nil, err
+		}
+	}
+	return &item, nil
+}
+
+// SimpanRiwayatZakat menimpa perhitungan pengguna untuk jenis zakat dan
+// tanggal yang sama sehingga penyimpanan berulang dalam sehari tetap menghasilkan satu baris.
 func (r *Repository) SimpanRiwayatZakat(ctx context.Context, riwayat *domain.ZakatRiwayat) error {
-	query := `INSERT INTO zakat_riwayat (id_pengguna, total_nilai, nisab, persen_zakat, zakat_terhitung, dibuat_pada) VALUES (?, ?, ?, ?, ?, ?)`
-	_, err := r.db.ExecContext(ctx, query, riwayat.IDPengguna, riwayat.TotalNilai, riwayat.Nisab, riwayat.PersenZakat, riwayat.ZakatTerhitung, riwayat.DibuatPada)
+	rincian, err := json.Marshal(riwayat.Rincian)
+	if err != nil {
+		return err
+	}
+	var masukan, parameter interface{}
+	if len(riwayat.Masukan) > 0 {
+		masukan = string(riwayat.Masukan)
+	}
+	if riwayat.Parameter != nil {
+		data, err := json.Marshal(riwayat.Parameter)
+		if err != nil {
+			return err
+		}
+		parameter = string(data)
+	}
+	query := `INSERT INTO zakat_riwayat (id_pengguna, jenis, tanggal, total_nilai, nisab, persen_zakat, zakat_terhitung, mata_uang, wajib_zakat, harga_emas_per_gram, rincian, masukan, parameter, dibuat_pada)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id), total_nilai = VALUES(total_nilai), nisab = VALUES(nisab),
+		persen_zakat = VALUES(persen_zakat), zakat_terhitung = VALUES(zakat_terhitung), mata_uang = VALUES(mata_uang), wajib_zakat = VALUES(wajib_zakat),
+		harga_emas_per_gram = VALUES(harga_emas_per_gram), rincian = VALUES(rincian), masukan = VALUES(masukan), parameter = VALUES(parameter), dibuat_pada = VALUES(dibuat_pada)`
+	result, err := r.db.ExecContext(ctx, query, riwayat.IDPengguna, riwayat.Jenis, riwayat.Tanggal.Format("2006-01-02"), riwayat.TotalNilai, riwayat.Nisab, riwayat.PersenZakat, riwayat.ZakatTerhitung, riwayat.MataUang, riwayat.WajibZakat, riwayat.HargaEmasPerGram, string(rincian), masukan, parameter, riwayat.DibuatPada)
+	if err != nil {
+		return err
+	}
+	riwayat.ID, err = result.LastInsertId()
 	return err
 }
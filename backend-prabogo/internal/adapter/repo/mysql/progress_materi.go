@@ -0,0 +1,130 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func (r *Repository) DetailModul(ctx context.Context, id int64) (*domain.Modul, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT modul.id, modul.id_kelas, modul.judul, modul.urutan, modul.ringkasan, `+durasiModul("modul", syaratTerbit)+`, modul.status, modul.terbit_pada, modul.dibuat_pada FROM modul
+		JOIN kelas ON kelas.id = modul.id_kelas
+		WHERE modul.id = ? AND `+syaratTerbit("modul")+` AND `+syaratTerbit("kelas"), id)
+	var item domain.Modul
+	var terbitPada sql.NullTime
+	if err := row.Scan(&item.ID, &item.IDKelas, &item.Judul, &item.Urutan, &item.Ringkasan, &item.DurasiMenit, &item.Status, &terbitPada, &item.DibuatPada); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	item.TerbitPada = waktuOpsional(terbitPada)
+	return &item, nil
+}
+
+func (r *Repository) DetailMateri(ctx context.Context, id int64) (*domain.Materi, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT materi.id, materi.id_modul, materi.judul, materi.tipe, materi.konten, materi.url_video, materi.durasi_menit, materi.status, materi.terbit_pada, materi.dibuat_pada FROM materi
+		JOIN modul ON modul.id = materi.id_modul
+		JOIN kelas ON kelas.id = modul.id_kelas
+		WHERE materi.id = ? AND `+syaratTerbit("materi")+` AND `+syaratTerbit("modul")+` AND `+syaratTerbit("kelas"), id)
+	var item domain.Materi
+	var terbitPada sql.NullTime
+	if err := row.Scan(&item.ID, &item.IDModul, &item.Judul, &item.Tipe, &item.Konten, &item.URLVideo, &item.DurasiMenit, &item.Status, &terbitPada, &item.DibuatPada); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	item.TerbitPada = waktuOpsional(terbitPada)
+	return &item, nil
+}
+
+func (r *Repository) DaftarMateriByKelas(ctx context.Context, idKelas int64) ([]domain.Materi, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT m.id, m.id_modul, m.judul, m.tipe, m.konten, m.url_video, m.durasi_menit, m.status, m.terbit_pada, m.dibuat_pada FROM materi m JOIN modul d ON d.id = m.id_modul WHERE d.id_kelas = ? AND `+syaratTerbit("m")+` AND `+syaratTerbit("d")+` ORDER BY d.urutan ASC, m.id ASC`, idKelas)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []domain.Materi
+	for rows.Next() {
+		var item domain.Materi
+		var terbitPada sql.NullTime
+		if err := rows.Scan(&item.ID, &item.IDModul, &item.Judul, &item.Tipe, &item.Konten, &item.URLVideo, &item.DurasiMenit, &item.Status, &terbitPada, &item.DibuatPada); err != nil {
+			return nil, err
+		}
+		item.TerbitPada = waktuOpsional(terbitPada)
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *Repository) AmbilProgressMateri(ctx context.Context, idPengguna, idMateri int64) (*domain.ProgressMateri, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT id, id_pengguna, id_materi, posisi_detik, selesai, selesai_pada, diperbarui_pada FROM progress_materi WHERE id_pengguna = ? AND id_materi = ?`, idPengguna, idMateri)
+	var item domain.ProgressMateri
+	var selesaiPada sql.NullTime
+	if err := row.Scan(&item.ID, &item.IDPengguna, &item.IDMateri, &item.PosisiDetik, &item.Selesai, &selesaiPada, &item.DiperbaruiPada); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if selesaiPada.Valid {
+		item.SelesaiPada = &selesaiPada.Time
+	}
+	return &item, nil
+}
+
+func (r *Repository) DaftarProgressMateri(ctx context.Context, idPengguna, idKelas int64) ([]domain.ProgressMateri, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT p.id, p.id_pengguna, p.id_materi, p.posisi_detik, p.selesai, p.selesai_pada, p.diperbarui_pada FROM progress_materi p
+		JOIN materi m ON m.id = p.id_materi
+		JOIN modul d ON d.id = m.id_modul
+		WHERE p.id_pengguna = ? AND d.id_kelas = ?`, idPengguna, idKelas)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []domain.ProgressMateri
+	for rows.Next() {
+		var item domain.ProgressMateri
+		var selesaiPada sql.NullTime
+		if err := rows.Scan(&item.ID, &item.IDPengguna, &item.IDMateri, &item.PosisiDetik, &item.Selesai, &selesaiPada, &item.DiperbaruiPada); err != nil {
+			return nil, err
+		}
+		if selesaiPada.Valid {
+			item.SelesaiPada = &selesaiPada.Time
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *Repository) SimpanProgressMateri(ctx context.Context, progress *domain.ProgressMateri) error {
+	query := `INSERT INTO progress_materi (id_pengguna, id_materi, posisi_detik, selesai, selesai_pada, diperbarui_pada)
+		VALUES (?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id), posisi_detik = VALUES(posisi_detik), selesai = VALUES(selesai), selesai_pada = VALUES(selesai_pada), diperbarui_pada = VALUES(diperbarui_pada)`
+	result, err := r.db.ExecContext(ctx, query, progress.IDPengguna, progress.IDMateri, progress.PosisiDetik, progress.Selesai, progress.SelesaiPada, progress.DiperbaruiPada)
+	if err != nil {
+		return err
+	}
+	progress.ID, err = result.LastInsertId()
+	return err
+}
+
+// DaftarUjianLulus mengembalikan ID ujian kelas yang pernah dilulusi peserta.
+func (r *Repository) DaftarUjianLulus(ctx context.Context, idPengguna, idKelas int64) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT DISTINCT u.id FROM ujian u JOIN ujian_percobaan p ON p.id_ujian = u.id WHERE u.id_kelas = ? AND p.id_pengguna = ? AND p.lulus = 1`, idKelas, idPengguna)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	return items, nil
+}
@@ -0,0 +1,97 @@
+package mysql
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func (r *Repository) SimpanRiwayatHarga(ctx context.Context, riwayat *domain.RiwayatHarga) error {
+	query := `INSERT INTO pasar_riwayat (simbol, tanggal, harga, mata_uang) VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE harga = VALUES(harga), mata_uang = VALUES(mata_uang)`
+	_, err := r.db.ExecContext(ctx, query, riwayat.Simbol, riwayat.Tanggal.Format("2006-01-02"), riwayat.Harga, riwayat.MataUang)
+	return err
+}
+
+func (r *Repository) DaftarRiwayatHarga(ctx context.Context, simbol []string, sampai time.Time) ([]domain.RiwayatHarga, error) {
+	if len(simbol) == 0 {
+		return nil, nil
+	}
+	placeholder := strings.TrimSuffix(strings.Repeat("?, ", len(simbol)), ", ")
+	args := make([]interface{}, 0, len(simbol)+1)
+	for _, s := range simbol {
+		args = append(args, s)
+	}
+	args = append(args, sampai.Format("2006-01-02"))
+	query := `SELECT id, simbol, tanggal, harga, mata_uang FROM pasar_riwayat WHERE simbol IN (` + placeholder + `) AND tanggal <= ? ORDER BY simbol ASC, tanggal ASC`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []domain.RiwayatHarga
+	for rows.Next() {
+		var item domain.RiwayatHarga
+		if err := rows.Scan(&item.ID, &item.Simbol, &item.Tanggal, &item.Harga, &item.MataUang); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *Repository) DaftarHargaEmas(ctx context.Context, dari, sampai time.Time) ([]domain.HargaEmas, error) {
+	return r.DaftarHargaLogam(ctx, domain.NisabEmas, dari, sampai)
+}
+
+func (r *Repository) DaftarHargaLogam(ctx context.Context, logam string, dari, sampai time.Time) ([]domain.HargaEmas, error) {
+	query := `SELECT id, tanggal, harga_per_gram, mata_uang, logam, sumber FROM harga_emas WHERE logam = ?`
+	args := []interface{}{logam}
+	if !dari.IsZero() {
+		query += " AND tanggal >= ?"
+		args = append(args, dari.Format("2006-01-02"))
+	}
+	if !sampai.IsZero() {
+		query += " AND tanggal <= ?"
+		args = append(args, sampai.Format("2006-01-02"))
+	}
+	query += " ORDER BY tanggal ASC, mata_uang ASC"
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []domain.HargaEmas
+	for rows.Next() {
+		var item domain.HargaEmas
+		if err := rows.Scan(&item.ID, &item.Tanggal, &item.HargaPerGram, &item.MataUang, &item.Logam, &item.Sumber); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *Repository) SimpanHargaLogam(ctx context.Context, harga *domain.HargaEmas) error {
+	query := `INSERT INTO harga_emas (tanggal, harga_per_gram, mata_uang, logam, sumber) VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE harga_per_gram = VALUES(harga_per_gram), sumber = VALUES(sumber), id = LAST_INSERT_ID(id)`
+	result, err := r.db.ExecContext(ctx, query, harga.Tanggal.Format("2006-01-02"), harga.HargaPerGram, harga.MataUang, harga.Logam, harga.Sumber)
+	if err != nil {
+		return err
+	}
+	harga.ID, err = result.LastInsertId()
+	return err
+}
+
+func (r *Repository) PerbaruiHargaLogam(ctx context.Context, harga *domain.HargaEmas) error {
+	query := `UPDATE harga_emas SET tanggal = ?, harga_per_gram = ?, mata_uang = ?, logam = ?, sumber = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, harga.Tanggal.Format("2006-01-02"), harga.HargaPerGram, harga.MataUang, harga.Logam, harga.Sumber, harga.ID)
+	return err
+}
+
+func (r *Repository) HapusHargaLogam(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM harga_emas WHERE id = ?`, id)
+	return err
+}
@@ -7,7 +7,7 @@ import (
 )
 
 func (r *Repository) DaftarScreener(ctx context.Context, kategori, cari string) ([]domain.Screener, error) {
-	query := `SELECT id, nama_aset, simbol, kategori, skor_syariah, keterangan, harga_terakhir, perubahan_24j, dibuat_pada
+	query := `SELECT id, nama_aset, simbol, kategori, kategori_aset, sektor, skor_syariah, keterangan, harga_terakhir, perubahan_24j, dibuat_pada
 		FROM screener WHERE 1=1`
 	args := []interface{}{}
 	if kategori != "" && kategori != "semua" {
@@ -30,7 +30,7 @@ func (r *Repository) DaftarScreener(ctx context.Context, kategori, cari string)
 	var items []domain.Screener
 	for rows.Next() {
 		var item domain.Screener
-		if err := rows.Scan(&item.ID, &item.NamaAset, &item.Simbol, &item.Kategori, &item.SkorSyariah, &item.Keterangan, &item.HargaTerakhir, &item.Perubahan24J, &item.DibuatPada); err != nil {
+		if err := rows.Scan(&item.ID, &item.NamaAset, &item.Simbol, &item.Kategori, &item.KategoriAset, &item.Sektor, &item.SkorSyariah, &item.Keterangan, &item.HargaTerakhir, &item.Perubahan24J, &item.DibuatPada); err != nil {
 			return nil, err
 		}
 		items = append(items, item)
@@ -39,9 +39,9 @@ func (r *Repository) DaftarScreener(ctx context.Context, kategori, cari string)
 }
 
 func (r *Repository) DetailScreener(ctx context.Context, id int64) (*domain.Screener, error) {
-	row := r.db.QueryRowContext(ctx, `SELECT id, nama_aset, simbol, kategori, skor_syariah, keterangan, harga_terakhir, perubahan_24j, dibuat_pada FROM screener WHERE id = ?`, id)
+	row := r.db.QueryRowContext(ctx, `SELECT id, nama_aset, simbol, kategori, kategori_aset, sektor, skor_syariah, keterangan, harga_terakhir, perubahan_24j, dibuat_pada FROM screener WHERE id = ?`, id)
 	var item domain.Screener
-	if err := row.Scan(&item.ID, &item.NamaAset, &item.Simbol, &item.Kategori, &item.SkorSyariah, &item.Keterangan, &item.HargaTerakhir, &item.Perubahan24J, &item.DibuatPada); err != nil {
+	if err := row.Scan(&item.ID, &item.NamaAset, &item.Simbol, &item.Kategori, &item.KategoriAset, &item.Sektor, &item.SkorSyariah, &item.Keterangan, &item.HargaTerakhir, &item.Perubahan24J, &item.DibuatPada); err != nil {
 		if err.Error() == "sql: no rows in result set" {
 			return nil, nil
 		}
@@ -68,7 +68,7 @@ func (r *Repository) DaftarCatatanScreener(ctx context.Context, idScreener int64
 }
 
 func (r *Repository) DaftarPasar(ctx context.Context) ([]domain.Pasar, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT id, nama_aset, simbol, harga, volume_24j, perubahan_24j, kapitalisasi_pasar, diperbarui_pada FROM pasar ORDER BY kapitalisasi_pasar DESC`)
+	rows, err := r.db.QueryContext(ctx, `SELECT id, nama_aset, simbol, kategori_aset, harga, volume_24j, perubahan_24j, kapitalisasi_pasar, mata_uang, diperbarui_pada FROM pasar ORDER BY kapitalisasi_pasar DESC`)
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +76,7 @@ func (r *Repository) DaftarPasar(ctx context.Context) ([]domain.Pasar, error) {
 	var items []domain.Pasar
 	for rows.Next() {
 		var item domain.Pasar
-		if err := rows.Scan(&item.ID, &item.NamaAset, &item.Simbol, &item.Harga, &item.Volume24J, &item.Perubahan24J, &item.KapitalisasiPasar, &item.DiperbaruiPada); err != nil {
+		if err := rows.Scan(&item.ID, &item.NamaAset, &item.Simbol, &item.KategoriAset, &item.Harga, &item.Volume24J, &item.Perubahan24J, &item.KapitalisasiPasar, &item.MataUang, &item.DiperbaruiPada); err != nil {
 			return nil, err
 		}
 		items = append(items, item)
@@ -85,14 +85,14 @@ func (r *Repository) DaftarPasar(ctx context.Context) ([]domain.Pasar, error) {
 }
 
 func (r *Repository) BuatScreener(ctx context.Context, screener *domain.Screener) error {
-	query := `INSERT INTO screener (nama_aset, simbol, kategori, skor_syariah, keterangan, harga_terakhir, perubahan_24j, dibuat_pada) VALUES (?, ?, ?, ?, ?, ?, ?, NOW())`
-	_, err := r.db.ExecContext(ctx, query, screener.NamaAset, screener.Simbol, screener.Kategori, screener.SkorSyariah, screener.Keterangan, screener.HargaTerakhir, screener.Perubahan24J)
+	query := `INSERT INTO screener (nama_aset, simbol, kategori, kategori_aset, sektor, skor_syariah, keterangan, harga_terakhir, perubahan_24j, dibuat_pada) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, NOW())`
+	_, err := r.db.ExecContext(ctx, query, screener.NamaAset, screener.Simbol, screener.Kategori, screener.KategoriAset, screener.Sektor, screener.SkorSyariah, screener.Keterangan, screener.HargaTerakhir, screener.Perubahan24J)
 	return err
 }
 
 func (r *Repository) PerbaruiScreener(ctx context.Context, screener *domain.Screener) error {
-	query := `UPDATE screener SET nama_aset = ?, simbol = ?, kategori = ?, skor_syariah = ?, keterangan = ?, harga_terakhir = ?, perubahan_24j = ? WHERE id = ?`
-	_, err := r.db.ExecContext(ctx, query, screener.NamaAset, screener.Simbol, screener.Kategori, screener.SkorSyariah, screener.Keterangan, screener.HargaTerakhir, screener.Perubahan24J, screener.ID)
+	query := `UPDATE screener SET nama_aset = ?, simbol = ?, kategori = ?, kategori_aset = ?, sektor = ?, skor_syariah = ?, keterangan = ?, harga_terakhir = ?, perubahan_24j = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, screener.NamaAset, screener.Simbol, screener.Kategori, screener.KategoriAset, screener.Sektor, screener.SkorSyariah, screener.Keterangan, screener.HargaTerakhir, screener.Perubahan24J, screener.ID)
 	return err
 }
 
@@ -102,14 +102,18 @@ func (r *Repository) HapusScreener(ctx context.Context, id int64) error {
 }
 
 func (r *Repository) BuatPasar(ctx context.Context, pasar *domain.Pasar) error {
-	query := `INSERT INTO pasar (nama_aset, simbol, harga, volume_24j, perubahan_24j, kapitalisasi_pasar, diperbarui_pada) VALUES (?, ?, ?, ?, ?, ?, NOW())`
-	_, err := r.db.ExecContext(ctx, query, pasar.NamaAset, pasar.Simbol, pasar.Harga, pasar.Volume24J, pasar.Perubahan24J, pasar.KapitalisasiPasar)
+	query := `INSERT INTO pasar (nama_aset, simbol, kategori_aset, harga, volume_24j, perubahan_24j, kapitalisasi_pasar, mata_uang, diperbarui_pada) VALUES (?, ?, ?, ?, ?, ?, ?, ?, NOW())`
+	result, err := r.db.ExecContext(ctx, query, pasar.NamaAset, pasar.Simbol, pasar.KategoriAset, pasar.Harga, pasar.Volume24J, pasar.Perubahan24J, pasar.KapitalisasiPasar, pasar.MataUang)
+	if err != nil {
+		return err
+	}
+	pasar.ID, err = result.LastInsertId()
 	return err
 }
 
 func (r *Repository) PerbaruiPasar(ctx context.Context, pasar *domain.Pasar) error {
-	query := `UPDATE pasar SET nama_aset = ?, simbol = ?, harga = ?, volume_24j = ?, perubahan_24j = ?, kapitalisasi_pasar = ?, diperbarui_pada = NOW() WHERE id = ?`
-	_, err := r.db.ExecContext(ctx, query, pasar.NamaAset, pasar.Simbol, pasar.Harga, pasar.Volume24J, pasar.Perubahan24J, pasar.KapitalisasiPasar, pasar.ID)
+	query := `UPDATE pasar SET nama_aset = ?, simbol = ?, kategori_aset = ?, harga = ?, volume_24j = ?, perubahan_24j = ?, kapitalisasi_pasar = ?, mata_uang = ?, diperbarui_pada = NOW() WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, pasar.NamaAset, pasar.Simbol, pasar.KategoriAset, pasar.Harga, pasar.Volume24J, pasar.Perubahan24J, pasar.KapitalisasiPasar, pasar.MataUang, pasar.ID)
 	return err
 }
 
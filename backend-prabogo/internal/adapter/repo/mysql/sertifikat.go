@@ -0,0 +1,63 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func (r *Repository) AmbilSertifikatKelas(ctx context.Context, idPengguna, idKelas int64) (*domain.Sertifikat, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT s.id, s.id_pengguna, s.id_kelas, s.kode, s.tanggal_terbit, k.judul FROM sertifikat s JOIN kelas k ON k.id = s.id_kelas WHERE s.id_pengguna = ? AND s.id_kelas = ?`, idPengguna, idKelas)
+	var item domain.Sertifikat
+	if err := row.Scan(&item.ID, &item.IDPengguna, &item.IDKelas, &item.Kode, &item.TanggalTerbit, &item.JudulKelas); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *Repository) DaftarSertifikatPengguna(ctx context.Context, idPengguna int64) ([]domain.Sertifikat, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT s.id, s.id_pengguna, s.id_kelas, s.kode, s.tanggal_terbit, k.judul FROM sertifikat s JOIN kelas k ON k.id = s.id_kelas WHERE s.id_pengguna = ? ORDER BY s.tanggal_terbit DESC`, idPengguna)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []domain.Sertifikat
+	for rows.Next() {
+		var item domain.Sertifikat
+		if err := rows.Scan(&item.ID, &item.IDPengguna, &item.IDKelas, &item.Kode, &item.TanggalTerbit, &item.JudulKelas); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *Repository) SimpanSertifikat(ctx context.Context, sertifikat *domain.Sertifikat) error {
+	query := `INSERT INTO sertifikat (id_pengguna, id_kelas, kode, tanggal_terbit) VALUES (?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, sertifikat.IDPengguna, sertifikat.IDKelas, sertifikat.Kode, sertifikat.TanggalTerbit)
+	if err != nil {
+		return err
+	}
+	sertifikat.ID, err = result.LastInsertId()
+	return err
+}
+
+func (r *Repository) AmbilSertifikatByKode(ctx context.Context, kode string) (*domain.VerifikasiSertifikat, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT s.kode, p.nama, s.id_kelas, k.judul, s.tanggal_terbit FROM sertifikat s
+		JOIN pengguna p ON p.id = s.id_pengguna
+		JOIN kelas k ON k.id = s.id_kelas
+		WHERE s.kode = ?`, kode)
+	var item domain.VerifikasiSertifikat
+	if err := row.Scan(&item.Kode, &item.NamaPeserta, &item.IDKelas, &item.JudulKelas, &item.TanggalTerbit); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
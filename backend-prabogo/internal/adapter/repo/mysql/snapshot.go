@@ -0,0 +1,67 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func (r *Repository) DaftarIDPenggunaPortofolio(ctx context.Context) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT DISTINCT id_pengguna FROM portofolio_transaksi ORDER BY id_pengguna ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	return items, nil
+}
+
+func (r *Repository) SimpanSnapshotPortofolio(ctx context.Context, snapshot *domain.SnapshotPortofolio) error {
+	query := `INSERT INTO portofolio_snapshot (id_pengguna, tanggal, total_nilai, total_modal, nilai_zakat, mata_uang, jumlah_aset, aset_tanpa_kurs, dibuat_pada)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE total_nilai = VALUES(total_nilai), total_modal = VALUES(total_modal),
+		nilai_zakat = VALUES(nilai_zakat), mata_uang = VALUES(mata_uang), jumlah_aset = VALUES(jumlah_aset),
+		aset_tanpa_kurs = VALUES(aset_tanpa_kurs), dibuat_pada = VALUES(dibuat_pada)`
+	_, err := r.db.ExecContext(ctx, query, snapshot.IDPengguna, snapshot.Tanggal.Format("2006-01-02"), snapshot.TotalNilai, snapshot.TotalModal, snapshot.NilaiZakat, snapshot.MataUang, snapshot.JumlahAset, snapshot.AsetTanpaKurs, snapshot.DibuatPada)
+	return err
+}
+
+func (r *Repository) DaftarSnapshotPortofolio(ctx context.Context, idPengguna int64, dari, sampai time.Time) ([]domain.SnapshotPortofolio, error) {
+	query := `SELECT id, id_pengguna, tanggal, total_nilai, total_modal, nilai_zakat, mata_uang, jumlah_aset, aset_tanpa_kurs, dibuat_pada FROM portofolio_snapshot WHERE id_pengguna = ?`
+	args := []interface{}{idPengguna}
+	if !dari.IsZero() {
+		query += " AND tanggal >= ?"
+		args = append(args, dari.Format("2006-01-02"))
+	}
+	if !sampai.IsZero() {
+		query += " AND tanggal <= ?"
+		args = append(args, sampai.Format("2006-01-02"))
+	}
+	query += " ORDER BY tanggal ASC"
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []domain.SnapshotPortofolio
+	for rows.Next() {
+		var item domain.SnapshotPortofolio
+		var nilaiZakat sql.Null[domain.Uang]
+		if err := rows.Scan(&item.ID, &item.IDPengguna, &item.Tanggal, &item.TotalNilai, &item.TotalModal, &nilaiZakat, &item.MataUang, &item.JumlahAset, &item.AsetTanpaKurs, &item.DibuatPada); err != nil {
+			return nil, err
+		}
+		if nilaiZakat.Valid {
+			item.NilaiZakat = &nilaiZakat.V
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,111 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+// syaratTerbit adalah syarat SQL agar baris pada tabel atau alias t tampil
+// di endpoint publik: berstatus terbit dan jadwal terbitnya sudah lewat.
+func syaratTerbit(t string) string {
+	return t + ".status = '" + domain.KontenTerbit + "' AND (" + t + ".terbit_pada IS NULL OR " + t + ".terbit_pada <= NOW())"
+}
+
+func waktuOpsional(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// DaftarKelasSemua mengambil seluruh kelas tanpa memandang status untuk admin.
+func (r *Repository) DaftarKelasSemua(ctx context.Context) ([]domain.Kelas, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, judul, deskripsi, level, `+jumlahModulKelas("kelas", syaratBukanArsip)+`, `+durasiKelas("kelas", syaratBukanArsip)+`, thumbnail_url, status, terbit_pada, dibuat_pada FROM kelas ORDER BY dibuat_pada DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []domain.Kelas
+	for rows.Next() {
+		var item domain.Kelas
+		var terbitPada sql.NullTime
+		if err := rows.Scan(&item.ID, &item.Judul, &item.Deskripsi, &item.Level, &item.JumlahModul, &item.DurasiMenit, &item.ThumbnailURL, &item.Status, &terbitPada, &item.DibuatPada); err != nil {
+			return nil, err
+		}
+		item.TerbitPada = waktuOpsional(terbitPada)
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// SimpanTokenPratinjau mengganti token pratinjau kelas. Mengembalikan false
+// bila kelas tidak ada.
+func (r *Repository) SimpanTokenPratinjau(ctx context.Context, idKelas int64, token string, kadaluarsa time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `UPDATE kelas SET token_pratinjau = ?, pratinjau_kadaluarsa = ? WHERE id = ?`, token, kadaluarsa, idKelas)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// DetailKelasPratinjau mengambil kelas apa pun statusnya lewat token pratinjau
+// yang belum kedaluwarsa.
+func (r *Repository) DetailKelasPratinjau(ctx context.Context, token string) (*domain.Kelas, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT id, judul, deskripsi, level, `+jumlahModulKelas("kelas", syaratBukanArsip)+`, `+durasiKelas("kelas", syaratBukanArsip)+`, thumbnail_url, status, terbit_pada, dibuat_pada FROM kelas WHERE token_pratinjau = ? AND pratinjau_kadaluarsa > NOW()`, token)
+	var item domain.Kelas
+	var terbitPada sql.NullTime
+	if err := row.Scan(&item.ID, &item.Judul, &item.Deskripsi, &item.Level, &item.JumlahModul, &item.DurasiMenit, &item.ThumbnailURL, &item.Status, &terbitPada, &item.DibuatPada); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	item.TerbitPada = waktuOpsional(terbitPada)
+	return &item, nil
+}
+
+// DaftarModulPratinjau mengambil modul kelas termasuk draf dan review; modul
+// arsip tetap disembunyikan.
+func (r *Repository) DaftarModulPratinjau(ctx context.Context, idKelas int64) ([]domain.Modul, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, id_kelas, judul, urutan, ringkasan, `+durasiModul("modul", syaratBukanArsip)+`, status, terbit_pada, dibuat_pada FROM modul WHERE id_kelas = ? AND status <> ? ORDER BY urutan ASC`, idKelas, domain.KontenArsip)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []domain.Modul
+	for rows.Next() {
+		var item domain.Modul
+		var terbitPada sql.NullTime
+		if err := rows.Scan(&item.ID, &item.IDKelas, &item.Judul, &item.Urutan, &item.Ringkasan, &item.DurasiMenit, &item.Status, &terbitPada, &item.DibuatPada); err != nil {
+			return nil, err
+		}
+		item.TerbitPada = waktuOpsional(terbitPada)
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// DaftarMateriPratinjau mengambil materi kelas termasuk draf dan review.
+func (r *Repository) DaftarMateriPratinjau(ctx context.Context, idKelas int64) ([]domain.Materi, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT m.id, m.id_modul, m.judul, m.tipe, m.konten, m.url_video, m.durasi_menit, m.status, m.terbit_pada, m.dibuat_pada FROM materi m JOIN modul d ON d.id = m.id_modul WHERE d.id_kelas = ? AND m.status <> ? ORDER BY d.urutan ASC, m.id ASC`, idKelas, domain.KontenArsip)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []domain.Materi
+	for rows.Next() {
+		var item domain.Materi
+		var terbitPada sql.NullTime
+		if err := rows.Scan(&item.ID, &item.IDModul, &item.Judul, &item.Tipe, &item.Konten, &item.URLVideo, &item.DurasiMenit, &item.Status, &terbitPada, &item.DibuatPada); err != nil {
+			return nil, err
+		}
+		item.TerbitPada = waktuOpsional(terbitPada)
+		items = append(items, item)
+	}
+	return items, nil
+}
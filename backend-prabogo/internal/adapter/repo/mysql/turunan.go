@@ -0,0 +1,33 @@
+package mysql
+
+import "github.com/averroes/backend-prabogo/internal/domain"
+
+// Kolom turunan kelas, modul dan ujian dihitung dari baris anaknya saat dibaca
+// sehingga tidak bisa bergeser dari isi sebenarnya. Parameter t adalah nama
+// tabel atau alias induk pada kueri luar; syarat menyaring baris anak yang
+// dihitung: syaratTerbit untuk endpoint publik, sama dengan yang dilihat
+// peserta, atau syaratBukanArsip untuk admin dan pratinjau.
+
+// syaratBukanArsip menyertakan draf, review dan terbit pada tabel atau alias t.
+func syaratBukanArsip(t string) string {
+	return t + ".status <> '" + domain.KontenArsip + "'"
+}
+
+func jumlahModulKelas(t string, syarat func(string) string) string {
+	return `(SELECT COUNT(*) FROM modul dm WHERE dm.id_kelas = ` + t + `.id AND ` + syarat("dm") + `)`
+}
+
+func durasiKelas(t string, syarat func(string) string) string {
+	return `(SELECT COALESCE(SUM(mm.durasi_menit), 0) FROM materi mm JOIN modul dm ON dm.id = mm.id_modul WHERE dm.id_kelas = ` + t + `.id AND ` + syarat("mm") + ` AND ` + syarat("dm") + `)`
+}
+
+func durasiModul(t string, syarat func(string) string) string {
+	return `(SELECT COALESCE(SUM(mm.durasi_menit), 0) FROM materi mm WHERE mm.id_modul = ` + t + `.id AND ` + syarat("mm") + `)`
+}
+
+// jumlahSoalUjian adalah jumlah soal yang dikerjakan per percobaan: seluruh bank
+// soal, atau soal_per_percobaan bila diisi dan lebih kecil dari bank soal.
+func jumlahSoalUjian(t string) string {
+	bank := `(SELECT COUNT(*) FROM ujian_soal us WHERE us.id_ujian = ` + t + `.id)`
+	return `(CASE WHEN ` + t + `.soal_per_percobaan > 0 THEN LEAST(` + bank + `, ` + t + `.soal_per_percobaan) ELSE ` + bank + ` END)`
+}
@@ -0,0 +1,204 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func (r *Repository) DetailUjian(ctx context.Context, id int64) (*domain.Ujian, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT ujian.id, ujian.id_kelas, ujian.judul, ujian.deskripsi, ujian.durasi_menit, `+jumlahSoalUjian("ujian")+`, ujian.nilai_lulus, ujian.soal_per_percobaan, ujian.maks_percobaan, ujian.jeda_menit, ujian.dibuat_pada FROM ujian
+		JOIN kelas ON kelas.id = ujian.id_kelas
+		WHERE ujian.id = ? AND `+syaratTerbit("kelas"), id)
+	var item domain.Ujian
+	if err := row.Scan(&item.ID, &item.IDKelas, &item.Judul, &item.Deskripsi, &item.DurasiMenit, &item.JumlahSoal, &item.NilaiLulus, &item.SoalPerPercobaan, &item.MaksPercobaan, &item.JedaMenit, &item.DibuatPada); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// DaftarSoalUjian mengambil seluruh bank soal ujian beserta opsinya dalam dua kueri.
+func (r *Repository) DaftarSoalUjian(ctx context.Context, idUjian int64) ([]domain.SoalUjian, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, id_ujian, tipe, pertanyaan, jawaban_isian, pembahasan, bobot, urutan, dibuat_pada FROM ujian_soal WHERE id_ujian = ? ORDER BY urutan ASC, id ASC`, idUjian)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []domain.SoalUjian
+	indeks := map[int64]int{}
+	for rows.Next() {
+		var item domain.SoalUjian
+		var pembahasan sql.NullString
+		if err := rows.Scan(&item.ID, &item.IDUjian, &item.Tipe, &item.Pertanyaan, &item.JawabanIsian, &pembahasan, &item.Bobot, &item.Urutan, &item.DibuatPada); err != nil {
+			return nil, err
+		}
+		item.Pembahasan = pembahasan.String
+		indeks[item.ID] = len(items)
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	opsi, err := r.db.QueryContext(ctx, `SELECT o.id, o.id_soal, o.teks, o.benar, o.urutan FROM ujian_opsi o JOIN ujian_soal s ON s.id = o.id_soal WHERE s.id_ujian = ? ORDER BY o.urutan ASC, o.id ASC`, idUjian)
+	if err != nil {
+		return nil, err
+	}
+	defer opsi.Close()
+	for opsi.Next() {
+		var item domain.OpsiSoal
+		if err := opsi.Scan(&item.ID, &item.IDSoal, &item.Teks, &item.Benar, &item.Urutan); err != nil {
+			return nil, err
+		}
+		if i, ok := indeks[item.IDSoal]; ok {
+			items[i].Opsi = append(items[i].Opsi, item)
+		}
+	}
+	return items, opsi.Err()
+}
+
+// BuatSoalUjian menyimpan soal dan opsinya dalam satu transaksi.
+func (r *Repository) BuatSoalUjian(ctx context.Context, soal *domain.SoalUjian) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	query := `INSERT INTO ujian_soal (id_ujian, tipe, pertanyaan, jawaban_isian, pembahasan, bobot, urutan, dibuat_pada) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := tx.ExecContext(ctx, query, soal.IDUjian, soal.Tipe, soal.Pertanyaan, soal.JawabanIsian, soal.Pembahasan, soal.Bobot, soal.Urutan, soal.DibuatPada)
+	if err != nil {
+		return err
+	}
+	if soal.ID, err = result.LastInsertId(); err != nil {
+		return err
+	}
+	if err := simpanOpsiSoal(ctx, tx, soal); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// PerbaruiSoalUjian memperbarui soal dan mengganti seluruh opsinya.
+func (r *Repository) PerbaruiSoalUjian(ctx context.Context, soal *domain.SoalUjian) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	query := `UPDATE ujian_soal SET tipe = ?, pertanyaan = ?, jawaban_isian = ?, pembahasan = ?, bobot = ?, urutan = ? WHERE id = ?`
+	if _, err := tx.ExecContext(ctx, query, soal.Tipe, soal.Pertanyaan, soal.JawabanIsian, soal.Pembahasan, soal.Bobot, soal.Urutan, soal.ID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM ujian_opsi WHERE id_soal = ?`, soal.ID); err != nil {
+		return err
+	}
+	if err := simpanOpsiSoal(ctx, tx, soal); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func simpanOpsiSoal(ctx context.Context, tx *sql.Tx, soal *domain.SoalUjian) error {
+	for i := range soal.Opsi {
+		opsi := &soal.Opsi[i]
+		opsi.IDSoal = soal.ID
+		result, err := tx.ExecContext(ctx, `INSERT INTO ujian_opsi (id_soal, teks, benar, urutan) VALUES (?, ?, ?, ?)`, opsi.IDSoal, opsi.Teks, opsi.Benar, opsi.Urutan)
+		if err != nil {
+			return err
+		}
+		if opsi.ID, err = result.LastInsertId(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Repository) HapusSoalUjian(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM ujian_soal WHERE id = ?`, id)
+	return err
+}
+
+func (r *Repository) AmbilPercobaanTerakhir(ctx context.Context, idUjian, idPengguna int64) (*domain.PercobaanUjian, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT id, id_ujian, id_pengguna, soal, jawaban, rincian, status, skor, lulus, mulai_pada, batas_waktu, selesai_pada FROM ujian_percobaan WHERE id_ujian = ? AND id_pengguna = ? ORDER BY id DESC LIMIT 1`, idUjian, idPengguna)
+	var item domain.PercobaanUjian
+	var soal string
+	var jawaban, rincian sql.NullString
+	var batasWaktu, selesaiPada sql.NullTime
+	if err := row.Scan(&item.ID, &item.IDUjian, &item.IDPengguna, &soal, &jawaban, &rincian, &item.Status, &item.Skor, &item.Lulus, &item.MulaiPada, &batasWaktu, &selesaiPada); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(soal), &item.Soal); err != nil {
+		return nil, err
+	}
+	if jawaban.Valid && jawaban.String != "" {
+		if err := json.Unmarshal([]byte(jawaban.String), &item.Jawaban); err != nil {
+			return nil, err
+		}
+	}
+	if rincian.Valid && rincian.String != "" {
+		if err := json.Unmarshal([]byte(rincian.String), &item.Rincian); err != nil {
+			return nil, err
+		}
+	}
+	if batasWaktu.Valid {
+		item.BatasWaktu = &batasWaktu.Time
+	}
+	if selesaiPada.Valid {
+		item.SelesaiPada = &selesaiPada.Time
+	}
+	return &item, nil
+}
+
+func (r *Repository) HitungPercobaanUjian(ctx context.Context, idUjian, idPengguna int64) (int, error) {
+	var jumlah int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM ujian_percobaan WHERE id_ujian = ? AND id_pengguna = ?`, idUjian, idPengguna).Scan(&jumlah)
+	return jumlah, err
+}
+
+func (r *Repository) SimpanPercobaanUjian(ctx context.Context, percobaan *domain.PercobaanUjian) error {
+	soal, err := json.Marshal(percobaan.Soal)
+	if err != nil {
+		return err
+	}
+	jawaban, err := json.Marshal(percobaan.Jawaban)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO ujian_percobaan (id_ujian, id_pengguna, soal, jawaban, status, skor, lulus, mulai_pada, batas_waktu, selesai_pada) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, percobaan.IDUjian, percobaan.IDPengguna, string(soal), string(jawaban), percobaan.Status, percobaan.Skor, percobaan.Lulus, percobaan.MulaiPada, percobaan.BatasWaktu, percobaan.SelesaiPada)
+	if err != nil {
+		return err
+	}
+	percobaan.ID, err = result.LastInsertId()
+	return err
+}
+
+func (r *Repository) PerbaruiPercobaanUjian(ctx context.Context, percobaan *domain.PercobaanUjian) error {
+	jawaban, err := json.Marshal(percobaan.Jawaban)
+	if err != nil {
+		return err
+	}
+	var rincian sql.NullString
+	if percobaan.Rincian != nil {
+		isi, err := json.Marshal(percobaan.Rincian)
+		if err != nil {
+			return err
+		}
+		rincian = sql.NullString{String: string(isi), Valid: true}
+	}
+	query := `UPDATE ujian_percobaan SET jawaban = ?, rincian = ?, status = ?, skor = ?, lulus = ?, selesai_pada = ? WHERE id = ? AND id_pengguna = ?`
+	_, err = r.db.ExecContext(ctx, query, string(jawaban), rincian, percobaan.Status, percobaan.Skor, percobaan.Lulus, percobaan.SelesaiPada, percobaan.ID, percobaan.IDPengguna)
+	return err
+}
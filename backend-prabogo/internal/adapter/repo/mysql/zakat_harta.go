@@ -0,0 +1,76 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func (r *Repository) DaftarHartaZakat(ctx context.Context, idPengguna int64) ([]domain.HartaZakat, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, id_pengguna, kelompok, jenis, nama, nilai, mata_uang, catatan, dibuat_pada, diperbarui_pada FROM zakat_harta WHERE id_pengguna = ? ORDER BY kelompok ASC, id ASC`, idPengguna)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []domain.HartaZakat
+	for rows.Next() {
+		var item domain.HartaZakat
+		if err := rows.Scan(&item.ID, &item.IDPengguna, &item.Kelompok, &item.Jenis, &item.Nama, &item.Nilai, &item.MataUang, &item.Catatan, &item.DibuatPada, &item.DiperbaruiPada); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *Repository) DaftarIDPenggunaHartaZakat(ctx context.Context) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT DISTINCT id_pengguna FROM zakat_harta ORDER BY id_pengguna ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	return items, nil
+}
+
+func (r *Repository) AmbilHartaZakat(ctx context.Context, id int64, idPengguna int64) (*domain.HartaZakat, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT id, id_pengguna, kelompok, jenis, nama, nilai, mata_uang, catatan, dibuat_pada, diperbarui_pada FROM zakat_harta WHERE id = ? AND id_pengguna = ?`, id, idPengguna)
+	var item domain.HartaZakat
+	if err := row.Scan(&item.ID, &item.IDPengguna, &item.Kelompok, &item.Jenis, &item.Nama, &item.Nilai, &item.MataUang, &item.Catatan, &item.DibuatPada, &item.DiperbaruiPada); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *Repository) SimpanHartaZakat(ctx context.Context, harta *domain.HartaZakat) error {
+	query := `INSERT INTO zakat_harta (id_pengguna, kelompok, jenis, nama, nilai, mata_uang, catatan, dibuat_pada, diperbarui_pada) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, harta.IDPengguna, harta.Kelompok, harta.Jenis, harta.Nama, harta.Nilai, harta.MataUang, harta.Catatan, harta.DibuatPada, harta.DiperbaruiPada)
+	if err != nil {
+		return err
+	}
+	harta.ID, err = result.LastInsertId()
+	return err
+}
+
+func (r *Repository) PerbaruiHartaZakat(ctx context.Context, harta *domain.HartaZakat) error {
+	query := `UPDATE zakat_harta SET kelompok = ?, jenis = ?, nama = ?, nilai = ?, mata_uang = ?, catatan = ?, diperbarui_pada = ? WHERE id = ? AND id_pengguna = ?`
+	_, err := r.db.ExecContext(ctx, query, harta.Kelompok, harta.Jenis, harta.Nama, harta.Nilai, harta.MataUang, harta.Catatan, harta.DiperbaruiPada, harta.ID, harta.IDPengguna)
+	return err
+}
+
+func (r *Repository) HapusHartaZakat(ctx context.Context, id int64, idPengguna int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM zakat_harta WHERE id = ? AND id_pengguna = ?`, id, idPengguna)
+	return err
+}
@@ -0,0 +1,83 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func (r *Repository) DaftarPembayaranZakat(ctx context.Context, idPengguna int64, dari, sampai time.Time) ([]domain.PembayaranZakat, error) {
+	query := `SELECT id, id_pengguna, jenis, tahun_haul, tanggal, jumlah, mata_uang, jumlah_idr, jenis_penerima, penerima, asnaf, nomor_bukti, bukti_url, catatan, dibuat_pada, diperbarui_pada FROM zakat_pembayaran WHERE id_pengguna = ?`
+	args := []interface{}{idPengguna}
+	if !dari.IsZero() {
+		query += " AND tanggal >= ?"
+		args = append(args, dari.Format("2006-01-02"))
+	}
+	if !sampai.IsZero() {
+		query += " AND tanggal <= ?"
+		args = append(args, sampai.Format("2006-01-02"))
+	}
+	query += " ORDER BY tanggal ASC, id ASC"
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []domain.PembayaranZakat
+	for rows.Next() {
+		var item domain.PembayaranZakat
+		var buktiURL sql.NullString
+		var jumlahIDR sql.Null[domain.Uang]
+		if err := rows.Scan(&item.ID, &item.IDPengguna, &item.Jenis, &item.TahunHaul, &item.Tanggal, &item.Jumlah, &item.MataUang, &jumlahIDR, &item.JenisPenerima, &item.Penerima, &item.Asnaf, &item.NomorBukti, &buktiURL, &item.Catatan, &item.DibuatPada, &item.DiperbaruiPada); err != nil {
+			return nil, err
+		}
+		item.BuktiURL = buktiURL.String
+		if jumlahIDR.Valid {
+			item.JumlahIDR = &jumlahIDR.V
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *Repository) AmbilPembayaranZakat(ctx context.Context, id int64, idPengguna int64) (*domain.PembayaranZakat, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT id, id_pengguna, jenis, tahun_haul, tanggal, jumlah, mata_uang, jumlah_idr, jenis_penerima, penerima, asnaf, nomor_bukti, bukti_url, catatan, dibuat_pada, diperbarui_pada FROM zakat_pembayaran WHERE id = ? AND id_pengguna = ?`, id, idPengguna)
+	var item domain.PembayaranZakat
+	var buktiURL sql.NullString
+	var jumlahIDR sql.Null[domain.Uang]
+	if err := row.Scan(&item.ID, &item.IDPengguna, &item.Jenis, &item.TahunHaul, &item.Tanggal, &item.Jumlah, &item.MataUang, &jumlahIDR, &item.JenisPenerima, &item.Penerima, &item.Asnaf, &item.NomorBukti, &buktiURL, &item.Catatan, &item.DibuatPada, &item.DiperbaruiPada); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	item.BuktiURL = buktiURL.String
+	if jumlahIDR.Valid {
+		item.JumlahIDR = &jumlahIDR.V
+	}
+	return &item, nil
+}
+
+func (r *Repository) SimpanPembayaranZakat(ctx context.Context, pembayaran *domain.PembayaranZakat) error {
+	query := `INSERT INTO zakat_pembayaran (id_pengguna, jenis, tahun_haul, tanggal, jumlah, mata_uang, jumlah_idr, jenis_penerima, penerima, asnaf, nomor_bukti, bukti_url, catatan, dibuat_pada, diperbarui_pada) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, pembayaran.IDPengguna, pembayaran.Jenis, pembayaran.TahunHaul, pembayaran.Tanggal.Format("2006-01-02"), pembayaran.Jumlah, pembayaran.MataUang, pembayaran.JumlahIDR, pembayaran.JenisPenerima, pembayaran.Penerima, pembayaran.Asnaf, pembayaran.NomorBukti, pembayaran.BuktiURL, pembayaran.Catatan, pembayaran.DibuatPada, pembayaran.DiperbaruiPada)
+	if err != nil {
+		return err
+	}
+	pembayaran.ID, err = result.LastInsertId()
+	return err
+}
+
+func (r *Repository) PerbaruiPembayaranZakat(ctx context.Context, pembayaran *domain.PembayaranZakat) error {
+	query := `UPDATE zakat_pembayaran SET jenis = ?, tahun_haul = ?, tanggal = ?, jumlah = ?, mata_uang = ?, jumlah_idr = ?, jenis_penerima = ?, penerima = ?, asnaf = ?, nomor_bukti = ?, bukti_url = ?, catatan = ?, diperbarui_pada = ? WHERE id = ? AND id_pengguna = ?`
+	_, err := r.db.ExecContext(ctx, query, pembayaran.Jenis, pembayaran.TahunHaul, pembayaran.Tanggal.Format("2006-01-02"), pembayaran.Jumlah, pembayaran.MataUang, pembayaran.JumlahIDR, pembayaran.JenisPenerima, pembayaran.Penerima, pembayaran.Asnaf, pembayaran.NomorBukti, pembayaran.BuktiURL, pembayaran.Catatan, pembayaran.DiperbaruiPada, pembayaran.ID, pembayaran.IDPengguna)
+	return err
+}
+
+func (r *Repository) HapusPembayaranZakat(ctx context.Context, id int64, idPengguna int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM zakat_pembayaran WHERE id = ? AND id_pengguna = ?`, id, idPengguna)
+	return err
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "errors"
+
+// Status progress kelas peserta. Kelas selesai bila seluruh materi selesai
+// dan seluruh ujian kelas sudah lulus. ProgressBelumMulai hanya dipakai untuk
+// ringkasan jalur belajar.
+const (
+	ProgressBelumMulai = "belum_mulai"
+	ProgressBerjalan   = "berjalan"
+	ProgressSelesai    = "selesai"
+)
+
+// Status terbit kelas, modul dan materi. Hanya konten KontenTerbit yang
+// jadwal terbitnya sudah lewat yang tampil di endpoint publik.
+const (
+	KontenDraf   = "draf"
+	KontenReview = "review"
+	KontenTerbit = "terbit"
+	KontenArsip  = "arsip"
+)
+
+func StatusKontenValid(status string) bool {
+	switch status {
+	case KontenDraf, KontenReview, KontenTerbit, KontenArsip:
+		return true
+	}
+	return false
+}
+
+// ErrPrasyaratBelumSelesai dikembalikan saat peserta memulai kelas yang
+// prasyaratnya belum selesai.
+var ErrPrasyaratBelumSelesai = errors.New("kelas prasyarat belum selesai")
+
+// ErrKelasBelumDimulai dikembalikan saat peserta mencatat progress materi atau
+// memulai ujian pada kelas yang belum dimulai lewat MulaiKelas.
+var ErrKelasBelumDimulai = errors.New("kelas belum dimulai")
+
+// ErrPercobaanDibatasi dikembalikan saat peserta memulai ujian setelah batas
+// percobaan tercapai atau sebelum jeda antarpercobaan berakhir.
+var ErrPercobaanDibatasi = errors.New("percobaan ujian dibatasi")
@@ -0,0 +1,220 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// SkalaHarga adalah jumlah angka di belakang koma untuk harga per unit, sama
+// dengan kolom DECIMAL(28,8) di database.
+const SkalaHarga = 8
+
+const pembagiHarga = 100000000
+
+// Harga adalah harga per unit aset dalam satuan 1e-8. Skalanya lebih halus dari
+// Uang agar token bernilai sangat kecil tidak terbulatkan menjadi nol; hasil
+// kali harga dengan jumlah unit dibulatkan ke skala uang lewat Kali.
+type Harga int64
+
+func HargaDariString(s string) (Harga, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, fmt.Errorf("harga tidak valid: %q", s)
+	}
+	n, err := desimalDariRat(r, pembagiHarga)
+	return Harga(n), err
+}
+
+// HargaDariFloat membulatkan float64 ke skala harga berdasarkan representasi
+// desimal terpendeknya.
+func HargaDariFloat(f float64) (Harga, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("harga tidak valid: %v", f)
+	}
+	return HargaDariString(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+func (h Harga) Float64() float64 {
+	return float64(h) / pembagiHarga
+}
+
+// String menghasilkan bentuk desimal tanpa nol berlebih, misalnya "0.00000123".
+func (h Harga) String() string {
+	return formatDesimal(int64(h), pembagiHarga, SkalaHarga, true)
+}
+
+// Kali menghitung nilai jumlah unit pada harga ini, dibulatkan setengah
+// menjauhi nol ke skala uang.
+func (h Harga) Kali(jumlah float64) (Uang, error) {
+	r, err := kaliRat(int64(h), pembagiHarga, jumlah)
+	if err != nil {
+		return 0, err
+	}
+	return uangDariRat(r)
+}
+
+// KaliFaktor mengalikan harga dengan faktor seperti kurs tanpa keluar dari
+// skala harga.
+func (h Harga) KaliFaktor(faktor float64) (Harga, error) {
+	r, err := kaliRat(int64(h), pembagiHarga, faktor)
+	if err != nil {
+		return 0, err
+	}
+	n, err := desimalDariRat(r, pembagiHarga)
+	return Harga(n), err
+}
+
+// BagiFaktor membagi harga dengan pembagi tanpa keluar dari skala harga.
+func (h Harga) BagiFaktor(pembagi float64) (Harga, error) {
+	r, err := bagiRat(int64(h), pembagiHarga, pembagi)
+	if err != nil {
+		return 0, err
+	}
+	n, err := desimalDariRat(r, pembagiHarga)
+	return Harga(n), err
+}
+
+// Uang membulatkan harga ke skala uang, misalnya untuk harga yang ditampilkan
+// sebagai nilai total satu unit.
+func (h Harga) Uang() Uang {
+	r := new(big.Rat).SetFrac(big.NewInt(int64(h)), big.NewInt(pembagiHarga))
+	u, _ := uangDariRat(r)
+	return u
+}
+
+// Harga memperlakukan nilai uang sebagai harga per unit, misalnya harga emas
+// per gram yang disimpan dalam skala uang.
+func (u Uang) Harga() Harga {
+	const skala = pembagiHarga / pembagiUang
+	if int64(u) > math.MaxInt64/skala {
+		return Harga(math.MaxInt64)
+	}
+	if int64(u) < math.MinInt64/skala {
+		return Harga(math.MinInt64)
+	}
+	return Harga(int64(u) * skala)
+}
+
+// PerUnit membagi nilai uang dengan jumlah unit menjadi harga per unit,
+// misalnya harga pokok rata-rata dari total modal.
+func (u Uang) PerUnit(jumlah float64) (Harga, error) {
+	r, err := bagiRat(int64(u), pembagiUang, jumlah)
+	if err != nil {
+		return 0, err
+	}
+	n, err := desimalDariRat(r, pembagiHarga)
+	return Harga(n), err
+}
+
+func (h Harga) MarshalJSON() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+func (h *Harga) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(strings.TrimSpace(string(data)), `"`)
+	if s == "null" {
+		*h = 0
+		return nil
+	}
+	hasil, err := HargaDariString(s)
+	if err != nil {
+		return err
+	}
+	*h = hasil
+	return nil
+}
+
+func (h *Harga) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*h = 0
+		return nil
+	case []byte:
+		hasil, err := HargaDariString(string(v))
+		*h = hasil
+		return err
+	case string:
+		hasil, err := HargaDariString(v)
+		*h = hasil
+		return err
+	case float64:
+		hasil, err := HargaDariFloat(v)
+		*h = hasil
+		return err
+	case int64:
+		if v > math.MaxInt64/pembagiHarga || v < math.MinInt64/pembagiHarga {
+			return fmt.Errorf("harga %d melampaui batas", v)
+		}
+		*h = Harga(v * pembagiHarga)
+		return nil
+	}
+	return fmt.Errorf("tipe %T tidak dapat dibaca sebagai harga", src)
+}
+
+func (h Harga) Value() (driver.Value, error) {
+	return formatDesimal(int64(h), pembagiHarga, SkalaHarga, false), nil
+}
+
+// kaliRat mengalikan n/pembagi dengan faktor float64 secara pasti.
+func kaliRat(n, pembagi int64, faktor float64) (*big.Rat, error) {
+	f, err := faktorRat(faktor)
+	if err != nil {
+		return nil, err
+	}
+	r := new(big.Rat).SetFrac(big.NewInt(n), big.NewInt(pembagi))
+	return r.Mul(r, f), nil
+}
+
+// bagiRat membagi n/pembagi dengan faktor float64 secara pasti.
+func bagiRat(n, pembagi int64, faktor float64) (*big.Rat, error) {
+	if faktor == 0 {
+		return nil, ErrPembagiNol
+	}
+	f, err := faktorRat(faktor)
+	if err != nil {
+		return nil, err
+	}
+	r := new(big.Rat).SetFrac(big.NewInt(n), big.NewInt(pembagi))
+	return r.Quo(r, f), nil
+}
+
+// faktorRat membaca faktor float64 dari representasi desimal terpendeknya;
+// NaN dan tak hingga menghasilkan error.
+func faktorRat(f float64) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	if !ok {
+		return nil, fmt.Errorf("faktor tidak valid: %v", f)
+	}
+	return r, nil
+}
+
+// formatDesimal menulis n/pembagi dengan skala digit pecahan; ringkas
+// membuang nol di belakang koma.
+func formatDesimal(n, pembagi int64, skala int, ringkas bool) string {
+	negatif := n < 0
+	m := uint64(n)
+	if negatif {
+		m = uint64(-n)
+	}
+	s := strconv.FormatUint(m/uint64(pembagi), 10)
+	pecahan := fmt.Sprintf("%0*d", skala, m%uint64(pembagi))
+	if ringkas {
+		if pecahan = strings.TrimRight(pecahan, "0"); pecahan != "" {
+			s += "." + pecahan
+		}
+	} else {
+		s += "." + pecahan
+	}
+	if negatif {
+		s = "-" + s
+	}
+	return s
+}
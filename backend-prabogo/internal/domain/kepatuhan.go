@@ -0,0 +1,19 @@
+package domain
+
+// Kategori hasil screener syariah.
+const (
+	ScreenerHalal                 = "halal"
+	ScreenerProses                = "proses"
+	ScreenerTidakDirekomendasikan = "tidak_direkomendasikan"
+)
+
+// KategoriAsetDefault dipakai untuk screener yang tidak menyebut kelas asetnya.
+const KategoriAsetDefault = "kripto"
+
+// Status kepatuhan sebuah kepemilikan pada laporan kepatuhan portofolio.
+const (
+	KepatuhanPatuh         = "patuh"
+	KepatuhanDiragukan     = "diragukan"
+	KepatuhanTidakPatuh    = "tidak_patuh"
+	KepatuhanBelumDisaring = "belum_disaring"
+)
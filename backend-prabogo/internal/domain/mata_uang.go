@@ -0,0 +1,43 @@
+package domain
+
+import "strings"
+
+const (
+	MataUangIDR = "IDR"
+	MataUangUSD = "USD"
+)
+
+// NormalisasiMataUang mengembalikan kode ISO 4217 huruf besar, atau fallback
+// bila kode kosong atau tidak berbentuk tiga huruf.
+func NormalisasiMataUang(kode, fallback string) string {
+	kode = strings.ToUpper(strings.TrimSpace(kode))
+	if len(kode) != 3 {
+		return fallback
+	}
+	for _, c := range kode {
+		if c < 'A' || c > 'Z' {
+			return fallback
+		}
+	}
+	return kode
+}
+
+// digitMataUang mencatat mata uang yang jumlah digit desimalnya bukan 2.
+// Rupiah dibulatkan ke rupiah penuh karena pecahan sen tidak beredar.
+var digitMataUang = map[string]int{
+	"IDR": 0,
+	"JPY": 0,
+	"KRW": 0,
+	"VND": 0,
+	"BHD": 3,
+	"KWD": 3,
+	"OMR": 3,
+}
+
+// DigitMataUang mengembalikan jumlah digit desimal yang dipakai saat membulatkan nilai.
+func DigitMataUang(kode string) int {
+	if digit, ok := digitMataUang[kode]; ok {
+		return digit
+	}
+	return 2
+}
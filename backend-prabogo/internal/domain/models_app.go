@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Pengguna struct {
 	ID              int64     `json:"id"`
@@ -10,6 +13,8 @@ type Pengguna struct {
 	Peran           string    `json:"peran"`
 	Status          string    `json:"status"`
 	SudahVerifikasi bool      `json:"sudah_verifikasi"`
+	MataUang        string    `json:"mata_uang"`
+	StandarNisab    string    `json:"standar_nisab"`
 	DibuatPada      time.Time `json:"dibuat_pada"`
 	DiubahPada      time.Time `json:"diubah_pada"`
 }
@@ -28,6 +33,8 @@ type Screener struct {
 	NamaAset      string    `json:"nama_aset"`
 	Simbol        string    `json:"simbol"`
 	Kategori      string    `json:"kategori"`
+	KategoriAset  string    `json:"kategori_aset"` // kelas aset, dicocokkan dengan kategori kepemilikan portofolio
+	Sektor        string    `json:"sektor"`
 	SkorSyariah   float64   `json:"skor_syariah"`
 	Keterangan    string    `json:"keterangan"`
 	HargaTerakhir float64   `json:"harga_terakhir"`
@@ -47,54 +54,164 @@ type Pasar struct {
 	ID                int64     `json:"id"`
 	NamaAset          string    `json:"nama_aset"`
 	Simbol            string    `json:"simbol"`
-	Harga             float64   `json:"harga"`
+	KategoriAset      string    `json:"kategori_aset"` // kelas aset untuk simbol yang belum disaring
+	Harga             Harga     `json:"harga"`
 	Volume24J         float64   `json:"volume_24j"`
 	Perubahan24J      float64   `json:"perubahan_24j"`
 	KapitalisasiPasar float64   `json:"kapitalisasi_pasar"`
+	MataUang          string    `json:"mata_uang"`
 	DiperbaruiPada    time.Time `json:"diperbarui_pada"`
 }
 
+type RiwayatHarga struct {
+	ID       int64     `json:"id"`
+	Simbol   string    `json:"simbol"`
+	Tanggal  time.Time `json:"tanggal"`
+	Harga    Harga     `json:"harga"`
+	MataUang string    `json:"mata_uang"`
+}
+
 type Kelas struct {
-	ID           int64     `json:"id"`
-	Judul        string    `json:"judul"`
-	Deskripsi    string    `json:"deskripsi"`
-	Level        string    `json:"level"`
-	JumlahModul  int       `json:"jumlah_modul"`
-	DurasiMenit  int       `json:"durasi_menit"`
-	ThumbnailURL string    `json:"thumbnail_url"`
-	Status       string    `json:"status"`
-	DibuatPada   time.Time `json:"dibuat_pada"`
+	ID           int64      `json:"id"`
+	Judul        string     `json:"judul"`
+	Deskripsi    string     `json:"deskripsi"`
+	Level        string     `json:"level"`
+	JumlahModul  int        `json:"jumlah_modul"` // dihitung dari modul terbit
+	DurasiMenit  int        `json:"durasi_menit"` // dihitung dari materi terbit
+	ThumbnailURL string     `json:"thumbnail_url"`
+	Status       string     `json:"status"`
+	TerbitPada   *time.Time `json:"terbit_pada"`
+	DibuatPada   time.Time  `json:"dibuat_pada"`
 }
 
 type Modul struct {
-	ID          int64     `json:"id"`
-	IDKelas     int64     `json:"id_kelas"`
-	Judul       string    `json:"judul"`
-	Urutan      int       `json:"urutan"`
-	Ringkasan   string    `json:"ringkasan"`
-	DurasiMenit int       `json:"durasi_menit"`
-	DibuatPada  time.Time `json:"dibuat_pada"`
+	ID          int64      `json:"id"`
+	IDKelas     int64      `json:"id_kelas"`
+	Judul       string     `json:"judul"`
+	Urutan      int        `json:"urutan"`
+	Ringkasan   string     `json:"ringkasan"`
+	DurasiMenit int        `json:"durasi_menit"` // dihitung dari materi terbit
+	Status      string     `json:"status"`
+	TerbitPada  *time.Time `json:"terbit_pada"`
+	DibuatPada  time.Time  `json:"dibuat_pada"`
 }
 
 type Materi struct {
-	ID          int64     `json:"id"`
-	IDModul     int64     `json:"id_modul"`
-	Judul       string    `json:"judul"`
-	Tipe        string    `json:"tipe"`
-	Konten      string    `json:"konten"`
-	URLVideo    string    `json:"url_video"`
-	DurasiMenit int       `json:"durasi_menit"`
-	DibuatPada  time.Time `json:"dibuat_pada"`
+	ID          int64      `json:"id"`
+	IDModul     int64      `json:"id_modul"`
+	Judul       string     `json:"judul"`
+	Tipe        string     `json:"tipe"`
+	Konten      string     `json:"konten"`
+	URLVideo    string     `json:"url_video"`
+	DurasiMenit int        `json:"durasi_menit"`
+	Status      string     `json:"status"`
+	TerbitPada  *time.Time `json:"terbit_pada"`
+	DibuatPada  time.Time  `json:"dibuat_pada"`
 }
 
 type Ujian struct {
-	ID          int64     `json:"id"`
-	IDKelas     int64     `json:"id_kelas"`
-	Judul       string    `json:"judul"`
-	Deskripsi   string    `json:"deskripsi"`
-	DurasiMenit int       `json:"durasi_menit"`
-	JumlahSoal  int       `json:"jumlah_soal"`
-	DibuatPada  time.Time `json:"dibuat_pada"`
+	ID               int64     `json:"id"`
+	IDKelas          int64     `json:"id_kelas"`
+	Judul            string    `json:"judul"`
+	Deskripsi        string    `json:"deskripsi"`
+	DurasiMenit      int       `json:"durasi_menit"`
+	JumlahSoal       int       `json:"jumlah_soal"` // soal per percobaan dari bank soal
+	NilaiLulus       int       `json:"nilai_lulus"`
+	SoalPerPercobaan int       `json:"soal_per_percobaan"`
+	MaksPercobaan    int       `json:"maks_percobaan"` // 0 berarti tanpa batas
+	JedaMenit        int       `json:"jeda_menit"`     // jeda antarpercobaan
+	DibuatPada       time.Time `json:"dibuat_pada"`
+}
+
+// SoalUjian adalah satu soal di bank soal ujian beserta kunci jawabannya.
+// Soal isian dinilai dari JawabanIsian; alternatif jawaban dipisah "|".
+type SoalUjian struct {
+	ID           int64      `json:"id"`
+	IDUjian      int64      `json:"id_ujian"`
+	Tipe         string     `json:"tipe"`
+	Pertanyaan   string     `json:"pertanyaan"`
+	Opsi         []OpsiSoal `json:"opsi"`
+	JawabanIsian string     `json:"jawaban_isian"`
+	Pembahasan   string     `json:"pembahasan"`
+	Bobot        int        `json:"bobot"`
+	Urutan       int        `json:"urutan"`
+	DibuatPada   time.Time  `json:"dibuat_pada"`
+}
+
+type OpsiSoal struct {
+	ID     int64  `json:"id"`
+	IDSoal int64  `json:"id_soal"`
+	Teks   string `json:"teks"`
+	Benar  bool   `json:"benar"`
+	Urutan int    `json:"urutan"`
+}
+
+type JawabanSoal struct {
+	IDSoal int64  `json:"id_soal"`
+	IDOpsi int64  `json:"id_opsi,omitempty"`
+	Teks   string `json:"teks,omitempty"`
+}
+
+// PercobaanUjian menyimpan soal yang diundi untuk satu percobaan peserta,
+// jawaban sementara, dan hasil penilaian setelah dikirim atau waktu habis.
+type PercobaanUjian struct {
+	ID          int64         `json:"id"`
+	IDUjian     int64         `json:"id_ujian"`
+	IDPengguna  int64         `json:"id_pengguna"`
+	Soal        []int64       `json:"soal"`
+	Jawaban     []JawabanSoal `json:"jawaban"`
+	Rincian     []HasilSoal   `json:"-"` // penilaian per soal saat dikirim; nil pada percobaan lama
+	Status      string        `json:"status"`
+	Skor        float64       `json:"skor"`
+	Lulus       bool          `json:"lulus"`
+	MulaiPada   time.Time     `json:"mulai_pada"`
+	BatasWaktu  *time.Time    `json:"batas_waktu"`
+	SelesaiPada *time.Time    `json:"selesai_pada"`
+}
+
+// SoalPeserta adalah soal yang ditampilkan kepada peserta tanpa kunci jawaban.
+type SoalPeserta struct {
+	ID         int64         `json:"id"`
+	Tipe       string        `json:"tipe"`
+	Pertanyaan string        `json:"pertanyaan"`
+	Bobot      int           `json:"bobot"`
+	Opsi       []OpsiPeserta `json:"opsi,omitempty"`
+}
+
+type OpsiPeserta struct {
+	ID   int64  `json:"id"`
+	Teks string `json:"teks"`
+}
+
+type LembarUjian struct {
+	Ujian     Ujian          `json:"ujian"`
+	Percobaan PercobaanUjian `json:"percobaan"`
+	Soal      []SoalPeserta  `json:"soal"`
+	SisaDetik *int64         `json:"sisa_detik"`
+}
+
+type HasilSoal struct {
+	IDSoal       int64       `json:"id_soal"`
+	Tipe         string      `json:"tipe"`
+	Pertanyaan   string      `json:"pertanyaan"`
+	Jawaban      JawabanSoal `json:"jawaban"`
+	JawabanBenar string      `json:"jawaban_benar,omitempty"`
+	Benar        bool        `json:"benar"`
+	Bobot        int         `json:"bobot"`
+	Pembahasan   string      `json:"pembahasan,omitempty"`
+}
+
+// HasilUjian menyertakan kunci jawaban dan pembahasan hanya setelah peserta
+// lulus atau kehabisan percobaan.
+type HasilUjian struct {
+	Ujian            Ujian          `json:"ujian"`
+	Percobaan        PercobaanUjian `json:"percobaan"`
+	Rincian          []HasilSoal    `json:"rincian"`
+	JumlahBenar      int            `json:"jumlah_benar"`
+	JumlahSoal       int            `json:"jumlah_soal"`
+	JumlahPercobaan  int            `json:"jumlah_percobaan"`
+	SisaPercobaan    *int           `json:"sisa_percobaan"` // nil bila tidak dibatasi
+	KunciDitampilkan bool           `json:"kunci_ditampilkan"`
 }
 
 type ProgressKelas struct {
@@ -106,12 +223,116 @@ type ProgressKelas struct {
 	TerakhirDiaksesPada time.Time `json:"terakhir_diakses_pada"`
 }
 
+// ProgressMateri mencatat posisi tonton video dan penyelesaian satu materi
+// oleh peserta.
+type ProgressMateri struct {
+	ID             int64      `json:"id"`
+	IDPengguna     int64      `json:"id_pengguna"`
+	IDMateri       int64      `json:"id_materi"`
+	PosisiDetik    int        `json:"posisi_detik"`
+	Selesai        bool       `json:"selesai"`
+	SelesaiPada    *time.Time `json:"selesai_pada"`
+	DiperbaruiPada time.Time  `json:"diperbarui_pada"`
+}
+
+type ProgressMateriKelas struct {
+	Materi ProgressMateri `json:"materi"`
+	Kelas  ProgressKelas  `json:"kelas"`
+}
+
+// KelasLengkap adalah pohon kelas → modul → materi beserta ujiannya untuk
+// satu halaman kelas. Progress hanya terisi bila pemanggil sudah login.
+type KelasLengkap struct {
+	Kelas
+	Modul     []ModulLengkap   `json:"modul"`
+	Ujian     []UjianLengkap   `json:"ujian"`
+	Prasyarat []PrasyaratKelas `json:"prasyarat"`
+	Progress  *ProgressKelas   `json:"progress,omitempty"`
+}
+
+// PratinjauKelas adalah token bagi editor untuk melihat kelas beserta modul
+// dan materi yang belum terbit.
+type PratinjauKelas struct {
+	IDKelas        int64     `json:"id_kelas"`
+	Token          string    `json:"token"`
+	KadaluarsaPada time.Time `json:"kadaluarsa_pada"`
+}
+
+type ModulLengkap struct {
+	Modul
+	Materi  []MateriLengkap `json:"materi"`
+	Selesai *bool           `json:"selesai,omitempty"`
+}
+
+type MateriLengkap struct {
+	Materi
+	Progress *ProgressMateri `json:"progress,omitempty"`
+}
+
+type UjianLengkap struct {
+	Ujian
+	Lulus *bool `json:"lulus,omitempty"`
+}
+
+// PrasyaratKelas adalah kelas yang harus selesai sebelum IDKelas dimulai.
+type PrasyaratKelas struct {
+	IDKelas        int64  `json:"id_kelas"`
+	IDPrasyarat    int64  `json:"id_prasyarat"`
+	JudulPrasyarat string `json:"judul_prasyarat"`
+	Selesai        *bool  `json:"selesai,omitempty"`
+}
+
+// JalurBelajar adalah rangkaian kelas berurutan hasil kurasi. Progress hanya
+// terisi bila pemanggil sudah login.
+type JalurBelajar struct {
+	ID         int64          `json:"id"`
+	Judul      string         `json:"judul"`
+	Deskripsi  string         `json:"deskripsi"`
+	Status     string         `json:"status"`
+	TerbitPada *time.Time     `json:"terbit_pada"`
+	DibuatPada time.Time      `json:"dibuat_pada"`
+	Kelas      []KelasJalur   `json:"kelas"`
+	Progress   *ProgressJalur `json:"progress,omitempty"`
+}
+
+type KelasJalur struct {
+	IDKelas      int64          `json:"id_kelas"`
+	Urutan       int            `json:"urutan"`
+	Judul        string         `json:"judul"`
+	Level        string         `json:"level"`
+	DurasiMenit  int            `json:"durasi_menit"`
+	ThumbnailURL string         `json:"thumbnail_url"`
+	Progress     *ProgressKelas `json:"progress,omitempty"`
+}
+
+// ProgressJalur merangkum progress peserta di seluruh kelas jalur; setiap
+// kelas berbobot sama.
+type ProgressJalur struct {
+	Persentase        float64 `json:"persentase"`
+	Status            string  `json:"status"`
+	KelasSelesai      int     `json:"kelas_selesai"`
+	JumlahKelas       int     `json:"jumlah_kelas"`
+	IDKelasBerikutnya *int64  `json:"id_kelas_berikutnya"`
+}
+
 type Sertifikat struct {
 	ID            int64     `json:"id"`
 	IDPengguna    int64     `json:"id_pengguna"`
 	IDKelas       int64     `json:"id_kelas"`
 	Kode          string    `json:"kode"`
 	TanggalTerbit time.Time `json:"tanggal_terbit"`
+	JudulKelas    string    `json:"judul_kelas,omitempty"`
+}
+
+// VerifikasiSertifikat adalah data sertifikat yang boleh ditampilkan publik;
+// email dan data pribadi lain peserta tidak disertakan.
+type VerifikasiSertifikat struct {
+	Kode          string    `json:"kode"`
+	NamaPeserta   string    `json:"nama_peserta"`
+	IDKelas       int64     `json:"id_kelas"`
+	JudulKelas    string    `json:"judul_kelas"`
+	TanggalTerbit time.Time `json:"tanggal_terbit"`
+	URLVerifikasi string    `json:"url_verifikasi"`
 }
 
 type Pustaka struct {
@@ -168,34 +389,374 @@ type Portofolio struct {
 	NamaAset    string    `json:"nama_aset"`
 	Simbol      string    `json:"simbol"`
 	Jumlah      float64   `json:"jumlah"`
-	HargaBeli   float64   `json:"harga_beli"`
-	NilaiSaatIni float64  `json:"nilai_saat_ini"`
+	HargaBeli   Harga     `json:"harga_beli"`
+	NilaiSaatIni Uang     `json:"nilai_saat_ini"`
 	Kategori    string    `json:"kategori"`
+	MataUang    string    `json:"mata_uang"`
 	DibuatPada  time.Time `json:"dibuat_pada"`
+
+	Metode             string `json:"metode"`
+	TotalModal         Uang   `json:"total_modal"`
+	HargaTerakhir      Harga  `json:"harga_terakhir"`
+	LabaRealisasi      Uang   `json:"laba_realisasi"`
+	LabaBelumRealisasi Uang   `json:"laba_belum_realisasi"`
+	Dividen            Uang   `json:"dividen"`
+	JumlahTransaksi    int    `json:"jumlah_transaksi"`
+
+	SumberHarga         string     `json:"sumber_harga"`
+	StatusHarga         string     `json:"status_harga"`
+	HargaDiperbaruiPada *time.Time `json:"harga_diperbarui_pada,omitempty"`
+
+	NilaiTampilan     Uang   `json:"nilai_tampilan"`
+	MataUangTampilan  string `json:"mata_uang_tampilan"`
+	KursTidakTersedia bool   `json:"kurs_tidak_tersedia"` // nilai_tampilan nol karena kurs ke mata uang tampilan belum ada
+}
+
+type TransaksiPortofolio struct {
+	ID         int64     `json:"id"`
+	IDPengguna int64     `json:"id_pengguna"`
+	Jenis      string    `json:"jenis"`
+	NamaAset   string    `json:"nama_aset"`
+	Simbol     string    `json:"simbol"`
+	Kategori   string    `json:"kategori"`
+	Jumlah     float64   `json:"jumlah"`
+	Harga      Harga     `json:"harga"`
+	Biaya      Uang      `json:"biaya"`
+	MataUang   string    `json:"mata_uang"`
+	Tanggal    time.Time `json:"tanggal"`
+	Catatan    string    `json:"catatan"`
+	DibuatPada time.Time `json:"dibuat_pada"`
+}
+
+// KunciPosisiPortofolio mengikat ID posisi yang stabil ke simbol dan mata
+// uang. Satu posisi dapat memiliki beberapa ID (baris portofolio lama yang
+// bersimbol sama); ID terkecil yang ditampilkan.
+type KunciPosisiPortofolio struct {
+	ID         int64     `json:"id"`
+	IDPengguna int64     `json:"id_pengguna"`
+	Simbol     string    `json:"simbol"`
+	MataUang   string    `json:"mata_uang"`
+	DibuatPada time.Time `json:"dibuat_pada"`
+}
+
+type NilaiManualPortofolio struct {
+	ID             int64     `json:"id"`
+	IDPengguna     int64     `json:"id_pengguna"`
+	Simbol         string    `json:"simbol"`
+	MataUang       string    `json:"mata_uang"`
+	Harga          Harga     `json:"harga"`
+	Catatan        string    `json:"catatan"`
+	DiperbaruiPada time.Time `json:"diperbarui_pada"`
+}
+
+type ImporPortofolio struct {
+	ID             int64        `json:"id"`
+	IDPengguna     int64        `json:"id_pengguna"`
+	Format         string       `json:"format"`
+	NamaBerkas     string       `json:"nama_berkas"`
+	Status         string       `json:"status"`
+	JumlahBaru     int          `json:"jumlah_baru"`
+	JumlahDuplikat int          `json:"jumlah_duplikat"`
+	JumlahGagal    int          `json:"jumlah_gagal"`
+	Baris          []BarisImpor `json:"baris"`
+	DibuatPada     time.Time    `json:"dibuat_pada"`
+	DiterapkanPada *time.Time   `json:"diterapkan_pada,omitempty"`
+}
+
+type BarisImpor struct {
+	Nomor     int                 `json:"nomor"`
+	Status    string              `json:"status"`
+	Pesan     string              `json:"pesan,omitempty"`
+	Transaksi TransaksiPortofolio `json:"transaksi"`
+}
+
+type SnapshotPortofolio struct {
+	ID            int64     `json:"id"`
+	IDPengguna    int64     `json:"id_pengguna"`
+	Tanggal       time.Time `json:"tanggal"`
+	TotalNilai    Uang      `json:"total_nilai"`
+	TotalModal    Uang      `json:"total_modal"`
+	NilaiZakat    *Uang     `json:"nilai_zakat"` // harta bersih zakat; nil pada snapshot lama
+	MataUang      string    `json:"mata_uang"`
+	JumlahAset    int       `json:"jumlah_aset"`
+	AsetTanpaKurs int       `json:"aset_tanpa_kurs"` // aset dan harta yang tidak ikut dinilai karena kurs belum ada
+	DibuatPada    time.Time `json:"dibuat_pada"`
+}
+
+type KepatuhanPortofolio struct {
+	MataUang            string          `json:"mata_uang"`
+	TotalNilai          Uang            `json:"total_nilai"`
+	NilaiPatuh          Uang            `json:"nilai_patuh"`
+	NilaiDiragukan      Uang            `json:"nilai_diragukan"`
+	NilaiTidakPatuh     Uang            `json:"nilai_tidak_patuh"`
+	PersenPatuh         float64         `json:"persen_patuh"`
+	PersenDiragukan     float64         `json:"persen_diragukan"`
+	PersenTidakPatuh    float64         `json:"persen_tidak_patuh"`
+	PersenBelumDisaring float64         `json:"persen_belum_disaring"`
+	Aset                []KepatuhanAset `json:"aset"`
+	PerluDivestasi      []KepatuhanAset `json:"perlu_divestasi"`
+}
+
+type KepatuhanAset struct {
+	IDPortofolio      int64      `json:"id_portofolio"`
+	NamaAset          string     `json:"nama_aset"`
+	Simbol            string     `json:"simbol"`
+	Status            string     `json:"status"`
+	KategoriScreener  string     `json:"kategori_screener"`
+	Sektor            string     `json:"sektor"`
+	SkorSyariah       float64    `json:"skor_syariah"`
+	Keterangan        string     `json:"keterangan"`
+	Nilai             Uang       `json:"nilai"`
+	Persen            float64    `json:"persen"`
+	KursTidakTersedia bool       `json:"kurs_tidak_tersedia,omitempty"` // nilai nol karena kurs belum ada
+	Alternatif        []Screener `json:"alternatif,omitempty"`
+}
+
+type AnalitikPortofolio struct {
+	MataUang        string              `json:"mata_uang"`
+	Dari            time.Time           `json:"dari"`
+	Sampai          time.Time           `json:"sampai"`
+	TotalNilai      Uang                `json:"total_nilai"`
+	TotalModal      Uang                `json:"total_modal"`
+	AlokasiKategori []AlokasiPortofolio `json:"alokasi_kategori"`
+	AlokasiAset     []AlokasiPortofolio `json:"alokasi_aset"`
+	ImbalHasilTWR   float64             `json:"imbal_hasil_twr"`
+	ImbalHasilMWR   *float64            `json:"imbal_hasil_mwr"`
+	KinerjaTerbaik  []KinerjaAset       `json:"kinerja_terbaik"`
+	KinerjaTerburuk []KinerjaAset       `json:"kinerja_terburuk"`
+	Peringatan      []string            `json:"peringatan"`
+}
+
+type AlokasiPortofolio struct {
+	Label  string  `json:"label"`
+	Nilai  Uang    `json:"nilai"`
+	Persen float64 `json:"persen"`
+}
+
+type KinerjaAset struct {
+	IDPortofolio int64   `json:"id_portofolio"`
+	NamaAset     string  `json:"nama_aset"`
+	Simbol       string  `json:"simbol"`
+	Modal        Uang    `json:"modal"`
+	Laba         Uang    `json:"laba"`
+	Persen       float64 `json:"persen"`
 }
 
 type ZakatRingkasan struct {
-	TotalNilai    float64 `json:"total_nilai"`
-	Nisab         float64 `json:"nisab"`
+	TotalAset     Uang    `json:"total_aset"`
+	TotalPengurang Uang   `json:"total_pengurang"`
+	TotalNilai    Uang    `json:"total_nilai"`
+	Nisab         Uang    `json:"nisab"`
 	PersenZakat   float64 `json:"persen_zakat"`
-	ZakatTerhitung float64 `json:"zakat_terhitung"`
+	ZakatTerhitung Uang   `json:"zakat_terhitung"`
 	WajibZakat    bool    `json:"wajib_zakat"`
+	MataUang      string  `json:"mata_uang"`
+	HargaEmasPerGram Uang `json:"harga_emas_per_gram"`
+	MencapaiNisab bool    `json:"mencapai_nisab"`
+	Parameter     *ParameterZakat `json:"parameter"`
+	Haul          *StatusHaul `json:"haul"`
+	Rincian       []RincianZakat `json:"rincian"`
+	Peringatan    []string       `json:"peringatan,omitempty"`
+}
+
+type StatusHaul struct {
+	TanggalHijriah      string     `json:"tanggal_hijriah"`
+	AwalHaul            *time.Time `json:"awal_haul"`
+	AwalHaulHijriah     string     `json:"awal_haul_hijriah,omitempty"`
+	JatuhTempo          *time.Time `json:"jatuh_tempo"`
+	JatuhTempoHijriah   string     `json:"jatuh_tempo_hijriah,omitempty"`
+	SisaHari            int        `json:"sisa_hari"`
+	HaulTercapai        bool       `json:"haul_tercapai"`
+	HaulTerakhir        *time.Time `json:"haul_terakhir"`
+	HaulTerakhirHijriah string     `json:"haul_terakhir_hijriah,omitempty"`
+	HaulTerputus        bool       `json:"haul_terputus"`
+	TerputusPada        *time.Time `json:"terputus_pada"`
+}
+
+type RincianZakat struct {
+	Kelompok          string  `json:"kelompok"`
+	Simbol            string  `json:"simbol"`
+	NamaAset          string  `json:"nama_aset"`
+	Kategori          string  `json:"kategori"`
+	Jumlah            float64 `json:"jumlah"`
+	Nilai             Uang    `json:"nilai"`
+	Nisab             Uang    `json:"nisab,omitempty"`               // nisab aset ini bila dinilai terpisah, misalnya per logam
+	KursTidakTersedia bool    `json:"kurs_tidak_tersedia,omitempty"` // nilai tidak dihitung karena kurs belum ada
+}
+
+type HartaZakat struct {
+	ID             int64     `json:"id"`
+	IDPengguna     int64     `json:"id_pengguna"`
+	Kelompok       string    `json:"kelompok"`
+	Jenis          string    `json:"jenis"`
+	Nama           string    `json:"nama"`
+	Nilai          Uang      `json:"nilai"`
+	MataUang       string    `json:"mata_uang"`
+	Catatan        string    `json:"catatan"`
+	DibuatPada     time.Time `json:"dibuat_pada"`
+	DiperbaruiPada time.Time `json:"diperbarui_pada"`
 }
 
 type ZakatRiwayat struct {
 	ID            int64     `json:"id"`
 	IDPengguna    int64     `json:"id_pengguna"`
-	TotalNilai    float64   `json:"total_nilai"`
-	Nisab         float64   `json:"nisab"`
+	Jenis         string    `json:"jenis"`
+	TotalNilai    Uang      `json:"total_nilai"`
+	Nisab         Uang      `json:"nisab"`
 	PersenZakat   float64   `json:"persen_zakat"`
-	ZakatTerhitung float64  `json:"zakat_terhitung"`
+	ZakatTerhitung Uang     `json:"zakat_terhitung"`
+	MataUang      string    `json:"mata_uang"`
+	Tanggal       time.Time `json:"tanggal"`
+	WajibZakat    bool      `json:"wajib_zakat"`
+	HargaEmasPerGram Uang   `json:"harga_emas_per_gram"`
+	Rincian       []RincianZakat `json:"rincian"`
+	Masukan       json.RawMessage `json:"masukan,omitempty"`
+	Parameter     *ParameterZakat `json:"parameter,omitempty"`
 	DibuatPada    time.Time `json:"dibuat_pada"`
 }
 
+type PembayaranZakat struct {
+	ID             int64     `json:"id"`
+	IDPengguna     int64     `json:"id_pengguna"`
+	Jenis          string    `json:"jenis"`
+	TahunHaul      int       `json:"tahun_haul"`
+	Tanggal        time.Time `json:"tanggal"`
+	Jumlah         Uang      `json:"jumlah"`
+	MataUang       string    `json:"mata_uang"`
+	JumlahIDR      *Uang     `json:"jumlah_idr"` // dikunci dengan kurs saat pembayaran dicatat
+	JenisPenerima  string    `json:"jenis_penerima"`
+	Penerima       string    `json:"penerima"`
+	Asnaf          string    `json:"asnaf"`
+	NomorBukti     string    `json:"nomor_bukti"`
+	BuktiURL       string    `json:"bukti_url"`
+	Catatan        string    `json:"catatan"`
+	DibuatPada     time.Time `json:"dibuat_pada"`
+	DiperbaruiPada time.Time `json:"diperbarui_pada"`
+}
+
+// StatusTahunHaul membandingkan zakat maal yang jatuh tempo pada satu tahun
+// haul (tahun Hijriah jatuh tempo) dengan pembayaran yang dicatat untuknya.
+type StatusTahunHaul struct {
+	TahunHaul         int        `json:"tahun_haul"`
+	JatuhTempo        *time.Time `json:"jatuh_tempo"`
+	JatuhTempoHijriah string     `json:"jatuh_tempo_hijriah,omitempty"`
+	NilaiHarta        Uang       `json:"nilai_harta"`
+	Nisab             Uang       `json:"nisab"`
+	ZakatWajib        Uang       `json:"zakat_wajib"`
+	Dibayar           Uang       `json:"dibayar"`
+	Sisa              Uang       `json:"sisa"`
+	Lunas             bool       `json:"lunas"`
+}
+
+type StatusPembayaranZakat struct {
+	MataUang     string            `json:"mata_uang"`
+	TotalWajib   Uang              `json:"total_wajib"`
+	TotalDibayar Uang              `json:"total_dibayar"`
+	TotalSisa    Uang              `json:"total_sisa"`
+	TahunHaul    []StatusTahunHaul `json:"tahun_haul"`
+}
+
+// LaporanZakatTahunan merangkum pembayaran zakat dalam satu tahun pajak
+// (tahun Masehi) untuk dilampirkan pada SPT Tahunan PPh orang pribadi.
+type LaporanZakatTahunan struct {
+	Tahun                int               `json:"tahun"`
+	NamaPengguna         string            `json:"nama_pengguna"`
+	Email                string            `json:"email"`
+	MataUang             string            `json:"mata_uang"`
+	TotalDibayar         Uang              `json:"total_dibayar"`
+	TotalDapatDikurangkan Uang             `json:"total_dapat_dikurangkan"`
+	Pembayaran           []PembayaranZakat `json:"pembayaran"`
+	Catatan              string            `json:"catatan"`
+	DibuatPada           time.Time         `json:"dibuat_pada"`
+}
+
+type MasukanZakatPenghasilan struct {
+	PenghasilanBulanan Uang   `json:"penghasilan_bulanan"`
+	PenghasilanLain    Uang   `json:"penghasilan_lain"`
+	KebutuhanPokok     Uang   `json:"kebutuhan_pokok"`
+	Cicilan            Uang   `json:"cicilan"`
+	MataUang           string `json:"mata_uang"`
+	StandarNisab       string `json:"standar_nisab"`
+}
+
+type MasukanZakatPerdagangan struct {
+	Persediaan   Uang   `json:"persediaan"`
+	KasUsaha     Uang   `json:"kas_usaha"`
+	Piutang      Uang   `json:"piutang"`
+	Utang        Uang   `json:"utang"`
+	MataUang     string `json:"mata_uang"`
+	StandarNisab string `json:"standar_nisab"`
+}
+
+type MasukanZakatFitrah struct {
+	JumlahJiwa      int     `json:"jumlah_jiwa"`
+	HargaBerasPerKg Uang    `json:"harga_beras_per_kg"`
+	KgPerJiwa       float64 `json:"kg_per_jiwa"`
+	MataUang        string  `json:"mata_uang"`
+}
+
+type MasukanZakatEmasPerak struct {
+	GramEmas          float64 `json:"gram_emas"`
+	GramPerak         float64 `json:"gram_perak"`
+	HargaEmasPerGram  Uang    `json:"harga_emas_per_gram"`
+	HargaPerakPerGram Uang    `json:"harga_perak_per_gram"`
+	MataUang          string  `json:"mata_uang"`
+}
+
 type HargaEmas struct {
 	ID         int64     `json:"id"`
 	Tanggal    time.Time `json:"tanggal"`
-	HargaPerGram float64 `json:"harga_per_gram"`
+	HargaPerGram Uang    `json:"harga_per_gram"`
+	MataUang   string    `json:"mata_uang"`
+	Logam      string    `json:"logam"`
+	Sumber     string    `json:"sumber"`
+}
+
+// HargaLogamTerkini adalah harga logam terbaru beserta usianya. Kedaluwarsa
+// bernilai true bila harga lebih tua dari batas usia yang dikonfigurasi.
+type HargaLogamTerkini struct {
+	HargaEmas
+	UsiaHari    int    `json:"usia_hari"`
+	Kedaluwarsa bool   `json:"kedaluwarsa"`
+	Peringatan  string `json:"peringatan,omitempty"`
+}
+
+type HasilImporHargaLogam struct {
+	JumlahTersimpan int               `json:"jumlah_tersimpan"`
+	JumlahGagal     int               `json:"jumlah_gagal"`
+	Gagal           []BarisGagalHarga `json:"gagal"`
+}
+
+type BarisGagalHarga struct {
+	Nomor  int    `json:"nomor"`
+	Alasan string `json:"alasan"`
+}
+
+// ParameterZakat mencatat aturan yang dipakai sebuah perhitungan zakat agar
+// hasilnya dapat ditelusuri ulang.
+type ParameterZakat struct {
+	StandarNisab        string     `json:"standar_nisab"`
+	GramNisabEmas       float64    `json:"gram_nisab_emas"`
+	GramNisabPerak      float64    `json:"gram_nisab_perak"`
+	PersenZakat         float64    `json:"persen_zakat"`
+	KelipatanPembulatan Uang       `json:"kelipatan_pembulatan"`
+	HargaEmasPerGram    Uang       `json:"harga_emas_per_gram"`
+	HargaPerakPerGram   Uang       `json:"harga_perak_per_gram"`
+	MataUang            string     `json:"mata_uang"`
+	TanggalHargaNisab   *time.Time `json:"tanggal_harga_nisab,omitempty"`
+	SumberHargaNisab    string     `json:"sumber_harga_nisab,omitempty"`
+	HargaKedaluwarsa    bool       `json:"harga_kedaluwarsa"`
+	PeringatanHarga     string     `json:"peringatan_harga,omitempty"`
+}
+
+type Kurs struct {
+	ID             int64     `json:"id"`
+	MataUangAsal   string    `json:"mata_uang_asal"`
+	MataUangTujuan string    `json:"mata_uang_tujuan"`
+	Nilai          float64   `json:"nilai"`
+	Sumber         string    `json:"sumber"`
+	Tanggal        time.Time `json:"tanggal"`
+	DiperbaruiPada time.Time `json:"diperbarui_pada"`
 }
 
 type Reels struct {
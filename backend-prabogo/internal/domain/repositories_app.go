@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type AuthRepository interface {
 	BuatPengguna(ctx context.Context, pengguna *Pengguna) (int64, error)
@@ -10,6 +13,8 @@ type AuthRepository interface {
 	SimpanOTP(ctx context.Context, otp *OTPVerifikasi) error
 	AmbilOTPByPengguna(ctx context.Context, idPengguna int64) (*OTPVerifikasi, error)
 	PerbaruiOTP(ctx context.Context, otp *OTPVerifikasi) error
+	PerbaruiMataUangPengguna(ctx context.Context, id int64, mataUang string) error
+	PerbaruiStandarNisabPengguna(ctx context.Context, id int64, standar string) error
 }
 
 type ScreenerRepository interface {
@@ -31,6 +36,33 @@ type EdukasiRepository interface {
 	DaftarSertifikat(ctx context.Context) ([]Sertifikat, error)
 	SimpanProgress(ctx context.Context, progress *ProgressKelas) error
 	DaftarProgress(ctx context.Context, idPengguna int64) ([]ProgressKelas, error)
+	AmbilProgressKelas(ctx context.Context, idPengguna, idKelas int64) (*ProgressKelas, error)
+	DaftarKelasSemua(ctx context.Context) ([]Kelas, error)
+	DetailKelasPratinjau(ctx context.Context, token string) (*Kelas, error)
+	DaftarModulPratinjau(ctx context.Context, idKelas int64) ([]Modul, error)
+	DaftarMateriPratinjau(ctx context.Context, idKelas int64) ([]Materi, error)
+	DaftarPrasyaratKelas(ctx context.Context, idKelas int64) ([]PrasyaratKelas, error)
+	DaftarJalur(ctx context.Context) ([]JalurBelajar, error)
+	DetailJalur(ctx context.Context, id int64) (*JalurBelajar, error)
+
+	DetailModul(ctx context.Context, id int64) (*Modul, error)
+	DetailMateri(ctx context.Context, id int64) (*Materi, error)
+	DaftarMateriByKelas(ctx context.Context, idKelas int64) ([]Materi, error)
+	AmbilProgressMateri(ctx context.Context, idPengguna, idMateri int64) (*ProgressMateri, error)
+	DaftarProgressMateri(ctx context.Context, idPengguna, idKelas int64) ([]ProgressMateri, error)
+	SimpanProgressMateri(ctx context.Context, progress *ProgressMateri) error
+	DaftarUjianLulus(ctx context.Context, idPengguna, idKelas int64) ([]int64, error)
+	AmbilSertifikatKelas(ctx context.Context, idPengguna, idKelas int64) (*Sertifikat, error)
+	DaftarSertifikatPengguna(ctx context.Context, idPengguna int64) ([]Sertifikat, error)
+	SimpanSertifikat(ctx context.Context, sertifikat *Sertifikat) error
+	AmbilSertifikatByKode(ctx context.Context, kode string) (*VerifikasiSertifikat, error)
+
+	DetailUjian(ctx context.Context, id int64) (*Ujian, error)
+	DaftarSoalUjian(ctx context.Context, idUjian int64) ([]SoalUjian, error)
+	AmbilPercobaanTerakhir(ctx context.Context, idUjian, idPengguna int64) (*PercobaanUjian, error)
+	HitungPercobaanUjian(ctx context.Context, idUjian, idPengguna int64) (int, error)
+	SimpanPercobaanUjian(ctx context.Context, percobaan *PercobaanUjian) error
+	PerbaruiPercobaanUjian(ctx context.Context, percobaan *PercobaanUjian) error
 }
 
 type PustakaRepository interface {
@@ -53,16 +85,92 @@ type DiskusiRepository interface {
 }
 
 type PortofolioRepository interface {
-	DaftarPortofolio(ctx context.Context, idPengguna int64) ([]Portofolio, error)
-	SimpanPortofolio(ctx context.Context, portofolio *Portofolio) error
-	PerbaruiPortofolio(ctx context.Context, portofolio *Portofolio) error
-	HapusPortofolio(ctx context.Context, id int64, idPengguna int64) error
+	DaftarTransaksiPortofolio(ctx context.Context, idPengguna int64, simbol string) ([]TransaksiPortofolio, error)
+	AmbilTransaksiPortofolio(ctx context.Context, id int64, idPengguna int64) (*TransaksiPortofolio, error)
+	SimpanTransaksiPortofolio(ctx context.Context, transaksi *TransaksiPortofolio) error
+	PerbaruiTransaksiPortofolio(ctx context.Context, transaksi *TransaksiPortofolio) error
+	HapusTransaksiPortofolio(ctx context.Context, id int64, idPengguna int64) error
+	PerbaruiAsetPortofolio(ctx context.Context, idPengguna int64, simbol string, mataUang string, namaAset string, kategori string) error
+	HapusPosisiPortofolio(ctx context.Context, idPengguna int64, simbol string, mataUang string) error
+	DaftarKunciPosisiPortofolio(ctx context.Context, idPengguna int64) ([]KunciPosisiPortofolio, error)
+	SimpanKunciPosisiPortofolio(ctx context.Context, kunci *KunciPosisiPortofolio) error
+	DaftarPasar(ctx context.Context) ([]Pasar, error)
+	HargaEmasTerbaru(ctx context.Context) (*HargaEmas, error)
+	DaftarNilaiManual(ctx context.Context, idPengguna int64) ([]NilaiManualPortofolio, error)
+	SimpanNilaiManual(ctx context.Context, nilai *NilaiManualPortofolio) error
+	HapusNilaiManual(ctx context.Context, idPengguna int64, simbol string, mataUang string) error
+	DaftarIDPenggunaPortofolio(ctx context.Context) ([]int64, error)
+	SimpanSnapshotPortofolio(ctx context.Context, snapshot *SnapshotPortofolio) error
+	DaftarSnapshotPortofolio(ctx context.Context, idPengguna int64, dari, sampai time.Time) ([]SnapshotPortofolio, error)
+}
+
+type ImporPortofolioRepository interface {
+	SimpanImporPortofolio(ctx context.Context, impor *ImporPortofolio) error
+	AmbilImporPortofolio(ctx context.Context, id int64, idPengguna int64) (*ImporPortofolio, error)
+	PerbaruiImporPortofolio(ctx context.Context, impor *ImporPortofolio) error
+	UbahStatusImporPortofolio(ctx context.Context, id int64, idPengguna int64, dari, ke string) (bool, error)
+	TerapkanImporPortofolio(ctx context.Context, impor *ImporPortofolio, transaksi []*TransaksiPortofolio) error
+	HapusImporPortofolio(ctx context.Context, id int64, idPengguna int64) error
+}
+
+// PenguraiMutasi mengubah berkas mutasi broker atau exchange menjadi baris
+// transaksi. Urai mengembalikan ErrFormatTidakCocok bila header berkas bukan
+// milik format tersebut, sehingga format dapat dideteksi otomatis.
+type PenguraiMutasi interface {
+	Format() string
+	Urai(data []byte) ([]BarisImpor, error)
 }
 
 type ZakatRepository interface {
 	HargaEmasTerbaru(ctx context.Context) (*HargaEmas, error)
-	DaftarRiwayatZakat(ctx context.Context, idPengguna int64) ([]ZakatRiwayat, error)
+	HargaLogamTerbaru(ctx context.Context, logam string) (*HargaEmas, error)
+	DaftarRiwayatZakat(ctx context.Context, idPengguna int64, jenis string) ([]ZakatRiwayat, error)
+	AmbilRiwayatZakat(ctx context.Context, id int64, idPengguna int64) (*ZakatRiwayat, error)
 	SimpanRiwayatZakat(ctx context.Context, riwayat *ZakatRiwayat) error
+	DaftarHartaZakat(ctx context.Context, idPengguna int64) ([]HartaZakat, error)
+	DaftarIDPenggunaHartaZakat(ctx context.Context) ([]int64, error)
+	AmbilHartaZakat(ctx context.Context, id int64, idPengguna int64) (*HartaZakat, error)
+	SimpanHartaZakat(ctx context.Context, harta *HartaZakat) error
+	PerbaruiHartaZakat(ctx context.Context, harta *HartaZakat) error
+	HapusHartaZakat(ctx context.Context, id int64, idPengguna int64) error
+	DaftarPembayaranZakat(ctx context.Context, idPengguna int64, dari, sampai time.Time) ([]PembayaranZakat, error)
+	AmbilPembayaranZakat(ctx context.Context, id int64, idPengguna int64) (*PembayaranZakat, error)
+	SimpanPembayaranZakat(ctx context.Context, pembayaran *PembayaranZakat) error
+	PerbaruiPembayaranZakat(ctx context.Context, pembayaran *PembayaranZakat) error
+	HapusPembayaranZakat(ctx context.Context, id int64, idPengguna int64) error
+}
+
+type RiwayatHargaRepository interface {
+	DaftarRiwayatHarga(ctx context.Context, simbol []string, sampai time.Time) ([]RiwayatHarga, error)
+	DaftarHargaEmas(ctx context.Context, dari, sampai time.Time) ([]HargaEmas, error)
+	DaftarHargaLogam(ctx context.Context, logam string, dari, sampai time.Time) ([]HargaEmas, error)
+}
+
+type KonfigurasiRepository interface {
+	DaftarKonfigurasi(ctx context.Context) ([]Konfigurasi, error)
+}
+
+type KursRepository interface {
+	SimpanKurs(ctx context.Context, kurs *Kurs) error
+	DaftarKursTerbaru(ctx context.Context) ([]Kurs, error)
+	DaftarKursPada(ctx context.Context, tanggal time.Time) ([]Kurs, error)
+}
+
+type PenyediaKurs interface {
+	Nama() string
+	AmbilKurs(ctx context.Context, dasar string) (map[string]float64, error)
+}
+
+// PenyediaHargaLogam mengambil harga per gram emas atau perak dari sumber luar.
+type PenyediaHargaLogam interface {
+	Nama() string
+	AmbilHargaLogam(ctx context.Context, logam, mataUang string) (Uang, error)
+}
+
+// PenyediaHargaPasar mengambil kuotasi terbaru untuk daftar simbol pasar.
+type PenyediaHargaPasar interface {
+	Nama() string
+	AmbilHargaPasar(ctx context.Context, simbol []string) ([]Pasar, error)
 }
 
 type ReelsRepository interface {
@@ -82,6 +190,13 @@ type AdminRepository interface {
 	BuatKelas(ctx context.Context, kelas *Kelas) error
 	PerbaruiKelas(ctx context.Context, kelas *Kelas) error
 	HapusKelas(ctx context.Context, id int64) error
+	SimpanTokenPratinjau(ctx context.Context, idKelas int64, token string, kadaluarsa time.Time) (bool, error)
+	DaftarPrasyaratSemua(ctx context.Context) ([]PrasyaratKelas, error)
+	SimpanPrasyaratKelas(ctx context.Context, idKelas int64, idPrasyarat []int64) error
+	DaftarJalurSemua(ctx context.Context) ([]JalurBelajar, error)
+	BuatJalur(ctx context.Context, jalur *JalurBelajar) error
+	PerbaruiJalur(ctx context.Context, jalur *JalurBelajar) error
+	HapusJalur(ctx context.Context, id int64) error
 
 	BuatModul(ctx context.Context, modul *Modul) error
 	PerbaruiModul(ctx context.Context, modul *Modul) error
@@ -95,6 +210,10 @@ type AdminRepository interface {
 	PerbaruiUjian(ctx context.Context, ujian *Ujian) error
 	HapusUjian(ctx context.Context, id int64) error
 
+	BuatSoalUjian(ctx context.Context, soal *SoalUjian) error
+	PerbaruiSoalUjian(ctx context.Context, soal *SoalUjian) error
+	HapusSoalUjian(ctx context.Context, id int64) error
+
 	BuatSertifikat(ctx context.Context, sertifikat *Sertifikat) error
 	HapusSertifikat(ctx context.Context, id int64) error
 
@@ -112,8 +231,13 @@ type AdminRepository interface {
 
 	BuatPasar(ctx context.Context, pasar *Pasar) error
 	PerbaruiPasar(ctx context.Context, pasar *Pasar) error
+	SimpanRiwayatHarga(ctx context.Context, riwayat *RiwayatHarga) error
 	HapusPasar(ctx context.Context, id int64) error
 
+	SimpanHargaLogam(ctx context.Context, harga *HargaEmas) error
+	PerbaruiHargaLogam(ctx context.Context, harga *HargaEmas) error
+	HapusHargaLogam(ctx context.Context, id int64) error
+
 	BuatReels(ctx context.Context, reels *Reels) error
 	PerbaruiReels(ctx context.Context, reels *Reels) error
 	HapusReels(ctx context.Context, id int64) error
@@ -127,3 +251,7 @@ type AdminRepository interface {
 	HapusKonfigurasi(ctx context.Context, id int64) error
 	DaftarKonfigurasi(ctx context.Context) ([]Konfigurasi, error)
 }
+
+type PenerbitHarga interface {
+	Terbitkan(pasar Pasar)
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "errors"
+
+const (
+	TransaksiBeli           = "beli"
+	TransaksiJual           = "jual"
+	TransaksiDividen        = "dividen"
+	TransaksiTransferMasuk  = "transfer_masuk"
+	TransaksiTransferKeluar = "transfer_keluar"
+)
+
+// Metode penghitungan harga pokok saat aset dijual sebagian.
+const (
+	MetodeFIFO     = "fifo"
+	MetodeRataRata = "rata_rata"
+)
+
+func JenisTransaksiValid(jenis string) bool {
+	switch jenis {
+	case TransaksiBeli, TransaksiJual, TransaksiDividen, TransaksiTransferMasuk, TransaksiTransferKeluar:
+		return true
+	}
+	return false
+}
+
+// Asal harga yang dipakai untuk menilai sebuah posisi.
+const (
+	SumberHargaPasar     = "pasar"
+	SumberHargaEmas      = "harga_emas"
+	SumberHargaManual    = "manual"
+	SumberHargaTransaksi = "transaksi"
+)
+
+const (
+	StatusHargaTerkini       = "terkini"
+	StatusHargaUsang         = "usang"
+	StatusHargaManual        = "manual"
+	StatusHargaTidakTersedia = "tidak_tersedia"
+)
+
+// Status impor mutasi dan status tiap barisnya.
+const (
+	ImporPratinjau  = "pratinjau"
+	ImporMenerapkan = "menerapkan"
+	ImporDiterapkan = "diterapkan"
+
+	BarisBaru      = "baru"
+	BarisDuplikat  = "duplikat"
+	BarisGagal     = "gagal"
+	BarisTersimpan = "tersimpan"
+)
+
+var ErrFormatTidakCocok = errors.New("format berkas tidak cocok")
@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// SkalaUang adalah jumlah angka di belakang koma yang disimpan, sama dengan
+// kolom DECIMAL(20,4) di database.
+const SkalaUang = 4
+
+const pembagiUang = 10000
+
+var (
+	ErrMelampauiBatas = errors.New("nilai melampaui batas")
+	ErrPembagiNol     = errors.New("pembagi nol")
+)
+
+// Uang adalah nilai moneter desimal pasti dalam satuan 1/10000. Penjumlahan dan
+// pengurangan memakai operator biasa; perkalian dengan faktor pecahan memakai Kali
+// agar hasilnya dibulatkan secara eksplisit, bukan tergantung galat float64.
+type Uang int64
+
+func UangDariString(s string) (Uang, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, fmt.Errorf("nilai uang tidak valid: %q", s)
+	}
+	return uangDariRat(r)
+}
+
+// UangDariFloat membulatkan float64 ke skala uang berdasarkan representasi
+// desimal terpendeknya, sehingga 0.1 menjadi tepat 0.1000. NaN, tak hingga
+// dan nilai di luar batas menghasilkan error.
+func UangDariFloat(f float64) (Uang, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("nilai uang tidak valid: %v", f)
+	}
+	return UangDariString(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+func (u Uang) Float64() float64 {
+	return float64(u) / pembagiUang
+}
+
+// String menghasilkan bentuk desimal tanpa nol berlebih, misalnya "1234567.89".
+func (u Uang) String() string {
+	return formatDesimal(int64(u), pembagiUang, SkalaUang, true)
+}
+
+// Kali mengalikan dengan faktor (jumlah unit, kurs, persen) lalu membulatkan
+// setengah menjauhi nol ke skala uang. Faktor NaN atau tak hingga dan hasil di
+// luar batas menghasilkan error.
+func (u Uang) Kali(faktor float64) (Uang, error) {
+	r, err := kaliRat(int64(u), pembagiUang, faktor)
+	if err != nil {
+		return 0, err
+	}
+	return uangDariRat(r)
+}
+
+// Bagi membagi dengan pembagi lalu membulatkan ke skala uang; pembagi nol
+// menghasilkan ErrPembagiNol.
+func (u Uang) Bagi(pembagi float64) (Uang, error) {
+	r, err := bagiRat(int64(u), pembagiUang, pembagi)
+	if err != nil {
+		return 0, err
+	}
+	return uangDariRat(r)
+}
+
+// Rasio mengembalikan u/v sebagai float64 untuk perhitungan persentase.
+func (u Uang) Rasio(v Uang) float64 {
+	if v == 0 {
+		return 0
+	}
+	return float64(u) / float64(v)
+}
+
+// Bulatkan membulatkan setengah menjauhi nol ke jumlah digit resmi mata uang.
+func (u Uang) Bulatkan(mataUang string) Uang {
+	digit := DigitMataUang(mataUang)
+	if digit >= SkalaUang {
+		return u
+	}
+	satuan := int64(math.Pow10(SkalaUang - digit))
+	n := int64(u)
+	sisa := n % satuan
+	n -= sisa
+	if sisa*2 >= satuan {
+		n += satuan
+	} else if sisa*2 <= -satuan {
+		n -= satuan
+	}
+	return Uang(n)
+}
+
+func (u Uang) MarshalJSON() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+func (u *Uang) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(strings.TrimSpace(string(data)), `"`)
+	if s == "null" {
+		*u = 0
+		return nil
+	}
+	hasil, err := UangDariString(s)
+	if err != nil {
+		return err
+	}
+	*u = hasil
+	return nil
+}
+
+func (u *Uang) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*u = 0
+		return nil
+	case []byte:
+		hasil, err := UangDariString(string(v))
+		*u = hasil
+		return err
+	case string:
+		hasil, err := UangDariString(v)
+		*u = hasil
+		return err
+	case float64:
+		hasil, err := UangDariFloat(v)
+		*u = hasil
+		return err
+	case int64:
+		if v > math.MaxInt64/pembagiUang || v < math.MinInt64/pembagiUang {
+			return fmt.Errorf("nilai uang %d melampaui batas", v)
+		}
+		*u = Uang(v * pembagiUang)
+		return nil
+	}
+	return fmt.Errorf("tipe %T tidak dapat dibaca sebagai uang", src)
+}
+
+func (u Uang) Value() (driver.Value, error) {
+	return formatDesimal(int64(u), pembagiUang, SkalaUang, false), nil
+}
+
+func uangDariRat(r *big.Rat) (Uang, error) {
+	n, err := desimalDariRat(r, pembagiUang)
+	return Uang(n), err
+}
+
+// desimalDariRat membulatkan r setengah menjauhi nol ke kelipatan 1/pembagi.
+func desimalDariRat(r *big.Rat, pembagi int64) (int64, error) {
+	skala := new(big.Rat).Mul(r, new(big.Rat).SetInt64(pembagi))
+	num := new(big.Int).Set(skala.Num())
+	den := skala.Denom()
+
+	hasil, sisa := new(big.Int).QuoRem(num, den, new(big.Int))
+	if sisa.Sign() != 0 {
+		dua := new(big.Int).Mul(new(big.Int).Abs(sisa), big.NewInt(2))
+		if dua.Cmp(den) >= 0 {
+			if num.Sign() < 0 {
+				hasil.Sub(hasil, big.NewInt(1))
+			} else {
+				hasil.Add(hasil, big.NewInt(1))
+			}
+		}
+	}
+	if !hasil.IsInt64() {
+		return 0, ErrMelampauiBatas
+	}
+	return hasil.Int64(), nil
+}
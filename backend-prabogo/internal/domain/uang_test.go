@@ -0,0 +1,227 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func uangUji(t *testing.T, s string) Uang {
+	t.Helper()
+	u, err := UangDariString(s)
+	if err != nil {
+		t.Fatalf("UangDariString(%q): %v", s, err)
+	}
+	return u
+}
+
+func hargaUji(t *testing.T, s string) Harga {
+	t.Helper()
+	h, err := HargaDariString(s)
+	if err != nil {
+		t.Fatalf("HargaDariString(%q): %v", s, err)
+	}
+	return h
+}
+
+func TestUangKali(t *testing.T) {
+	tests := []struct {
+		nilai  string
+		faktor float64
+		ingin  string
+	}{
+		{"100", 0.1, "10"},
+		{"0.0001", 0.5, "0.0001"},
+		{"-0.0001", 0.5, "-0.0001"},
+		{"0.0001", 0.49, "0"},
+		{"15000", 0.025, "375"},
+		{"1.2345", 3, "3.7035"},
+		{"10", 0, "0"},
+	}
+	for _, tt := range tests {
+		got, err := uangUji(t, tt.nilai).Kali(tt.faktor)
+		if err != nil || got != uangUji(t, tt.ingin) {
+			t.Errorf("%s.Kali(%v) = %s, %v, ingin %s", tt.nilai, tt.faktor, got, err, tt.ingin)
+		}
+	}
+}
+
+func TestUangBagi(t *testing.T) {
+	tests := []struct {
+		nilai   string
+		pembagi float64
+		ingin   string
+	}{
+		{"10", 3, "3.3333"},
+		{"20", 3, "6.6667"},
+		{"-20", 3, "-6.6667"},
+		{"1", 16000, "0.0001"},
+	}
+	for _, tt := range tests {
+		got, err := uangUji(t, tt.nilai).Bagi(tt.pembagi)
+		if err != nil || got != uangUji(t, tt.ingin) {
+			t.Errorf("%s.Bagi(%v) = %s, %v, ingin %s", tt.nilai, tt.pembagi, got, err, tt.ingin)
+		}
+	}
+}
+
+// TestAritmetikaGagal memastikan operasi yang tidak bisa dihitung pasti
+// mengembalikan error alih-alih nol atau nilai jenuh.
+func TestAritmetikaGagal(t *testing.T) {
+	besar := Uang(math.MaxInt64 / 2)
+	hargaBesar := Harga(math.MaxInt64 / 2)
+	tests := []struct {
+		nama   string
+		hitung func() error
+		ingin  error
+	}{
+		{"Uang.Kali meluap", func() error { _, err := besar.Kali(3); return err }, ErrMelampauiBatas},
+		{"Uang.Kali meluap negatif", func() error { _, err := besar.Kali(-3); return err }, ErrMelampauiBatas},
+		{"Uang.Kali NaN", func() error { _, err := besar.Kali(math.NaN()); return err }, nil},
+		{"Uang.Kali tak hingga", func() error { _, err := besar.Kali(math.Inf(1)); return err }, nil},
+		{"Uang.Bagi nol", func() error { _, err := besar.Bagi(0); return err }, ErrPembagiNol},
+		{"Uang.Bagi meluap", func() error { _, err := besar.Bagi(0.1); return err }, ErrMelampauiBatas},
+		{"Uang.Bagi NaN", func() error { _, err := besar.Bagi(math.NaN()); return err }, nil},
+		{"Harga.Kali meluap", func() error { _, err := hargaBesar.Kali(1e9); return err }, ErrMelampauiBatas},
+		{"Harga.KaliFaktor meluap", func() error { _, err := hargaBesar.KaliFaktor(16000); return err }, ErrMelampauiBatas},
+		{"Harga.KaliFaktor tak hingga", func() error { _, err := hargaBesar.KaliFaktor(math.Inf(-1)); return err }, nil},
+		{"Harga.BagiFaktor nol", func() error { _, err := hargaBesar.BagiFaktor(0); return err }, ErrPembagiNol},
+		{"Harga.BagiFaktor meluap", func() error { _, err := hargaBesar.BagiFaktor(1.0 / 16000); return err }, ErrMelampauiBatas},
+		{"Uang.PerUnit nol", func() error { _, err := besar.PerUnit(0); return err }, ErrPembagiNol},
+		{"Uang.PerUnit meluap", func() error { _, err := besar.PerUnit(0.5); return err }, ErrMelampauiBatas},
+		{"Uang.PerUnit NaN", func() error { _, err := besar.PerUnit(math.NaN()); return err }, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			err := tt.hitung()
+			if err == nil {
+				t.Fatal("tidak mengembalikan error")
+			}
+			if tt.ingin != nil && !errors.Is(err, tt.ingin) {
+				t.Errorf("error = %v, ingin %v", err, tt.ingin)
+			}
+		})
+	}
+}
+
+func TestUangBulatkan(t *testing.T) {
+	tests := []struct {
+		nilai    string
+		mataUang string
+		ingin    string
+	}{
+		{"1234.5", "IDR", "1235"},
+		{"1234.4999", "IDR", "1234"},
+		{"-1234.5", "IDR", "-1235"},
+		{"12.345", "USD", "12.35"},
+		{"12.3449", "USD", "12.34"},
+		{"-12.345", "USD", "-12.35"},
+		{"1.2345", "KWD", "1.235"},
+		{"1.2345", "XYZ", "1.23"},
+	}
+	for _, tt := range tests {
+		if got := uangUji(t, tt.nilai).Bulatkan(tt.mataUang); got != uangUji(t, tt.ingin) {
+			t.Errorf("%s.Bulatkan(%s) = %s, ingin %s", tt.nilai, tt.mataUang, got, tt.ingin)
+		}
+	}
+}
+
+func TestUangDariFloat(t *testing.T) {
+	u, err := UangDariFloat(0.1)
+	if err != nil || u != 1000 {
+		t.Errorf("UangDariFloat(0.1) = %d, %v; ingin 1000", u, err)
+	}
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1), 1e30} {
+		if _, err := UangDariFloat(f); err == nil {
+			t.Errorf("UangDariFloat(%v) tidak mengembalikan error", f)
+		}
+	}
+}
+
+func TestUangScanValue(t *testing.T) {
+	for _, s := range []string{"0", "1234567.8901", "-0.0001", "922337203685477.5807"} {
+		awal := uangUji(t, s)
+		v, err := awal.Value()
+		if err != nil {
+			t.Fatalf("Value(%s): %v", s, err)
+		}
+		var hasil Uang
+		if err := hasil.Scan([]byte(v.(string))); err != nil {
+			t.Fatalf("Scan(%q): %v", v, err)
+		}
+		if hasil != awal {
+			t.Errorf("round-trip %s menjadi %s", awal, hasil)
+		}
+	}
+
+	sumber := []struct {
+		src   interface{}
+		ingin Uang
+	}{
+		{nil, 0},
+		{"12.5", 125000},
+		{float64(0.3), 3000},
+		{int64(7), 70000},
+	}
+	for _, tt := range sumber {
+		var u Uang
+		if err := u.Scan(tt.src); err != nil || u != tt.ingin {
+			t.Errorf("Scan(%#v) = %d, %v; ingin %d", tt.src, u, err, tt.ingin)
+		}
+	}
+	for _, src := range []interface{}{"abc", math.NaN(), int64(math.MaxInt64), true} {
+		var u Uang
+		if err := u.Scan(src); err == nil {
+			t.Errorf("Scan(%#v) tidak mengembalikan error", src)
+		}
+	}
+}
+
+func TestHargaPresisi(t *testing.T) {
+	h := hargaUji(t, "0.00000123")
+	if h != 123 {
+		t.Fatalf("HargaDariString = %d, ingin 123", h)
+	}
+	if got := h.String(); got != "0.00000123" {
+		t.Errorf("String = %q", got)
+	}
+	if got, err := h.Kali(1000000); err != nil || got != uangUji(t, "1.23") {
+		t.Errorf("Kali = %s, %v, ingin 1.23", got, err)
+	}
+	// Harga di bawah skala uang tetap utuh saat dikonversi kurs.
+	if got, err := h.KaliFaktor(16000); err != nil || got != hargaUji(t, "0.01968") {
+		t.Errorf("KaliFaktor = %s, %v, ingin 0.01968", got, err)
+	}
+	if got, err := hargaUji(t, "0.01968").BagiFaktor(16000); err != nil || got != h {
+		t.Errorf("BagiFaktor = %s, %v, ingin %s", got, err, h)
+	}
+	if got, err := uangUji(t, "10").PerUnit(3); err != nil || got != hargaUji(t, "3.33333333") {
+		t.Errorf("PerUnit = %s, %v", got, err)
+	}
+	if got := uangUji(t, "1.5").Harga(); got != hargaUji(t, "1.5") {
+		t.Errorf("Uang.Harga = %s", got)
+	}
+	if got := hargaUji(t, "1.23456789").Uang(); got != uangUji(t, "1.2346") {
+		t.Errorf("Harga.Uang = %s", got)
+	}
+}
+
+func TestHargaScanValue(t *testing.T) {
+	for _, s := range []string{"0", "0.00000001", "65000.12345678", "-3.5"} {
+		awal := hargaUji(t, s)
+		v, err := awal.Value()
+		if err != nil {
+			t.Fatalf("Value(%s): %v", s, err)
+		}
+		var hasil Harga
+		if err := hasil.Scan(v); err != nil {
+			t.Fatalf("Scan(%q): %v", v, err)
+		}
+		if hasil != awal {
+			t.Errorf("round-trip %s menjadi %s", awal, hasil)
+		}
+	}
+	if _, err := HargaDariFloat(math.Inf(1)); err == nil {
+		t.Error("HargaDariFloat(+Inf) tidak mengembalikan error")
+	}
+}
@@ -0,0 +1,26 @@
+package domain
+
+// Tipe soal di bank soal ujian.
+const (
+	SoalPilihanGanda = "pilihan_ganda"
+	SoalBenarSalah   = "benar_salah"
+	SoalIsian        = "isian"
+)
+
+func TipeSoalValid(tipe string) bool {
+	switch tipe {
+	case SoalPilihanGanda, SoalBenarSalah, SoalIsian:
+		return true
+	}
+	return false
+}
+
+// Status percobaan ujian. Percobaan berjalan yang melewati batas waktu
+// dinilai dengan jawaban yang sudah tersimpan.
+const (
+	PercobaanBerjalan = "berjalan"
+	PercobaanSelesai  = "selesai"
+)
+
+// NilaiLulusBawaan dipakai bila ujian tidak menetapkan nilai lulus.
+const NilaiLulusBawaan = 70
@@ -0,0 +1,86 @@
+package domain
+
+import "errors"
+
+// Jenis zakat yang dihitung dan disimpan di riwayat zakat.
+const (
+	ZakatMaal        = "maal"
+	ZakatPenghasilan = "penghasilan"
+	ZakatPerdagangan = "perdagangan"
+	ZakatFitrah      = "fitrah"
+	ZakatEmasPerak   = "emas_perak"
+)
+
+func JenisZakatValid(jenis string) bool {
+	switch jenis {
+	case ZakatMaal, ZakatPenghasilan, ZakatPerdagangan, ZakatFitrah, ZakatEmasPerak:
+		return true
+	}
+	return false
+}
+
+// Standar nisab zakat maal.
+const (
+	NisabEmas  = "emas"
+	NisabPerak = "perak"
+)
+
+// ErrLogamTidakValid dikembalikan untuk logam selain emas atau perak.
+var ErrLogamTidakValid = errors.New("logam harus emas atau perak")
+
+// Kelompok baris rincian perhitungan zakat dan harta non-portofolio.
+const (
+	RincianAset      = "aset"
+	RincianPengurang = "pengurang"
+)
+
+// Jenis harta non-portofolio yang dicatat pengguna untuk zakat maal. Kas dan
+// tabungan menambah harta; utang dan cicilan jatuh tempo menjadi pengurang.
+const (
+	HartaKas      = "kas"
+	HartaTabungan = "tabungan"
+	HartaDeposito = "deposito"
+	HartaPiutang  = "piutang"
+	HartaUtang    = "utang"
+	HartaCicilan  = "cicilan"
+)
+
+// KelompokHarta mengembalikan kelompok rincian untuk jenis harta, atau string
+// kosong bila jenis tidak dikenal.
+func KelompokHarta(jenis string) string {
+	switch jenis {
+	case HartaKas, HartaTabungan, HartaDeposito, HartaPiutang:
+		return RincianAset
+	case HartaUtang, HartaCicilan:
+		return RincianPengurang
+	}
+	return ""
+}
+
+// Penyaluran zakat: lewat lembaga amil (BAZNAS/LAZ) atau langsung ke mustahik.
+// Hanya pembayaran lewat lembaga amil resmi dengan bukti setor yang dapat
+// menjadi pengurang penghasilan bruto pada SPT Tahunan.
+const (
+	PenerimaAmil     = "amil"
+	PenerimaMustahik = "mustahik"
+)
+
+// Delapan golongan penerima zakat (asnaf) menurut QS At-Taubah: 60.
+const (
+	AsnafFakir        = "fakir"
+	AsnafMiskin       = "miskin"
+	AsnafAmil         = "amil"
+	AsnafMualaf       = "mualaf"
+	AsnafRiqab        = "riqab"
+	AsnafGharimin     = "gharimin"
+	AsnafFisabilillah = "fisabilillah"
+	AsnafIbnuSabil    = "ibnu_sabil"
+)
+
+func AsnafValid(asnaf string) bool {
+	switch asnaf {
+	case AsnafFakir, AsnafMiskin, AsnafAmil, AsnafMualaf, AsnafRiqab, AsnafGharimin, AsnafFisabilillah, AsnafIbnuSabil:
+		return true
+	}
+	return false
+}
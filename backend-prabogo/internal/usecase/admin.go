@@ -2,16 +2,20 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/averroes/backend-prabogo/internal/domain"
 )
 
 type AdminUsecase struct {
-	repo domain.AdminRepository
+	repo          domain.AdminRepository
+	penerbitHarga domain.PenerbitHarga
 }
 
-func NewAdminUsecase(repo domain.AdminRepository) *AdminUsecase {
-	return &AdminUsecase{repo: repo}
+func NewAdminUsecase(repo domain.AdminRepository, penerbitHarga domain.PenerbitHarga) *AdminUsecase {
+	return &AdminUsecase{repo: repo, penerbitHarga: penerbitHarga}
 }
 
 func (u *AdminUsecase) DaftarPengguna(ctx context.Context) ([]domain.Pengguna, error) {
@@ -27,10 +31,16 @@ func (u *AdminUsecase) HapusPengguna(ctx context.Context, id int64) error {
 }
 
 func (u *AdminUsecase) BuatKelas(ctx context.Context, kelas *domain.Kelas) error {
+	if err := siapkanStatusKonten(&kelas.Status, true); err != nil {
+		return err
+	}
 	return u.repo.BuatKelas(ctx, kelas)
 }
 
 func (u *AdminUsecase) PerbaruiKelas(ctx context.Context, kelas *domain.Kelas) error {
+	if err := siapkanStatusKonten(&kelas.Status, false); err != nil {
+		return err
+	}
 	return u.repo.PerbaruiKelas(ctx, kelas)
 }
 
@@ -39,10 +49,16 @@ func (u *AdminUsecase) HapusKelas(ctx context.Context, id int64) error {
 }
 
 func (u *AdminUsecase) BuatModul(ctx context.Context, modul *domain.Modul) error {
+	if err := siapkanStatusKonten(&modul.Status, true); err != nil {
+		return err
+	}
 	return u.repo.BuatModul(ctx, modul)
 }
 
 func (u *AdminUsecase) PerbaruiModul(ctx context.Context, modul *domain.Modul) error {
+	if err := siapkanStatusKonten(&modul.Status, false); err != nil {
+		return err
+	}
 	return u.repo.PerbaruiModul(ctx, modul)
 }
 
@@ -51,10 +67,16 @@ func (u *AdminUsecase) HapusModul(ctx context.Context, id int64) error {
 }
 
 func (u *AdminUsecase) BuatMateri(ctx context.Context, materi *domain.Materi) error {
+	if err := siapkanStatusKonten(&materi.Status, true); err != nil {
+		return err
+	}
 	return u.repo.BuatMateri(ctx, materi)
 }
 
 func (u *AdminUsecase) PerbaruiMateri(ctx context.Context, materi *domain.Materi) error {
+	if err := siapkanStatusKonten(&materi.Status, false); err != nil {
+		return err
+	}
 	return u.repo.PerbaruiMateri(ctx, materi)
 }
 
@@ -63,10 +85,16 @@ func (u *AdminUsecase) HapusMateri(ctx context.Context, id int64) error {
 }
 
 func (u *AdminUsecase) BuatUjian(ctx context.Context, ujian *domain.Ujian) error {
+	if ujian.NilaiLulus == 0 {
+		ujian.NilaiLulus = domain.NilaiLulusBawaan
+	}
 	return u.repo.BuatUjian(ctx, ujian)
 }
 
 func (u *AdminUsecase) PerbaruiUjian(ctx context.Context, ujian *domain.Ujian) error {
+	if ujian.NilaiLulus == 0 {
+		ujian.NilaiLulus = domain.NilaiLulusBawaan
+	}
 	return u.repo.PerbaruiUjian(ctx, ujian)
 }
 
@@ -74,7 +102,98 @@ func (u *AdminUsecase) HapusUjian(ctx context.Context, id int64) error {
 	return u.repo.HapusUjian(ctx, id)
 }
 
+func (u *AdminUsecase) BuatSoalUjian(ctx context.Context, soal *domain.SoalUjian) error {
+	if err := validasiSoal(soal); err != nil {
+		return err
+	}
+	soal.DibuatPada = time.Now()
+	return u.repo.BuatSoalUjian(ctx, soal)
+}
+
+func (u *AdminUsecase) PerbaruiSoalUjian(ctx context.Context, soal *domain.SoalUjian) error {
+	if err := validasiSoal(soal); err != nil {
+		return err
+	}
+	return u.repo.PerbaruiSoalUjian(ctx, soal)
+}
+
+func (u *AdminUsecase) HapusSoalUjian(ctx context.Context, id int64) error {
+	return u.repo.HapusSoalUjian(ctx, id)
+}
+
+// validasiSoal memeriksa kunci jawaban sesuai tipe soal. Soal benar/salah
+// tanpa opsi diberi opsi "Benar" dan "Salah" dengan kunci dari jawaban_isian.
+func validasiSoal(soal *domain.SoalUjian) error {
+	soal.Tipe = strings.ToLower(strings.TrimSpace(soal.Tipe))
+	soal.Pertanyaan = strings.TrimSpace(soal.Pertanyaan)
+	soal.JawabanIsian = strings.TrimSpace(soal.JawabanIsian)
+	if !domain.TipeSoalValid(soal.Tipe) {
+		return errors.New("tipe soal harus pilihan_ganda, benar_salah atau isian")
+	}
+	if soal.Pertanyaan == "" {
+		return errors.New("pertanyaan wajib diisi")
+	}
+	if soal.Bobot < 0 {
+		return errors.New("bobot soal tidak boleh negatif")
+	}
+	if soal.Bobot == 0 {
+		soal.Bobot = 1
+	}
+
+	switch soal.Tipe {
+	case domain.SoalIsian:
+		if soal.JawabanIsian == "" {
+			return errors.New("jawaban_isian wajib diisi untuk soal isian")
+		}
+		soal.Opsi = nil
+		return nil
+	case domain.SoalBenarSalah:
+		if len(soal.Opsi) == 0 {
+			kunci := strings.ToLower(soal.JawabanIsian)
+			if kunci != "benar" && kunci != "salah" {
+				return errors.New("soal benar_salah memerlukan opsi atau jawaban_isian benar/salah")
+			}
+			soal.Opsi = []domain.OpsiSoal{
+				{Teks: "Benar", Benar: kunci == "benar"},
+				{Teks: "Salah", Benar: kunci == "salah"},
+			}
+		}
+		if len(soal.Opsi) != 2 {
+			return errors.New("soal benar_salah harus memiliki tepat dua opsi")
+		}
+	default:
+		if len(soal.Opsi) < 2 {
+			return errors.New("soal pilihan_ganda minimal memiliki dua opsi")
+		}
+	}
+
+	soal.JawabanIsian = ""
+	jumlahBenar := 0
+	for i := range soal.Opsi {
+		soal.Opsi[i].Teks = strings.TrimSpace(soal.Opsi[i].Teks)
+		if soal.Opsi[i].Teks == "" {
+			return errors.New("teks opsi wajib diisi")
+		}
+		soal.Opsi[i].Urutan = i + 1
+		if soal.Opsi[i].Benar {
+			jumlahBenar++
+		}
+	}
+	if jumlahBenar != 1 {
+		return errors.New("soal harus memiliki tepat satu opsi benar")
+	}
+	return nil
+}
+
 func (u *AdminUsecase) BuatSertifikat(ctx context.Context, sertifikat *domain.Sertifikat) error {
+	sertifikat.Kode = strings.TrimSpace(sertifikat.Kode)
+	if sertifikat.Kode == "" {
+		kode, err := buatKodeSertifikat()
+		if err != nil {
+			return err
+		}
+		sertifikat.Kode = kode
+	}
 	return u.repo.BuatSertifikat(ctx, sertifikat)
 }
 
@@ -107,23 +226,64 @@ func (u *AdminUsecase) HapusBerita(ctx context.Context, id int64) error {
 }
 
 func (u *AdminUsecase) BuatScreener(ctx context.Context, screener *domain.Screener) error {
+	screener.KategoriAset = normalisasiKategoriAset(screener.KategoriAset)
 	return u.repo.BuatScreener(ctx, screener)
 }
 
 func (u *AdminUsecase) PerbaruiScreener(ctx context.Context, screener *domain.Screener) error {
+	screener.KategoriAset = normalisasiKategoriAset(screener.KategoriAset)
 	return u.repo.PerbaruiScreener(ctx, screener)
 }
 
+func normalisasiKategoriAset(kategori string) string {
+	kategori = strings.ToLower(strings.TrimSpace(kategori))
+	if kategori == "" {
+		return domain.KategoriAsetDefault
+	}
+	return kategori
+}
+
 func (u *AdminUsecase) HapusScreener(ctx context.Context, id int64) error {
 	return u.repo.HapusScreener(ctx, id)
 }
 
 func (u *AdminUsecase) BuatPasar(ctx context.Context, pasar *domain.Pasar) error {
-	return u.repo.BuatPasar(ctx, pasar)
+	pasar.MataUang = domain.NormalisasiMataUang(pasar.MataUang, domain.MataUangUSD)
+	pasar.KategoriAset = normalisasiKategoriAset(pasar.KategoriAset)
+	if err := u.repo.BuatPasar(ctx, pasar); err != nil {
+		return err
+	}
+	u.terbitkanHarga(pasar)
+	return u.catatRiwayatHarga(ctx, pasar)
 }
 
 func (u *AdminUsecase) PerbaruiPasar(ctx context.Context, pasar *domain.Pasar) error {
-	return u.repo.PerbaruiPasar(ctx, pasar)
+	pasar.MataUang = domain.NormalisasiMataUang(pasar.MataUang, domain.MataUangUSD)
+	pasar.KategoriAset = normalisasiKategoriAset(pasar.KategoriAset)
+	if err := u.repo.PerbaruiPasar(ctx, pasar); err != nil {
+		return err
+	}
+	u.terbitkanHarga(pasar)
+	return u.catatRiwayatHarga(ctx, pasar)
+}
+
+func (u *AdminUsecase) terbitkanHarga(pasar *domain.Pasar) {
+	if u.penerbitHarga == nil {
+		return
+	}
+	pasar.DiperbaruiPada = time.Now()
+	u.penerbitHarga.Terbitkan(*pasar)
+}
+
+// catatRiwayatHarga menyimpan harga penutupan harian; pembaruan di hari yang
+// sama menimpa harga hari itu.
+func (u *AdminUsecase) catatRiwayatHarga(ctx context.Context, pasar *domain.Pasar) error {
+	return u.repo.SimpanRiwayatHarga(ctx, &domain.RiwayatHarga{
+		Simbol:   strings.ToUpper(strings.TrimSpace(pasar.Simbol)),
+		Tanggal:  time.Now(),
+		Harga:    pasar.Harga,
+		MataUang: pasar.MataUang,
+	})
 }
 
 func (u *AdminUsecase) HapusPasar(ctx context.Context, id int64) error {
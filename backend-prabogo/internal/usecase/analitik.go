@@ -0,0 +1,475 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+const (
+	batasKonsentrasiAset     = 25.0
+	batasKonsentrasiKategori = 50.0
+	maksKinerja              = 3
+)
+
+type AnalitikUsecase struct {
+	portofolio *PortofolioUsecase
+	riwayat    domain.RiwayatHargaRepository
+}
+
+func NewAnalitikUsecase(portofolio *PortofolioUsecase, riwayat domain.RiwayatHargaRepository) *AnalitikUsecase {
+	return &AnalitikUsecase{portofolio: portofolio, riwayat: riwayat}
+}
+
+type titikHarga struct {
+	tanggal time.Time
+	harga   domain.Harga
+}
+
+// deretHarga menyimpan harga per simbol dalam mata uang tampilan, urut tanggal.
+type deretHarga map[string][]titikHarga
+
+func (d deretHarga) tambah(kunci string, tanggal time.Time, harga domain.Harga) {
+	d[kunci] = append(d[kunci], titikHarga{tanggal: tanggal, harga: harga})
+}
+
+func (d deretHarga) pada(kunci string, t time.Time) (domain.Harga, bool) {
+	deret := d[kunci]
+	i := sort.Search(len(deret), func(i int) bool { return deret[i].tanggal.After(t) })
+	if i == 0 {
+		return 0, false
+	}
+	return deret[i-1].harga, true
+}
+
+// Analitik menghitung alokasi, imbal hasil dan konsentrasi portofolio sejak
+// tanggal dari (kosong berarti sejak transaksi pertama). Semua nilai memakai
+// kurs terbaru karena riwayat kurs tidak disimpan.
+func (u *AnalitikUsecase) Analitik(ctx context.Context, idPengguna int64, mataUang string, dari time.Time) (*domain.AnalitikPortofolio, error) {
+	tampilan, err := u.portofolio.MataUangTampilan(ctx, idPengguna, mataUang)
+	if err != nil {
+		return nil, err
+	}
+	posisi, err := u.portofolio.Daftar(ctx, idPengguna, tampilan, "", true)
+	if err != nil {
+		return nil, err
+	}
+	transaksi, err := u.portofolio.DaftarTransaksi(ctx, idPengguna, "")
+	if err != nil {
+		return nil, err
+	}
+	konverter, err := u.portofolio.kurs.Konverter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	posisi, transaksi, tanpaKurs := pisahTanpaKurs(posisi, transaksi, konverter, tampilan)
+
+	sampai := time.Now()
+	if dari.IsZero() && len(transaksi) > 0 {
+		dari = transaksi[0].Tanggal
+	}
+	hasil := &domain.AnalitikPortofolio{
+		MataUang:        tampilan,
+		Dari:            dari,
+		Sampai:          sampai,
+		AlokasiKategori: []domain.AlokasiPortofolio{},
+		AlokasiAset:     []domain.AlokasiPortofolio{},
+		KinerjaTerbaik:  []domain.KinerjaAset{},
+		KinerjaTerburuk: []domain.KinerjaAset{},
+		Peringatan:      []string{},
+	}
+	if len(tanpaKurs) > 0 {
+		hasil.Peringatan = append(hasil.Peringatan, fmt.Sprintf("Kurs ke %s belum tersedia untuk %s; aset tersebut tidak dihitung dalam analitik", tampilan, strings.Join(tanpaKurs, ", ")))
+	}
+
+	for _, p := range posisi {
+		hasil.TotalNilai += p.NilaiTampilan
+		modal, err := konverter.Konversi(p.TotalModal, p.MataUang, tampilan)
+		if err != nil {
+			return nil, err
+		}
+		hasil.TotalModal += modal.Bulatkan(tampilan)
+	}
+	u.isiAlokasi(hasil, posisi)
+
+	kinerja, err := hitungKinerja(posisi, transaksi, konverter, tampilan)
+	if err != nil {
+		return nil, err
+	}
+	hasil.KinerjaTerbaik, hasil.KinerjaTerburuk = pilihKinerja(kinerja)
+
+	harga, err := u.muatDeretHarga(ctx, transaksi, konverter, tampilan, sampai)
+	if err != nil {
+		return nil, err
+	}
+	twr, arus, err := hitungImbalHasil(transaksi, harga, konverter, tampilan, dari, hasil.TotalNilai, sampai)
+	if err != nil {
+		return nil, err
+	}
+	hasil.ImbalHasilTWR = math.Round(twr*10000) / 100
+	if mwr, ok := hitungXIRR(arus); ok {
+		mwr = math.Round(mwr*10000) / 100
+		hasil.ImbalHasilMWR = &mwr
+	}
+	return hasil, nil
+}
+
+// pisahTanpaKurs mengeluarkan posisi dan transaksi yang mata uangnya belum
+// punya kurs ke mata uang tampilan agar nilai akhir dan arus kas tetap
+// sebanding. Mengembalikan simbol yang dikeluarkan.
+func pisahTanpaKurs(posisi []domain.Portofolio, transaksi []domain.TransaksiPortofolio, konverter *KonverterKurs, tampilan string) ([]domain.Portofolio, []domain.TransaksiPortofolio, []string) {
+	var tanpaKurs []string
+	adaKurs := make([]domain.Portofolio, 0, len(posisi))
+	for _, p := range posisi {
+		if p.KursTidakTersedia {
+			tanpaKurs = append(tanpaKurs, p.Simbol)
+			continue
+		}
+		adaKurs = append(adaKurs, p)
+	}
+	if len(tanpaKurs) == 0 {
+		return posisi, transaksi, nil
+	}
+	transaksiAdaKurs := make([]domain.TransaksiPortofolio, 0, len(transaksi))
+	for _, t := range transaksi {
+		if _, err := konverter.Faktor(t.MataUang, tampilan); err == nil {
+			transaksiAdaKurs = append(transaksiAdaKurs, t)
+		}
+	}
+	return adaKurs, transaksiAdaKurs, tanpaKurs
+}
+
+func (u *AnalitikUsecase) isiAlokasi(hasil *domain.AnalitikPortofolio, posisi []domain.Portofolio) {
+	perKategori := map[string]domain.Uang{}
+	var urutanKategori []string
+	for _, p := range posisi {
+		if p.NilaiTampilan == 0 {
+			continue
+		}
+		kategori := p.Kategori
+		if kategori == "" {
+			kategori = "lainnya"
+		}
+		if _, ok := perKategori[kategori]; !ok {
+			urutanKategori = append(urutanKategori, kategori)
+		}
+		perKategori[kategori] += p.NilaiTampilan
+		hasil.AlokasiAset = append(hasil.AlokasiAset, domain.AlokasiPortofolio{
+			Label:  p.Simbol,
+			Nilai:  p.NilaiTampilan,
+			Persen: persen(p.NilaiTampilan, hasil.TotalNilai),
+		})
+	}
+	for _, kategori := range urutanKategori {
+		hasil.AlokasiKategori = append(hasil.AlokasiKategori, domain.AlokasiPortofolio{
+			Label:  kategori,
+			Nilai:  perKategori[kategori],
+			Persen: persen(perKategori[kategori], hasil.TotalNilai),
+		})
+	}
+	urutkanAlokasi(hasil.AlokasiAset)
+	urutkanAlokasi(hasil.AlokasiKategori)
+
+	for _, a := range hasil.AlokasiAset {
+		if a.Persen > batasKonsentrasiAset {
+			hasil.Peringatan = append(hasil.Peringatan, fmt.Sprintf("Aset %s mencakup %.2f%% portofolio, melebihi batas %.0f%%", a.Label, a.Persen, batasKonsentrasiAset))
+		}
+	}
+	for _, a := range hasil.AlokasiKategori {
+		if a.Persen > batasKonsentrasiKategori {
+			hasil.Peringatan = append(hasil.Peringatan, fmt.Sprintf("Kategori %s mencakup %.2f%% portofolio, melebihi batas %.0f%%", a.Label, a.Persen, batasKonsentrasiKategori))
+		}
+	}
+}
+
+func urutkanAlokasi(daftar []domain.AlokasiPortofolio) {
+	sort.SliceStable(daftar, func(i, j int) bool { return daftar[i].Nilai > daftar[j].Nilai })
+}
+
+// hitungKinerja menghitung laba total (belum terealisasi, terealisasi dan
+// dividen) per posisi terhadap seluruh modal yang pernah dimasukkan.
+func hitungKinerja(posisi []domain.Portofolio, transaksi []domain.TransaksiPortofolio, konverter *KonverterKurs, tampilan string) ([]domain.KinerjaAset, error) {
+	modalMasuk := map[string]domain.Uang{}
+	for _, t := range transaksi {
+		if t.Jenis == domain.TransaksiBeli || t.Jenis == domain.TransaksiTransferMasuk {
+			nilai, err := nilaiTransaksi(t)
+			if err != nil {
+				return nil, err
+			}
+			modalMasuk[kunciPosisi(t.Simbol, t.MataUang)] += nilai + t.Biaya
+		}
+	}
+	var hasil []domain.KinerjaAset
+	for _, p := range posisi {
+		modal := modalMasuk[kunciPosisi(p.Simbol, p.MataUang)]
+		if modal <= 0 {
+			continue
+		}
+		modalTampilan, err := konverter.Konversi(modal, p.MataUang, tampilan)
+		if err != nil {
+			return nil, err
+		}
+		laba, err := konverter.Konversi(p.LabaBelumRealisasi+p.LabaRealisasi+p.Dividen, p.MataUang, tampilan)
+		if err != nil {
+			return nil, err
+		}
+		hasil = append(hasil, domain.KinerjaAset{
+			IDPortofolio: p.ID,
+			NamaAset:     p.NamaAset,
+			Simbol:       p.Simbol,
+			Modal:        modalTampilan.Bulatkan(tampilan),
+			Laba:         laba.Bulatkan(tampilan),
+			Persen:       persen(laba, modalTampilan),
+		})
+	}
+	return hasil, nil
+}
+
+// pilihKinerja mengambil paling banyak maksKinerja aset terbaik dan terburuk.
+// Aset yang sudah masuk daftar terbaik tidak diulang di daftar terburuk,
+// sehingga portofolio kecil membagi asetnya ke dua daftar.
+func pilihKinerja(kinerja []domain.KinerjaAset) ([]domain.KinerjaAset, []domain.KinerjaAset) {
+	sort.SliceStable(kinerja, func(i, j int) bool { return kinerja[i].Persen > kinerja[j].Persen })
+	nTerbaik := min(maksKinerja, (len(kinerja)+1)/2)
+	nTerburuk := min(maksKinerja, len(kinerja)-nTerbaik)
+	terbaik := append([]domain.KinerjaAset{}, kinerja[:nTerbaik]...)
+	terburuk := make([]domain.KinerjaAset, 0, nTerburuk)
+	for i := len(kinerja) - 1; i >= len(kinerja)-nTerburuk; i-- {
+		terburuk = append(terburuk, kinerja[i])
+	}
+	return terbaik, terburuk
+}
+
+// muatDeretHarga memuat riwayat harga pasar dan harga emas per simbol, lalu
+// melengkapinya dengan harga transaksi per posisi sebagai cadangan.
+func (u *AnalitikUsecase) muatDeretHarga(ctx context.Context, transaksi []domain.TransaksiPortofolio, konverter *KonverterKurs, tampilan string, sampai time.Time) (deretHarga, error) {
+	deret := deretHarga{}
+	var simbol []string
+	sudah := map[string]bool{}
+	for _, t := range transaksi {
+		s := strings.ToUpper(t.Simbol)
+		if !sudah[s] {
+			sudah[s] = true
+			simbol = append(simbol, s)
+		}
+	}
+
+	riwayat, err := u.riwayat.DaftarRiwayatHarga(ctx, simbol, sampai)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range riwayat {
+		harga, err := konverter.KonversiHarga(r.Harga, domain.NormalisasiMataUang(r.MataUang, domain.MataUangUSD), tampilan)
+		if err != nil {
+			continue
+		}
+		deret.tambah(strings.ToUpper(r.Simbol), r.Tanggal, harga)
+	}
+
+	punyaEmas := false
+	for s := range sudah {
+		punyaEmas = punyaEmas || simbolEmas[s]
+	}
+	if punyaEmas {
+		emas, err := u.riwayat.DaftarHargaEmas(ctx, time.Time{}, sampai)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range emas {
+			harga, err := konverter.KonversiHarga(e.HargaPerGram.Harga(), domain.NormalisasiMataUang(e.MataUang, domain.MataUangIDR), tampilan)
+			if err != nil {
+				continue
+			}
+			for s := range sudah {
+				if simbolEmas[s] {
+					deret.tambah(s, e.Tanggal, harga)
+				}
+			}
+		}
+	}
+
+	for _, t := range transaksi {
+		if t.Jenis == domain.TransaksiDividen || t.Harga <= 0 {
+			continue
+		}
+		harga, err := konverter.KonversiHarga(t.Harga, t.MataUang, tampilan)
+		if err != nil {
+			return nil, err
+		}
+		deret.tambah(kunciPosisi(t.Simbol, t.MataUang), t.Tanggal, harga)
+	}
+	for kunci := range deret {
+		titik := deret[kunci]
+		sort.SliceStable(titik, func(i, j int) bool { return titik[i].tanggal.Before(titik[j].tanggal) })
+	}
+	return deret, nil
+}
+
+type arusKas struct {
+	tanggal time.Time
+	nilai   domain.Uang
+}
+
+// hitungImbalHasil menghitung time-weighted return kumulatif dengan memecah
+// periode pada setiap hari yang memiliki arus kas, serta mengembalikan arus kas
+// dari sisi investor untuk perhitungan money-weighted return.
+func hitungImbalHasil(transaksi []domain.TransaksiPortofolio, harga deretHarga, konverter *KonverterKurs, tampilan string, dari time.Time, nilaiAkhir domain.Uang, sampai time.Time) (float64, []arusKas, error) {
+	unit := map[string]float64{}
+	simbolPosisi := map[string]string{}
+	nilaiPada := func(t time.Time) (domain.Uang, error) {
+		var total domain.Uang
+		for kunci, jumlah := range unit {
+			if jumlah <= toleransiJumlah {
+				continue
+			}
+			h, ok := harga.pada(simbolPosisi[kunci], t)
+			if !ok {
+				h, _ = harga.pada(kunci, t)
+			}
+			nilai, err := h.Kali(jumlah)
+			if err != nil {
+				return 0, fmt.Errorf("nilai %s pada %s: %w", kunci, t.Format("2006-01-02"), err)
+			}
+			total += nilai
+		}
+		return total, nil
+	}
+
+	hariMulai := awalHari(dari)
+	faktor := 1.0
+	var nilaiSetelah domain.Uang
+	mulai := false
+	var arus []arusKas
+
+	for i := 0; i < len(transaksi); {
+		hari := awalHari(transaksi[i].Tanggal)
+		akhirHari := hari.Add(24*time.Hour - time.Nanosecond)
+		if !mulai && !hari.Before(hariMulai) {
+			mulai = true
+			var err error
+			if nilaiSetelah, err = nilaiPada(hariMulai); err != nil {
+				return 0, nil, err
+			}
+			if nilaiSetelah > 0 {
+				arus = append(arus, arusKas{tanggal: hariMulai, nilai: -nilaiSetelah})
+			}
+		}
+
+		nilaiSebelum, err := nilaiPada(akhirHari)
+		if err != nil {
+			return 0, nil, err
+		}
+		var masuk, distribusi domain.Uang
+		for ; i < len(transaksi) && awalHari(transaksi[i].Tanggal).Equal(hari); i++ {
+			t := transaksi[i]
+			kunci := kunciPosisi(t.Simbol, t.MataUang)
+			simbolPosisi[kunci] = strings.ToUpper(t.Simbol)
+			nilai, err := nilaiTransaksi(t)
+			if err != nil {
+				return 0, nil, err
+			}
+			if nilai, err = konverter.Konversi(nilai, t.MataUang, tampilan); err != nil {
+				return 0, nil, err
+			}
+			biaya, err := konverter.Konversi(t.Biaya, t.MataUang, tampilan)
+			if err != nil {
+				return 0, nil, err
+			}
+			switch t.Jenis {
+			case domain.TransaksiBeli:
+				unit[kunci] += t.Jumlah
+				masuk += nilai + biaya
+			case domain.TransaksiTransferMasuk:
+				unit[kunci] += t.Jumlah
+				masuk += nilai
+			case domain.TransaksiJual:
+				unit[kunci] -= t.Jumlah
+				masuk -= nilai - biaya
+			case domain.TransaksiTransferKeluar:
+				unit[kunci] -= t.Jumlah
+				masuk -= nilai
+			case domain.TransaksiDividen:
+				if t.Jumlah == 0 {
+					nilai, err = konverter.Konversi(t.Harga.Uang(), t.MataUang, tampilan)
+					if err != nil {
+						return 0, nil, err
+					}
+				}
+				distribusi += nilai - biaya
+			}
+		}
+		if !mulai {
+			continue
+		}
+		if nilaiSetelah > 0 {
+			faktor *= (nilaiSebelum + distribusi).Rasio(nilaiSetelah)
+		}
+		if nilaiSetelah, err = nilaiPada(akhirHari); err != nil {
+			return 0, nil, err
+		}
+		if bersih := masuk - distribusi; bersih != 0 {
+			arus = append(arus, arusKas{tanggal: hari, nilai: -bersih})
+		}
+	}
+	if !mulai {
+		var err error
+		if nilaiSetelah, err = nilaiPada(hariMulai); err != nil {
+			return 0, nil, err
+		}
+		if nilaiSetelah > 0 {
+			arus = append(arus, arusKas{tanggal: hariMulai, nilai: -nilaiSetelah})
+		}
+	}
+	if nilaiSetelah > 0 {
+		faktor *= nilaiAkhir.Rasio(nilaiSetelah)
+	}
+	if nilaiAkhir > 0 {
+		arus = append(arus, arusKas{tanggal: sampai, nilai: nilaiAkhir})
+	}
+	return faktor - 1, arus, nil
+}
+
+func awalHari(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// hitungXIRR mencari imbal hasil tahunan yang membuat nilai kini arus kas nol
+// dengan metode biseksi. Mengembalikan false bila arus kas tidak berganti tanda
+// atau tidak ada akar pada rentang -99% sampai 10000% per tahun.
+func hitungXIRR(arus []arusKas) (float64, bool) {
+	if len(arus) < 2 {
+		return 0, false
+	}
+	awal := arus[0].tanggal
+	npv := func(r float64) float64 {
+		var total float64
+		for _, a := range arus {
+			tahun := a.tanggal.Sub(awal).Hours() / 24 / 365
+			total += a.nilai.Float64() / math.Pow(1+r, tahun)
+		}
+		return total
+	}
+	bawah, atas := -0.99, 100.0
+	fBawah, fAtas := npv(bawah), npv(atas)
+	if math.IsNaN(fBawah) || math.IsNaN(fAtas) || fBawah*fAtas > 0 {
+		return 0, false
+	}
+	for i := 0; i < 200; i++ {
+		tengah := (bawah + atas) / 2
+		fTengah := npv(tengah)
+		if math.Abs(fTengah) < 1e-7 || atas-bawah < 1e-10 {
+			return tengah, true
+		}
+		if fBawah*fTengah < 0 {
+			atas = tengah
+		} else {
+			bawah, fBawah = tengah, fTengah
+		}
+	}
+	return (bawah + atas) / 2, true
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func TestPisahTanpaKurs(t *testing.T) {
+	konverter := &KonverterKurs{dasar: "USD", kurs: map[[2]string]float64{{"USD", "IDR"}: 16000}}
+	posisi := []domain.Portofolio{
+		{Simbol: "BBCA", MataUang: "IDR"},
+		{Simbol: "AMAN", MataUang: "USD"},
+		{Simbol: "MAYBANK", MataUang: "MYR", KursTidakTersedia: true},
+	}
+	transaksi := []domain.TransaksiPortofolio{
+		{ID: 1, Simbol: "BBCA", MataUang: "IDR"},
+		{ID: 2, Simbol: "AMAN", MataUang: "USD"},
+		{ID: 3, Simbol: "MAYBANK", MataUang: "MYR"},
+	}
+
+	sisaPosisi, sisaTransaksi, tanpaKurs := pisahTanpaKurs(posisi, transaksi, konverter, "IDR")
+	if len(sisaPosisi) != 2 || sisaPosisi[1].Simbol != "AMAN" {
+		t.Errorf("posisi tersisa = %v", sisaPosisi)
+	}
+	if len(sisaTransaksi) != 2 || sisaTransaksi[1].ID != 2 {
+		t.Errorf("transaksi tersisa = %v", sisaTransaksi)
+	}
+	if len(tanpaKurs) != 1 || tanpaKurs[0] != "MAYBANK" {
+		t.Errorf("tanpa kurs = %v, ingin [MAYBANK]", tanpaKurs)
+	}
+}
+
+func TestPeringatanKurs(t *testing.T) {
+	rincian := []domain.RincianZakat{
+		{NamaAset: "Bank Central Asia", Nilai: uangUji(t, "1000")},
+		{NamaAset: "Tabungan Ringgit", KursTidakTersedia: true},
+	}
+	peringatan := peringatanKurs(rincian, "IDR")
+	if len(peringatan) != 1 || !strings.Contains(peringatan[0], "Tabungan Ringgit") || strings.Contains(peringatan[0], "Bank Central Asia") {
+		t.Errorf("peringatan = %v, ingin hanya menyebut Tabungan Ringgit", peringatan)
+	}
+	if peringatanKurs(rincian[:1], "IDR") != nil {
+		t.Error("peringatan muncul padahal semua kurs tersedia")
+	}
+}
+
+func TestPilihKinerja(t *testing.T) {
+	buat := func(n int) []domain.KinerjaAset {
+		var daftar []domain.KinerjaAset
+		for i := range n {
+			daftar = append(daftar, domain.KinerjaAset{IDPortofolio: int64(i + 1), Persen: float64(i * 10)})
+		}
+		return daftar
+	}
+	tests := []struct {
+		jumlah   int
+		terbaik  []int64
+		terburuk []int64
+	}{
+		{0, nil, nil},
+		{1, []int64{1}, nil},
+		{2, []int64{2}, []int64{1}},
+		{5, []int64{5, 4, 3}, []int64{1, 2}},
+		{8, []int64{8, 7, 6}, []int64{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		terbaik, terburuk := pilihKinerja(buat(tt.jumlah))
+		if !samaID(terbaik, tt.terbaik) || !samaID(terburuk, tt.terburuk) {
+			t.Errorf("%d aset: terbaik %v terburuk %v, ingin %v dan %v", tt.jumlah, idKinerja(terbaik), idKinerja(terburuk), tt.terbaik, tt.terburuk)
+		}
+	}
+}
+
+func idKinerja(daftar []domain.KinerjaAset) []int64 {
+	var id []int64
+	for _, k := range daftar {
+		id = append(id, k.IDPortofolio)
+	}
+	return id
+}
+
+func samaID(daftar []domain.KinerjaAset, ingin []int64) bool {
+	id := idKinerja(daftar)
+	if len(id) != len(ingin) {
+		return false
+	}
+	for i := range id {
+		if id[i] != ingin[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func hariUji(hari int) time.Time {
+	return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, hari)
+}
+
+func TestHitungXIRR(t *testing.T) {
+	tests := []struct {
+		nama  string
+		arus  []arusKas
+		ingin float64
+	}{
+		{"satu tahun", []arusKas{{hariUji(0), uangUji(t, "-1000")}, {hariUji(365), uangUji(t, "1100")}}, 0.10},
+		{"dua tahun", []arusKas{{hariUji(0), uangUji(t, "-1000")}, {hariUji(730), uangUji(t, "1210")}}, 0.10},
+		// 1000 x 1.1^2 + 1000 x 1.1 = 2310.
+		{"setoran bertahap", []arusKas{{hariUji(0), uangUji(t, "-1000")}, {hariUji(365), uangUji(t, "-1000")}, {hariUji(730), uangUji(t, "2310")}}, 0.10},
+		{"rugi", []arusKas{{hariUji(0), uangUji(t, "-1000")}, {hariUji(365), uangUji(t, "800")}}, -0.20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			got, ok := hitungXIRR(tt.arus)
+			if !ok || math.Abs(got-tt.ingin) > 1e-6 {
+				t.Errorf("hitungXIRR = %v %v, ingin %v", got, ok, tt.ingin)
+			}
+		})
+	}
+
+	if _, ok := hitungXIRR([]arusKas{{hariUji(0), uangUji(t, "-1000")}, {hariUji(365), uangUji(t, "-100")}}); ok {
+		t.Error("arus kas tanpa pergantian tanda tidak boleh punya XIRR")
+	}
+	if _, ok := hitungXIRR([]arusKas{{hariUji(0), uangUji(t, "-1000")}}); ok {
+		t.Error("satu arus kas tidak boleh punya XIRR")
+	}
+}
+
+func TestHitungImbalHasilTWR(t *testing.T) {
+	konverter := &KonverterKurs{dasar: "USD", kurs: map[[2]string]float64{}}
+	harga := deretHarga{}
+	harga.tambah("AMAN", hariUji(0), hargaUji(t, "100"))
+	harga.tambah("AMAN", hariUji(9), hargaUji(t, "120"))
+
+	beli1 := transaksiUji(t, 1, 1, domain.TransaksiBeli, 10, "100", "0")
+	beli2 := transaksiUji(t, 2, 10, domain.TransaksiBeli, 10, "120", "0")
+	transaksi := []domain.TransaksiPortofolio{beli1, beli2}
+	sampai := hariUji(19)
+
+	// Subperiode: 1000 -> 1200 sebelum setoran kedua (x1.2), lalu 2400 -> 3000 (x1.25).
+	twr, arus, err := hitungImbalHasil(transaksi, harga, konverter, "IDR", beli1.Tanggal, uangUji(t, "3000"), sampai)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(twr-0.5) > 1e-9 {
+		t.Errorf("TWR = %v, ingin 0.5", twr)
+	}
+	ingin := []arusKas{{beli1.Tanggal, uangUji(t, "-1000")}, {beli2.Tanggal, uangUji(t, "-1200")}, {sampai, uangUji(t, "3000")}}
+	if len(arus) != len(ingin) {
+		t.Fatalf("arus = %v, ingin %v", arus, ingin)
+	}
+	for i := range ingin {
+		if !arus[i].tanggal.Equal(ingin[i].tanggal) || arus[i].nilai != ingin[i].nilai {
+			t.Errorf("arus[%d] = %v, ingin %v", i, arus[i], ingin[i])
+		}
+	}
+
+	// Dimulai setelah pembelian pertama: nilai awal 10 x 100 menjadi setoran awal.
+	twr, arus, err = hitungImbalHasil(transaksi, harga, konverter, "IDR", hariUji(4), uangUji(t, "3000"), sampai)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(twr-0.5) > 1e-9 || len(arus) != 3 || arus[0].nilai != uangUji(t, "-1000") || !arus[0].tanggal.Equal(hariUji(4)) {
+		t.Errorf("TWR dari tengah periode = %v dengan arus %v", twr, arus)
+	}
+}
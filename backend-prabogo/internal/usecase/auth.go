@@ -150,6 +150,28 @@ func (u *AuthUsecase) Profil(ctx context.Context, id int64) (*domain.Pengguna, e
 	return u.repo.AmbilPenggunaByID(ctx, id)
 }
 
+func (u *AuthUsecase) PerbaruiMataUang(ctx context.Context, id int64, mataUang string) (string, error) {
+	kode := domain.NormalisasiMataUang(mataUang, "")
+	if kode == "" {
+		return "", errors.New("kode mata uang harus tiga huruf ISO 4217")
+	}
+	if err := u.repo.PerbaruiMataUangPengguna(ctx, id, kode); err != nil {
+		return "", err
+	}
+	return kode, nil
+}
+
+func (u *AuthUsecase) PerbaruiStandarNisab(ctx context.Context, id int64, standar string) (string, error) {
+	standar = normalisasiStandarNisab(standar)
+	if standar == "" {
+		return "", errors.New("standar nisab harus emas atau perak")
+	}
+	if err := u.repo.PerbaruiStandarNisabPengguna(ctx, id, standar); err != nil {
+		return "", err
+	}
+	return standar, nil
+}
+
 func buatKodeOTP() string {
 	var b [8]byte
 	if _, err := rand.Read(b[:]); err != nil {
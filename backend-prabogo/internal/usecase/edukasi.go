@@ -2,17 +2,20 @@ package usecase
 
 import (
 	"context"
-	"time"
+	"errors"
+	"strings"
 
 	"github.com/averroes/backend-prabogo/internal/domain"
 )
 
 type EdukasiUsecase struct {
-	repo domain.EdukasiRepository
+	repo      domain.EdukasiRepository
+	urlPublik string
 }
 
-func NewEdukasiUsecase(repo domain.EdukasiRepository) *EdukasiUsecase {
-	return &EdukasiUsecase{repo: repo}
+// NewEdukasiUsecase menerima URL publik API untuk tautan verifikasi sertifikat.
+func NewEdukasiUsecase(repo domain.EdukasiRepository, urlPublik string) *EdukasiUsecase {
+	return &EdukasiUsecase{repo: repo, urlPublik: strings.TrimRight(urlPublik, "/")}
 }
 
 func (u *EdukasiUsecase) DaftarKelas(ctx context.Context) ([]domain.Kelas, error) {
@@ -23,18 +26,36 @@ func (u *EdukasiUsecase) DetailKelas(ctx context.Context, id int64) (*domain.Kel
 	return u.repo.DetailKelas(ctx, id)
 }
 
+// DaftarModul mengembalikan modul terbit; kelas yang belum terbit dianggap
+// tidak memiliki modul.
 func (u *EdukasiUsecase) DaftarModul(ctx context.Context, idKelas int64) ([]domain.Modul, error) {
+	kelas, err := u.repo.DetailKelas(ctx, idKelas)
+	if err != nil || kelas == nil {
+		return nil, err
+	}
 	return u.repo.DaftarModulByKelas(ctx, idKelas)
 }
 
 func (u *EdukasiUsecase) DaftarMateri(ctx context.Context, idModul int64) ([]domain.Materi, error) {
+	modul, err := u.repo.DetailModul(ctx, idModul)
+	if err != nil || modul == nil {
+		return nil, err
+	}
 	return u.repo.DaftarMateriByModul(ctx, idModul)
 }
 
 func (u *EdukasiUsecase) DaftarUjian(ctx context.Context, idKelas int64) ([]domain.Ujian, error) {
+	kelas, err := u.repo.DetailKelas(ctx, idKelas)
+	if err != nil || kelas == nil {
+		return nil, err
+	}
 	return u.repo.DaftarUjianByKelas(ctx, idKelas)
 }
 
+func (u *EdukasiUsecase) DaftarKelasSemua(ctx context.Context) ([]domain.Kelas, error) {
+	return u.repo.DaftarKelasSemua(ctx)
+}
+
 func (u *EdukasiUsecase) DaftarModulSemua(ctx context.Context) ([]domain.Modul, error) {
 	return u.repo.DaftarModulSemua(ctx)
 }
@@ -47,19 +68,49 @@ func (u *EdukasiUsecase) DaftarUjianSemua(ctx context.Context) ([]domain.Ujian,
 	return u.repo.DaftarUjianSemua(ctx)
 }
 
+func (u *EdukasiUsecase) DaftarSoalUjian(ctx context.Context, idUjian int64) ([]domain.SoalUjian, error) {
+	return u.repo.DaftarSoalUjian(ctx, idUjian)
+}
+
 func (u *EdukasiUsecase) DaftarSertifikat(ctx context.Context) ([]domain.Sertifikat, error) {
 	return u.repo.DaftarSertifikat(ctx)
 }
 
+// MulaiKelas membuat atau menyegarkan progress kelas tanpa menghapus
+// materi yang sudah diselesaikan. Prasyarat hanya diperiksa saat kelas pertama
+// kali dimulai agar peserta lama tidak terkunci saat prasyarat baru ditambahkan.
 func (u *EdukasiUsecase) MulaiKelas(ctx context.Context, idPengguna, idKelas int64) error {
-	progress := &domain.ProgressKelas{
-		IDPengguna:         idPengguna,
-		IDKelas:            idKelas,
-		Persentase:         0,
-		Status:             "berjalan",
-		TerakhirDiaksesPada: time.Now(),
+	kelas, err := u.repo.DetailKelas(ctx, idKelas)
+	if err != nil {
+		return err
+	}
+	if kelas == nil {
+		return errors.New("kelas tidak ditemukan")
+	}
+	progress, err := u.repo.AmbilProgressKelas(ctx, idPengguna, idKelas)
+	if err != nil {
+		return err
+	}
+	if progress == nil {
+		if err := u.cekPrasyarat(ctx, idPengguna, idKelas); err != nil {
+			return err
+		}
+	}
+	_, err = u.perbaruiProgressKelas(ctx, idPengguna, idKelas)
+	return err
+}
+
+// wajibTerdaftar memastikan peserta sudah memulai kelas, sehingga prasyarat
+// yang diperiksa MulaiKelas tidak dapat dilewati lewat materi atau ujian.
+func (u *EdukasiUsecase) wajibTerdaftar(ctx context.Context, idPengguna, idKelas int64) error {
+	progress, err := u.repo.AmbilProgressKelas(ctx, idPengguna, idKelas)
+	if err != nil {
+		return err
+	}
+	if progress == nil {
+		return domain.ErrKelasBelumDimulai
 	}
-	return u.repo.SimpanProgress(ctx, progress)
+	return nil
 }
 
 func (u *EdukasiUsecase) DaftarProgress(ctx context.Context, idPengguna int64) ([]domain.ProgressKelas, error) {
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+type HargaLogamUsecase struct {
+	repo      domain.ZakatRepository
+	riwayat   domain.RiwayatHargaRepository
+	admin     domain.AdminRepository
+	penyedia  domain.PenyediaHargaLogam
+	mataUang  string
+	batasUsia time.Duration
+}
+
+func NewHargaLogamUsecase(repo domain.ZakatRepository, riwayat domain.RiwayatHargaRepository, admin domain.AdminRepository, penyedia domain.PenyediaHargaLogam, mataUang string, batasUsia time.Duration) *HargaLogamUsecase {
+	return &HargaLogamUsecase{
+		repo:      repo,
+		riwayat:   riwayat,
+		admin:     admin,
+		penyedia:  penyedia,
+		mataUang:  domain.NormalisasiMataUang(mataUang, domain.MataUangIDR),
+		batasUsia: batasUsia,
+	}
+}
+
+// normalisasiLogam menerima emas atau perak; kosong berarti emas.
+func normalisasiLogam(logam string) (string, error) {
+	logam = strings.ToLower(strings.TrimSpace(logam))
+	if logam == "" {
+		return domain.NisabEmas, nil
+	}
+	if normalisasiStandarNisab(logam) == "" {
+		return "", domain.ErrLogamTidakValid
+	}
+	return logam, nil
+}
+
+// Terkini mengembalikan harga logam terbaru dari basis data. Sinkronisasi
+// dengan penyedia hanya dilakukan oleh job terjadwal agar permintaan publik
+// tidak memanggil penyedia; harga yang lebih tua dari batas usia dikembalikan
+// dengan peringatan. Mengembalikan nil bila belum ada harga sama sekali.
+func (u *HargaLogamUsecase) Terkini(ctx context.Context, logam string) (*domain.HargaLogamTerkini, error) {
+	logam, err := normalisasiLogam(logam)
+	if err != nil {
+		return nil, err
+	}
+	harga, err := u.repo.HargaLogamTerbaru(ctx, logam)
+	if err != nil || harga == nil {
+		return nil, err
+	}
+
+	sekarang := time.Now()
+	terkini := &domain.HargaLogamTerkini{
+		HargaEmas:   *harga,
+		UsiaHari:    int(awalHari(sekarang).Sub(tanggalLokal(harga.Tanggal)).Hours() / 24),
+		Kedaluwarsa: u.kedaluwarsa(harga, sekarang),
+	}
+	if terkini.Kedaluwarsa {
+		terkini.Peringatan = fmt.Sprintf("Harga %s terakhir diperbarui %d hari lalu (%s); nisab mungkin tidak akurat", logam, terkini.UsiaHari, tanggalLokal(harga.Tanggal).Format("2006-01-02"))
+	}
+	return terkini, nil
+}
+
+func (u *HargaLogamUsecase) kedaluwarsa(harga *domain.HargaEmas, sekarang time.Time) bool {
+	if u.batasUsia <= 0 {
+		return false
+	}
+	// Harga harian dicatat per tanggal; usia dihitung dari akhir hari harga tersebut.
+	return sekarang.Sub(tanggalLokal(harga.Tanggal).AddDate(0, 0, 1)) > u.batasUsia
+}
+
+func (u *HargaLogamUsecase) Riwayat(ctx context.Context, logam string, dari, sampai time.Time) ([]domain.HargaEmas, error) {
+	logam, err := normalisasiLogam(logam)
+	if err != nil {
+		return nil, err
+	}
+	if !dari.IsZero() && !sampai.IsZero() && sampai.Before(dari) {
+		return nil, errors.New("tanggal sampai tidak boleh sebelum tanggal dari")
+	}
+	daftar, err := u.riwayat.DaftarHargaLogam(ctx, logam, dari, sampai)
+	if err != nil {
+		return nil, err
+	}
+	if daftar == nil {
+		daftar = []domain.HargaEmas{}
+	}
+	return daftar, nil
+}
+
+func (u *HargaLogamUsecase) validasi(harga *domain.HargaEmas) error {
+	logam, err := normalisasiLogam(harga.Logam)
+	if err != nil {
+		return err
+	}
+	harga.Logam = logam
+	if harga.HargaPerGram <= 0 {
+		return errors.New("harga per gram harus lebih dari nol")
+	}
+	harga.MataUang = domain.NormalisasiMataUang(harga.MataUang, u.mataUang)
+	if harga.Tanggal.IsZero() {
+		harga.Tanggal = time.Now()
+	}
+	harga.Tanggal = tanggalLokal(harga.Tanggal)
+	if harga.Tanggal.After(awalHari(time.Now())) {
+		return errors.New("tanggal harga tidak boleh di masa depan")
+	}
+	harga.Sumber = strings.TrimSpace(harga.Sumber)
+	if harga.Sumber == "" {
+		harga.Sumber = "manual"
+	}
+	return nil
+}
+
+// Simpan mencatat harga; harga untuk logam, tanggal dan mata uang yang sama
+// menimpa harga sebelumnya.
+func (u *HargaLogamUsecase) Simpan(ctx context.Context, harga *domain.HargaEmas) error {
+	if err := u.validasi(harga); err != nil {
+		return err
+	}
+	return u.admin.SimpanHargaLogam(ctx, harga)
+}
+
+func (u *HargaLogamUsecase) Perbarui(ctx context.Context, harga *domain.HargaEmas) error {
+	if err := u.validasi(harga); err != nil {
+		return err
+	}
+	return u.admin.PerbaruiHargaLogam(ctx, harga)
+}
+
+func (u *HargaLogamUsecase) Hapus(ctx context.Context, id int64) error {
+	return u.admin.HapusHargaLogam(ctx, id)
+}
+
+// Impor menyimpan banyak harga sekaligus. Baris yang tidak valid dilewati dan
+// dilaporkan tanpa membatalkan baris lain.
+func (u *HargaLogamUsecase) Impor(ctx context.Context, daftar []domain.HargaEmas) (*domain.HasilImporHargaLogam, error) {
+	if len(daftar) == 0 {
+		return nil, errors.New("tidak ada harga untuk diimpor")
+	}
+	hasil := &domain.HasilImporHargaLogam{Gagal: []domain.BarisGagalHarga{}}
+	for i := range daftar {
+		if err := u.validasi(&daftar[i]); err != nil {
+			hasil.Gagal = append(hasil.Gagal, domain.BarisGagalHarga{Nomor: i + 1, Alasan: err.Error()})
+			continue
+		}
+		if err := u.admin.SimpanHargaLogam(ctx, &daftar[i]); err != nil {
+			return nil, err
+		}
+		hasil.JumlahTersimpan++
+	}
+	hasil.JumlahGagal = len(hasil.Gagal)
+	return hasil, nil
+}
+
+// Sinkronkan mengambil harga emas dan perak hari ini dari penyedia.
+// Mengembalikan jumlah harga yang disimpan.
+func (u *HargaLogamUsecase) Sinkronkan(ctx context.Context) (int, error) {
+	if u.penyedia == nil {
+		return 0, errors.New("penyedia harga logam belum dikonfigurasi")
+	}
+	tersimpan := 0
+	for _, logam := range []string{domain.NisabEmas, domain.NisabPerak} {
+		n, err := u.sinkronkanLogam(ctx, logam)
+		if err != nil {
+			return tersimpan, err
+		}
+		tersimpan += n
+	}
+	return tersimpan, nil
+}
+
+func (u *HargaLogamUsecase) sinkronkanLogam(ctx context.Context, logam string) (int, error) {
+	perGram, err := u.penyedia.AmbilHargaLogam(ctx, logam, u.mataUang)
+	if err != nil {
+		return 0, err
+	}
+	harga := &domain.HargaEmas{
+		Tanggal:      time.Now(),
+		HargaPerGram: perGram,
+		MataUang:     u.mataUang,
+		Logam:        logam,
+		Sumber:       u.penyedia.Nama(),
+	}
+	if err := u.Simpan(ctx, harga); err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
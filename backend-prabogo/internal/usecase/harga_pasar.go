@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+type HargaPasarUsecase struct {
+	repo     domain.ScreenerRepository
+	admin    *AdminUsecase
+	penyedia domain.PenyediaHargaPasar
+}
+
+func NewHargaPasarUsecase(repo domain.ScreenerRepository, admin *AdminUsecase, penyedia domain.PenyediaHargaPasar) *HargaPasarUsecase {
+	return &HargaPasarUsecase{repo: repo, admin: admin, penyedia: penyedia}
+}
+
+// Sinkronkan mengambil kuotasi terbaru seluruh simbol pasar dari penyedia.
+// Setiap pasar disimpan lewat AdminUsecase.PerbaruiPasar sehingga riwayat
+// harga dicatat dan pembaruan diterbitkan ke stream seperti perubahan admin.
+// Kuotasi dengan mata uang berbeda dari pasar dilewati dan dilaporkan sebagai
+// error tanpa membatalkan simbol lain. Mengembalikan jumlah pasar yang diperbarui.
+func (u *HargaPasarUsecase) Sinkronkan(ctx context.Context) (int, error) {
+	if u.penyedia == nil {
+		return 0, errors.New("penyedia harga pasar belum dikonfigurasi")
+	}
+	daftar, err := u.repo.DaftarPasar(ctx)
+	if err != nil || len(daftar) == 0 {
+		return 0, err
+	}
+	indeks := make(map[string]int, len(daftar))
+	simbol := make([]string, 0, len(daftar))
+	for i := range daftar {
+		s := strings.ToUpper(strings.TrimSpace(daftar[i].Simbol))
+		indeks[s] = i
+		simbol = append(simbol, s)
+	}
+
+	kuotasi, err := u.penyedia.AmbilHargaPasar(ctx, simbol)
+	if err != nil {
+		return 0, err
+	}
+	var gagal []error
+	diperbarui := 0
+	for _, k := range kuotasi {
+		i, ok := indeks[strings.ToUpper(strings.TrimSpace(k.Simbol))]
+		if !ok || k.Harga <= 0 {
+			continue
+		}
+		pasar := daftar[i]
+		if mataUang := domain.NormalisasiMataUang(k.MataUang, pasar.MataUang); mataUang != pasar.MataUang {
+			gagal = append(gagal, fmt.Errorf("kuotasi %s dalam %s, pasar dalam %s", pasar.Simbol, mataUang, pasar.MataUang))
+			continue
+		}
+		pasar.Harga = k.Harga
+		pasar.Perubahan24J = k.Perubahan24J
+		// Volume dan kapitalisasi tidak selalu disediakan; nilai lama dipertahankan.
+		if k.Volume24J > 0 {
+			pasar.Volume24J = k.Volume24J
+		}
+		if k.KapitalisasiPasar > 0 {
+			pasar.KapitalisasiPasar = k.KapitalisasiPasar
+		}
+		if err := u.admin.PerbaruiPasar(ctx, &pasar); err != nil {
+			return diperbarui, err
+		}
+		diperbarui++
+	}
+	return diperbarui, errors.Join(gagal...)
+}
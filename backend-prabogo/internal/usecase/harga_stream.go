@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+const kapasitasPelangganDefault = 32
+
+// HubHarga menyebarkan setiap pembaruan harga pasar ke seluruh pelanggan stream.
+// Pelanggan yang lambat tidak menahan penerbit: bila antreannya penuh, harga
+// paling lama dibuang sehingga pelanggan selalu menerima harga terbaru.
+type HubHarga struct {
+	mu        sync.RWMutex
+	pelanggan map[*PelangganHarga]struct{}
+	kapasitas int
+}
+
+func NewHubHarga(kapasitas int) *HubHarga {
+	if kapasitas <= 0 {
+		kapasitas = kapasitasPelangganDefault
+	}
+	return &HubHarga{
+		pelanggan: make(map[*PelangganHarga]struct{}),
+		kapasitas: kapasitas,
+	}
+}
+
+type PelangganHarga struct {
+	mu       sync.Mutex
+	simbol   map[string]struct{}
+	kanal    chan domain.Pasar
+	terlewat atomic.Int64
+}
+
+func (h *HubHarga) Langganan(simbol []string) *PelangganHarga {
+	p := &PelangganHarga{kanal: make(chan domain.Pasar, h.kapasitas)}
+	p.AturSimbol(simbol)
+
+	h.mu.Lock()
+	h.pelanggan[p] = struct{}{}
+	h.mu.Unlock()
+	return p
+}
+
+func (h *HubHarga) Berhenti(p *PelangganHarga) {
+	h.mu.Lock()
+	delete(h.pelanggan, p)
+	h.mu.Unlock()
+}
+
+func (h *HubHarga) JumlahPelanggan() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.pelanggan)
+}
+
+// Terbitkan memenuhi domain.PenerbitHarga.
+func (h *HubHarga) Terbitkan(pasar domain.Pasar) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for p := range h.pelanggan {
+		if p.Mengikuti(pasar.Simbol) {
+			p.kirim(pasar)
+		}
+	}
+}
+
+// AturSimbol mengganti daftar simbol yang diikuti. Daftar kosong berarti semua simbol.
+func (p *PelangganHarga) AturSimbol(simbol []string) {
+	set := make(map[string]struct{}, len(simbol))
+	for _, s := range simbol {
+		s = strings.ToUpper(strings.TrimSpace(s))
+		if s != "" {
+			set[s] = struct{}{}
+		}
+	}
+	p.mu.Lock()
+	p.simbol = set
+	p.mu.Unlock()
+}
+
+func (p *PelangganHarga) Kanal() <-chan domain.Pasar {
+	return p.kanal
+}
+
+// Terlewat mengembalikan jumlah harga yang dibuang sejak pemanggilan sebelumnya.
+func (p *PelangganHarga) Terlewat() int64 {
+	return p.terlewat.Swap(0)
+}
+
+// Mengikuti melaporkan apakah pelanggan berlangganan simbol tersebut.
+func (p *PelangganHarga) Mengikuti(simbol string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.simbol) == 0 {
+		return true
+	}
+	_, ok := p.simbol[strings.ToUpper(simbol)]
+	return ok
+}
+
+func (p *PelangganHarga) kirim(pasar domain.Pasar) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for {
+		select {
+		case p.kanal <- pasar:
+			return
+		default:
+		}
+		select {
+		case <-p.kanal:
+			p.terlewat.Add(1)
+		default:
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func pasarUji(t *testing.T, simbol, harga string) domain.Pasar {
+	t.Helper()
+	return domain.Pasar{Simbol: simbol, Harga: hargaUji(t, harga)}
+}
+
+func terimaSemua(p *PelangganHarga) []domain.Pasar {
+	var hasil []domain.Pasar
+	for {
+		select {
+		case pasar := <-p.Kanal():
+			hasil = append(hasil, pasar)
+		default:
+			return hasil
+		}
+	}
+}
+
+func TestHubHargaMembuangHargaTerlama(t *testing.T) {
+	hub := NewHubHarga(2)
+	lambat := hub.Langganan(nil)
+	defer hub.Berhenti(lambat)
+
+	// Pelanggan tidak membaca sama sekali; penerbit tidak boleh tertahan.
+	for _, harga := range []string{"1", "2", "3", "4", "5"} {
+		hub.Terbitkan(pasarUji(t, "AMAN", harga))
+	}
+
+	diterima := terimaSemua(lambat)
+	if len(diterima) != 2 {
+		t.Fatalf("diterima %d harga, ingin 2", len(diterima))
+	}
+	for i, ingin := range []string{"4", "5"} {
+		if diterima[i].Harga != hargaUji(t, ingin) {
+			t.Errorf("harga ke-%d = %s, ingin %s", i, diterima[i].Harga, ingin)
+		}
+	}
+	if n := lambat.Terlewat(); n != 3 {
+		t.Errorf("terlewat = %d, ingin 3", n)
+	}
+	if n := lambat.Terlewat(); n != 0 {
+		t.Errorf("terlewat setelah dibaca = %d, ingin 0", n)
+	}
+}
+
+func TestHubHargaPelangganLambatTidakMenahanLainnya(t *testing.T) {
+	hub := NewHubHarga(1)
+	lambat := hub.Langganan(nil)
+	cepat := hub.Langganan([]string{"aman"})
+	lain := hub.Langganan([]string{"MZN"})
+	defer hub.Berhenti(lambat)
+	defer hub.Berhenti(cepat)
+	defer hub.Berhenti(lain)
+
+	var diterimaCepat []domain.Pasar
+	for _, harga := range []string{"1", "2", "3"} {
+		hub.Terbitkan(pasarUji(t, "AMAN", harga))
+		diterimaCepat = append(diterimaCepat, terimaSemua(cepat)...)
+	}
+
+	if len(diterimaCepat) != 3 || cepat.Terlewat() != 0 {
+		t.Errorf("pelanggan cepat menerima %d harga dengan %d terlewat, ingin 3 tanpa terlewat", len(diterimaCepat), cepat.Terlewat())
+	}
+	if diterima := terimaSemua(lambat); len(diterima) != 1 || diterima[0].Harga != hargaUji(t, "3") {
+		t.Errorf("pelanggan lambat menerima %v, ingin hanya harga terakhir", diterima)
+	}
+	if diterima := terimaSemua(lain); len(diterima) != 0 {
+		t.Errorf("pelanggan simbol lain menerima %d harga", len(diterima))
+	}
+}
+
+func TestHubHargaBerhenti(t *testing.T) {
+	hub := NewHubHarga(0)
+	p := hub.Langganan(nil)
+	hub.Berhenti(p)
+	hub.Terbitkan(pasarUji(t, "AMAN", "1"))
+	if hub.JumlahPelanggan() != 0 || len(terimaSemua(p)) != 0 {
+		t.Error("pelanggan yang berhenti masih menerima harga")
+	}
+}
@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+type ImporPortofolioUsecase struct {
+	portofolio *PortofolioUsecase
+	repo       domain.ImporPortofolioRepository
+	pengurai   []domain.PenguraiMutasi
+}
+
+func NewImporPortofolioUsecase(portofolio *PortofolioUsecase, repo domain.ImporPortofolioRepository, pengurai []domain.PenguraiMutasi) *ImporPortofolioUsecase {
+	return &ImporPortofolioUsecase{portofolio: portofolio, repo: repo, pengurai: pengurai}
+}
+
+// Pratinjau mengurai berkas tanpa menyentuh buku besar. Format kosong atau
+// "otomatis" mencoba setiap pengurai secara berurutan. Baris yang sudah ada
+// di buku besar ditandai duplikat sehingga impor ulang berkas yang sama aman.
+func (u *ImporPortofolioUsecase) Pratinjau(ctx context.Context, idPengguna int64, format, namaBerkas string, data []byte) (*domain.ImporPortofolio, error) {
+	if len(data) == 0 {
+		return nil, errors.New("berkas kosong")
+	}
+	format = strings.ToLower(strings.TrimSpace(format))
+	var baris []domain.BarisImpor
+	var cocok domain.PenguraiMutasi
+	for _, p := range u.pengurai {
+		if format != "" && format != "otomatis" && p.Format() != format {
+			continue
+		}
+		hasil, err := p.Urai(data)
+		if errors.Is(err, domain.ErrFormatTidakCocok) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		baris, cocok = hasil, p
+		break
+	}
+	if cocok == nil {
+		if format != "" && format != "otomatis" {
+			return nil, fmt.Errorf("berkas bukan format %s yang didukung", format)
+		}
+		return nil, errors.New("format berkas tidak dikenali")
+	}
+
+	for i := range baris {
+		if baris[i].Status != domain.BarisBaru {
+			continue
+		}
+		baris[i].Transaksi.IDPengguna = idPengguna
+		if err := normalisasiTransaksi(&baris[i].Transaksi); err != nil {
+			baris[i].Status = domain.BarisGagal
+			baris[i].Pesan = err.Error()
+		}
+	}
+	if err := u.tandaiDuplikat(ctx, idPengguna, baris); err != nil {
+		return nil, err
+	}
+
+	impor := &domain.ImporPortofolio{
+		IDPengguna: idPengguna,
+		Format:     cocok.Format(),
+		NamaBerkas: namaBerkas,
+		Status:     domain.ImporPratinjau,
+		Baris:      baris,
+		DibuatPada: time.Now(),
+	}
+	hitungRingkasanImpor(impor)
+	if err := u.repo.SimpanImporPortofolio(ctx, impor); err != nil {
+		return nil, err
+	}
+	return impor, nil
+}
+
+func (u *ImporPortofolioUsecase) Ambil(ctx context.Context, id int64, idPengguna int64) (*domain.ImporPortofolio, error) {
+	return u.repo.AmbilImporPortofolio(ctx, id, idPengguna)
+}
+
+// Terapkan mencatat baris berstatus baru ke buku besar dalam urutan
+// kronologis. Impor diklaim lebih dulu agar permintaan ganda tidak menerapkan
+// baris yang sama dua kali, lalu seluruh transaksi disimpan dalam satu
+// transaksi basis data. Duplikat diperiksa ulang karena buku besar bisa
+// berubah sejak pratinjau; baris yang ditolak validasi buku besar ditandai gagal.
+func (u *ImporPortofolioUsecase) Terapkan(ctx context.Context, id int64, idPengguna int64) (*domain.ImporPortofolio, error) {
+	impor, err := u.repo.AmbilImporPortofolio(ctx, id, idPengguna)
+	if err != nil {
+		return nil, err
+	}
+	if impor == nil {
+		return nil, errors.New("impor tidak ditemukan")
+	}
+	diklaim, err := u.repo.UbahStatusImporPortofolio(ctx, id, idPengguna, domain.ImporPratinjau, domain.ImporMenerapkan)
+	if err != nil {
+		return nil, err
+	}
+	if !diklaim {
+		return nil, errors.New("impor sudah atau sedang diterapkan")
+	}
+	if err := u.terapkan(ctx, impor, idPengguna); err != nil {
+		// Lepas klaim agar impor dapat dicoba lagi.
+		if _, errLepas := u.repo.UbahStatusImporPortofolio(ctx, id, idPengguna, domain.ImporMenerapkan, domain.ImporPratinjau); errLepas != nil {
+			return nil, errors.Join(err, errLepas)
+		}
+		return nil, err
+	}
+	return impor, nil
+}
+
+func (u *ImporPortofolioUsecase) terapkan(ctx context.Context, impor *domain.ImporPortofolio, idPengguna int64) error {
+	buku, err := u.portofolio.repo.DaftarTransaksiPortofolio(ctx, idPengguna, "")
+	if err != nil {
+		return err
+	}
+	tandaiDuplikatDari(buku, impor.Baris)
+
+	var urutan []int
+	for i := range impor.Baris {
+		if impor.Baris[i].Status == domain.BarisBaru {
+			urutan = append(urutan, i)
+		}
+	}
+	sort.SliceStable(urutan, func(a, b int) bool {
+		return impor.Baris[urutan[a]].Transaksi.Tanggal.Before(impor.Baris[urutan[b]].Transaksi.Tanggal)
+	})
+
+	sekarang := time.Now()
+	var simpan []*domain.TransaksiPortofolio
+	for k, i := range urutan {
+		b := &impor.Baris[i]
+		transaksi := b.Transaksi
+		transaksi.ID = 0
+		transaksi.IDPengguna = idPengguna
+		err := normalisasiTransaksi(&transaksi)
+		if err == nil {
+			err = validasiTransaksiBaru(&transaksi, transaksiSimbol(buku, transaksi.Simbol))
+		}
+		if err != nil {
+			b.Status = domain.BarisGagal
+			b.Pesan = err.Error()
+			continue
+		}
+		transaksi.DibuatPada = sekarang
+		b.Status = domain.BarisTersimpan
+		b.Transaksi = transaksi
+		simpan = append(simpan, &b.Transaksi)
+
+		// Baris yang diterima ikut menjadi buku besar bagi baris berikutnya,
+		// dengan ID sementara yang mengikuti urutan penyimpanan.
+		transaksi.ID = math.MaxInt64 - int64(len(urutan)) + int64(k)
+		buku = append(buku, transaksi)
+	}
+
+	impor.Status = domain.ImporDiterapkan
+	impor.DiterapkanPada = &sekarang
+	hitungRingkasanImpor(impor)
+	return u.repo.TerapkanImporPortofolio(ctx, impor, simpan)
+}
+
+func (u *ImporPortofolioUsecase) Hapus(ctx context.Context, id int64, idPengguna int64) error {
+	return u.repo.HapusImporPortofolio(ctx, id, idPengguna)
+}
+
+// tandaiDuplikat mencocokkan sidik setiap baris baru dengan buku besar.
+func (u *ImporPortofolioUsecase) tandaiDuplikat(ctx context.Context, idPengguna int64, baris []domain.BarisImpor) error {
+	ada, err := u.portofolio.repo.DaftarTransaksiPortofolio(ctx, idPengguna, "")
+	if err != nil {
+		return err
+	}
+	tandaiDuplikatDari(ada, baris)
+	return nil
+}
+
+// tandaiDuplikatDari menghitung sidik per kemunculan agar dua trade identik
+// dalam satu berkas tidak saling menganggap duplikat bila buku besar baru
+// berisi salah satunya.
+func tandaiDuplikatDari(ada []domain.TransaksiPortofolio, baris []domain.BarisImpor) {
+	sisa := map[string]int{}
+	for _, t := range ada {
+		sisa[sidikTransaksi(t)]++
+	}
+	for i := range baris {
+		if baris[i].Status != domain.BarisBaru && baris[i].Status != domain.BarisDuplikat {
+			continue
+		}
+		sidik := sidikTransaksi(baris[i].Transaksi)
+		if sisa[sidik] > 0 {
+			sisa[sidik]--
+			baris[i].Status = domain.BarisDuplikat
+			baris[i].Pesan = "transaksi sudah tercatat"
+			continue
+		}
+		if baris[i].Status == domain.BarisDuplikat {
+			baris[i].Status = domain.BarisBaru
+			baris[i].Pesan = ""
+		}
+	}
+}
+
+// transaksiSimbol menyaring buku besar untuk satu simbol, sama dengan filter
+// simbol pada repository.
+func transaksiSimbol(buku []domain.TransaksiPortofolio, simbol string) []domain.TransaksiPortofolio {
+	var hasil []domain.TransaksiPortofolio
+	for _, t := range buku {
+		if strings.EqualFold(t.Simbol, simbol) {
+			hasil = append(hasil, t)
+		}
+	}
+	return hasil
+}
+
+// sidikTransaksi mengikuti presisi kolom basis data: detik untuk tanggal dan
+// delapan digit untuk jumlah.
+func sidikTransaksi(t domain.TransaksiPortofolio) string {
+	return fmt.Sprintf("%s|%s|%s|%s|%.8f|%s|%s",
+		t.Jenis,
+		strings.ToUpper(t.Simbol),
+		t.MataUang,
+		t.Tanggal.UTC().Truncate(time.Second).Format(time.RFC3339),
+		t.Jumlah,
+		t.Harga.String(),
+		t.Biaya.String(),
+	)
+}
+
+func hitungRingkasanImpor(impor *domain.ImporPortofolio) {
+	impor.JumlahBaru, impor.JumlahDuplikat, impor.JumlahGagal = 0, 0, 0
+	for _, b := range impor.Baris {
+		switch b.Status {
+		case domain.BarisBaru, domain.BarisTersimpan:
+			impor.JumlahBaru++
+		case domain.BarisDuplikat:
+			impor.JumlahDuplikat++
+		case domain.BarisGagal:
+			impor.JumlahGagal++
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func TestSidikTransaksi(t *testing.T) {
+	dasar := transaksiUji(t, 1, 5, domain.TransaksiBeli, 0.5, "42000.5", "1")
+	sama := dasar
+	sama.ID = 99
+	sama.Simbol = "aman"
+	sama.Tanggal = dasar.Tanggal.Add(400 * time.Millisecond)
+	sama.Catatan = "catatan lain"
+	if sidikTransaksi(sama) != sidikTransaksi(dasar) {
+		t.Errorf("sidik berbeda untuk transaksi yang sama:\n%s\n%s", sidikTransaksi(sama), sidikTransaksi(dasar))
+	}
+
+	beda := []func(*domain.TransaksiPortofolio){
+		func(t *domain.TransaksiPortofolio) { t.Jenis = domain.TransaksiJual },
+		func(t *domain.TransaksiPortofolio) { t.MataUang = "USD" },
+		func(t *domain.TransaksiPortofolio) { t.Tanggal = t.Tanggal.Add(time.Second) },
+		func(t *domain.TransaksiPortofolio) { t.Jumlah += 0.00000001 },
+		func(t *domain.TransaksiPortofolio) { t.Harga++ },
+		func(t *domain.TransaksiPortofolio) { t.Biaya++ },
+	}
+	for i, ubah := range beda {
+		lain := dasar
+		ubah(&lain)
+		if sidikTransaksi(lain) == sidikTransaksi(dasar) {
+			t.Errorf("perubahan %d tidak mengubah sidik", i)
+		}
+	}
+}
+
+func TestTandaiDuplikatDari(t *testing.T) {
+	trade := transaksiUji(t, 0, 5, domain.TransaksiBeli, 1, "100", "0")
+	lain := transaksiUji(t, 0, 6, domain.TransaksiBeli, 2, "100", "0")
+	buku := []domain.TransaksiPortofolio{trade}
+	baris := []domain.BarisImpor{
+		{Nomor: 2, Status: domain.BarisBaru, Transaksi: trade},
+		// Trade identik kedua dalam berkas yang sama tetap baru karena buku
+		// besar hanya berisi satu.
+		{Nomor: 3, Status: domain.BarisBaru, Transaksi: trade},
+		{Nomor: 4, Status: domain.BarisBaru, Pesan: "biaya 0.1 BNB tidak dicatat", Transaksi: lain},
+		// Duplikat dari pratinjau yang transaksinya sudah dihapus kembali baru.
+		{Nomor: 5, Status: domain.BarisDuplikat, Pesan: "transaksi sudah tercatat", Transaksi: lain},
+		{Nomor: 6, Status: domain.BarisGagal, Pesan: "tanggal tidak dikenali", Transaksi: trade},
+	}
+	tandaiDuplikatDari(buku, baris)
+
+	ingin := []struct {
+		status string
+		pesan  string
+	}{
+		{domain.BarisDuplikat, "transaksi sudah tercatat"},
+		{domain.BarisBaru, ""},
+		{domain.BarisBaru, "biaya 0.1 BNB tidak dicatat"},
+		{domain.BarisBaru, ""},
+		{domain.BarisGagal, "tanggal tidak dikenali"},
+	}
+	for i, w := range ingin {
+		if baris[i].Status != w.status || baris[i].Pesan != w.pesan {
+			t.Errorf("baris %d = %q %q, ingin %q %q", baris[i].Nomor, baris[i].Status, baris[i].Pesan, w.status, w.pesan)
+		}
+	}
+}
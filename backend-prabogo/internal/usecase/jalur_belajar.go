@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func (u *EdukasiUsecase) DaftarJalur(ctx context.Context) ([]domain.JalurBelajar, error) {
+	return u.repo.DaftarJalur(ctx)
+}
+
+// DetailJalur mengambil jalur terbit beserta kelasnya. idPengguna 0 berarti
+// pemanggil belum login sehingga progress tidak disertakan. Mengembalikan nil
+// bila jalur tidak ditemukan.
+func (u *EdukasiUsecase) DetailJalur(ctx context.Context, id, idPengguna int64) (*domain.JalurBelajar, error) {
+	jalur, err := u.repo.DetailJalur(ctx, id)
+	if err != nil || jalur == nil || idPengguna == 0 {
+		return jalur, err
+	}
+	progress, err := u.repo.DaftarProgress(ctx, idPengguna)
+	if err != nil {
+		return nil, err
+	}
+	susunProgressJalur(jalur, progress)
+	return jalur, nil
+}
+
+// susunProgressJalur mengisi progress tiap kelas jalur dan ringkasannya.
+// Kelas berikutnya adalah kelas pertama menurut urutan yang belum selesai,
+// dan persentase jalur adalah rata-rata persentase seluruh kelasnya.
+func susunProgressJalur(jalur *domain.JalurBelajar, progress []domain.ProgressKelas) {
+	perKelas := make(map[int64]*domain.ProgressKelas, len(progress))
+	for i := range progress {
+		perKelas[progress[i].IDKelas] = &progress[i]
+	}
+
+	ringkasan := &domain.ProgressJalur{Status: domain.ProgressBelumMulai, JumlahKelas: len(jalur.Kelas)}
+	var total float64
+	for i := range jalur.Kelas {
+		k := &jalur.Kelas[i]
+		k.Progress = perKelas[k.IDKelas]
+		if k.Progress == nil {
+			if ringkasan.IDKelasBerikutnya == nil {
+				ringkasan.IDKelasBerikutnya = &k.IDKelas
+			}
+			continue
+		}
+		ringkasan.Status = domain.ProgressBerjalan
+		total += k.Progress.Persentase
+		if k.Progress.Status == domain.ProgressSelesai {
+			ringkasan.KelasSelesai++
+		} else if ringkasan.IDKelasBerikutnya == nil {
+			ringkasan.IDKelasBerikutnya = &k.IDKelas
+		}
+	}
+	if ringkasan.JumlahKelas > 0 {
+		ringkasan.Persentase = math.Round(total/float64(ringkasan.JumlahKelas)*100) / 100
+		if ringkasan.KelasSelesai == ringkasan.JumlahKelas {
+			ringkasan.Status = domain.ProgressSelesai
+		}
+	}
+	jalur.Progress = ringkasan
+}
+
+// cekPrasyarat memastikan seluruh kelas prasyarat sudah selesai.
+func (u *EdukasiUsecase) cekPrasyarat(ctx context.Context, idPengguna, idKelas int64) error {
+	prasyarat, err := u.repo.DaftarPrasyaratKelas(ctx, idKelas)
+	if err != nil || len(prasyarat) == 0 {
+		return err
+	}
+	progress, err := u.repo.DaftarProgress(ctx, idPengguna)
+	if err != nil {
+		return err
+	}
+	return prasyaratBelumSelesai(prasyarat, progress)
+}
+
+// prasyaratBelumSelesai mengembalikan ErrPrasyaratBelumSelesai beserta judul
+// prasyarat yang belum selesai.
+func prasyaratBelumSelesai(prasyarat []domain.PrasyaratKelas, progress []domain.ProgressKelas) error {
+	tandaiPrasyaratSelesai(prasyarat, progress)
+	var belum []string
+	for _, p := range prasyarat {
+		if !*p.Selesai {
+			belum = append(belum, p.JudulPrasyarat)
+		}
+	}
+	if len(belum) > 0 {
+		return fmt.Errorf("%w: %s", domain.ErrPrasyaratBelumSelesai, strings.Join(belum, ", "))
+	}
+	return nil
+}
+
+func tandaiPrasyaratSelesai(prasyarat []domain.PrasyaratKelas, progress []domain.ProgressKelas) {
+	selesai := make(map[int64]bool, len(progress))
+	for _, p := range progress {
+		if p.Status == domain.ProgressSelesai {
+			selesai[p.IDKelas] = true
+		}
+	}
+	for i := range prasyarat {
+		s := selesai[prasyarat[i].IDPrasyarat]
+		prasyarat[i].Selesai = &s
+	}
+}
+
+func (u *AdminUsecase) DaftarJalur(ctx context.Context) ([]domain.JalurBelajar, error) {
+	return u.repo.DaftarJalurSemua(ctx)
+}
+
+func (u *AdminUsecase) BuatJalur(ctx context.Context, jalur *domain.JalurBelajar) error {
+	if err := validasiJalur(jalur, true); err != nil {
+		return err
+	}
+	return u.repo.BuatJalur(ctx, jalur)
+}
+
+func (u *AdminUsecase) PerbaruiJalur(ctx context.Context, jalur *domain.JalurBelajar) error {
+	if err := validasiJalur(jalur, false); err != nil {
+		return err
+	}
+	return u.repo.PerbaruiJalur(ctx, jalur)
+}
+
+func (u *AdminUsecase) HapusJalur(ctx context.Context, id int64) error {
+	return u.repo.HapusJalur(ctx, id)
+}
+
+// validasiJalur memeriksa isian jalur dan menomori ulang kelas sesuai urutan
+// kiriman.
+func validasiJalur(jalur *domain.JalurBelajar, baru bool) error {
+	jalur.Judul = strings.TrimSpace(jalur.Judul)
+	if jalur.Judul == "" {
+		return errors.New("judul jalur wajib diisi")
+	}
+	if err := siapkanStatusKonten(&jalur.Status, baru); err != nil {
+		return err
+	}
+	ada := make(map[int64]bool, len(jalur.Kelas))
+	for i := range jalur.Kelas {
+		id := jalur.Kelas[i].IDKelas
+		if id <= 0 {
+			return errors.New("id_kelas jalur tidak valid")
+		}
+		if ada[id] {
+			return errors.New("kelas tidak boleh muncul dua kali dalam satu jalur")
+		}
+		ada[id] = true
+		jalur.Kelas[i].Urutan = i + 1
+	}
+	return nil
+}
+
+// SimpanPrasyaratKelas mengganti prasyarat kelas dan menolak prasyarat yang
+// membentuk siklus, misalnya A membutuhkan B sementara B membutuhkan A.
+func (u *AdminUsecase) SimpanPrasyaratKelas(ctx context.Context, idKelas int64, idPrasyarat []int64) error {
+	semua, err := u.repo.DaftarPrasyaratSemua(ctx)
+	if err != nil {
+		return err
+	}
+	if err := validasiPrasyarat(idKelas, idPrasyarat, semua); err != nil {
+		return err
+	}
+	return u.repo.SimpanPrasyaratKelas(ctx, idKelas, idPrasyarat)
+}
+
+// validasiPrasyarat menelusuri prasyarat yang ada, tanpa prasyarat lama
+// idKelas yang akan diganti, dari setiap prasyarat baru; bila idKelas
+// tercapai berarti prasyarat membentuk siklus.
+func validasiPrasyarat(idKelas int64, idPrasyarat []int64, semua []domain.PrasyaratKelas) error {
+	unik := make(map[int64]bool, len(idPrasyarat))
+	for _, id := range idPrasyarat {
+		if id <= 0 {
+			return errors.New("id_prasyarat tidak valid")
+		}
+		if id == idKelas {
+			return errors.New("kelas tidak boleh menjadi prasyarat dirinya sendiri")
+		}
+		if unik[id] {
+			return errors.New("id_prasyarat tidak boleh ganda")
+		}
+		unik[id] = true
+	}
+
+	graf := make(map[int64][]int64)
+	for _, p := range semua {
+		if p.IDKelas != idKelas {
+			graf[p.IDKelas] = append(graf[p.IDKelas], p.IDPrasyarat)
+		}
+	}
+	dikunjungi := make(map[int64]bool)
+	antrian := append([]int64(nil), idPrasyarat...)
+	for len(antrian) > 0 {
+		id := antrian[0]
+		antrian = antrian[1:]
+		if id == idKelas {
+			return errors.New("prasyarat membentuk siklus antarkelas")
+		}
+		if dikunjungi[id] {
+			continue
+		}
+		dikunjungi[id] = true
+		antrian = append(antrian, graf[id]...)
+	}
+	return nil
+}
+
+func (u *AdminUsecase) DaftarPrasyaratKelas(ctx context.Context, idKelas int64) ([]domain.PrasyaratKelas, error) {
+	semua, err := u.repo.DaftarPrasyaratSemua(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items := []domain.PrasyaratKelas{}
+	for _, p := range semua {
+		if p.IDKelas == idKelas {
+			items = append(items, p)
+		}
+	}
+	return items, nil
+}
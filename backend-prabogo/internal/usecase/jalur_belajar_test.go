@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func TestValidasiPrasyarat(t *testing.T) {
+	// Graf yang sudah ada: 2 membutuhkan 3, 3 membutuhkan 4, 5 membutuhkan 1.
+	semua := []domain.PrasyaratKelas{
+		{IDKelas: 2, IDPrasyarat: 3},
+		{IDKelas: 3, IDPrasyarat: 4},
+		{IDKelas: 5, IDPrasyarat: 1},
+	}
+	tests := []struct {
+		nama        string
+		idKelas     int64
+		idPrasyarat []int64
+		semua       []domain.PrasyaratKelas
+		gagal       string
+	}{
+		{"tanpa prasyarat", 1, nil, semua, ""},
+		{"rantai tanpa siklus", 1, []int64{2}, semua, ""},
+		{"dua jalur ke kelas yang sama", 1, []int64{2, 3}, semua, ""},
+		{"siklus langsung", 1, []int64{5}, semua, "siklus"},
+		{"siklus tiga kelas", 4, []int64{2}, semua, "siklus"},
+		{
+			// A membutuhkan B, B membutuhkan C, lalu C diberi prasyarat A.
+			nama:        "siklus A ke B ke C ke A",
+			idKelas:     3,
+			idPrasyarat: []int64{1},
+			semua:       []domain.PrasyaratKelas{{IDKelas: 1, IDPrasyarat: 2}, {IDKelas: 2, IDPrasyarat: 3}},
+			gagal:       "siklus",
+		},
+		{
+			// Prasyarat lama kelas 3 diganti sehingga tidak dihitung sebagai siklus.
+			nama:        "prasyarat lama diganti",
+			idKelas:     4,
+			idPrasyarat: []int64{5},
+			semua:       append([]domain.PrasyaratKelas{{IDKelas: 4, IDPrasyarat: 2}}, semua...),
+			gagal:       "",
+		},
+		{"dirinya sendiri", 1, []int64{1}, semua, "dirinya sendiri"},
+		{"ganda", 1, []int64{2, 2}, semua, "ganda"},
+		{"id tidak valid", 1, []int64{0}, semua, "tidak valid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			err := validasiPrasyarat(tt.idKelas, tt.idPrasyarat, tt.semua)
+			switch {
+			case tt.gagal == "" && err != nil:
+				t.Errorf("validasiPrasyarat = %v, ingin lolos", err)
+			case tt.gagal != "" && (err == nil || !strings.Contains(err.Error(), tt.gagal)):
+				t.Errorf("validasiPrasyarat = %v, ingin error %q", err, tt.gagal)
+			}
+		})
+	}
+}
+
+func TestSusunProgressJalur(t *testing.T) {
+	selesai := domain.ProgressKelas{IDKelas: 1, Persentase: 100, Status: domain.ProgressSelesai}
+	berjalan := domain.ProgressKelas{IDKelas: 2, Persentase: 50, Status: domain.ProgressBerjalan}
+	id := func(n int64) *int64 { return &n }
+
+	tests := []struct {
+		nama       string
+		progress   []domain.ProgressKelas
+		status     string
+		persentase float64
+		selesai    int
+		berikutnya *int64
+	}{
+		{"belum mulai", nil, domain.ProgressBelumMulai, 0, 0, id(1)},
+		{"kelas berjalan menjadi berikutnya", []domain.ProgressKelas{selesai, berjalan}, domain.ProgressBerjalan, 50, 1, id(2)},
+		{
+			"kelas awal yang belum dimulai didahulukan",
+			[]domain.ProgressKelas{{IDKelas: 2, Persentase: 100, Status: domain.ProgressSelesai}},
+			domain.ProgressBerjalan, 33.33, 1, id(1),
+		},
+		{
+			"semua selesai",
+			[]domain.ProgressKelas{selesai, {IDKelas: 2, Persentase: 100, Status: domain.ProgressSelesai}, {IDKelas: 3, Persentase: 100, Status: domain.ProgressSelesai}},
+			domain.ProgressSelesai, 100, 3, nil,
+		},
+		{
+			"progress kelas di luar jalur diabaikan",
+			[]domain.ProgressKelas{{IDKelas: 9, Persentase: 100, Status: domain.ProgressSelesai}},
+			domain.ProgressBelumMulai, 0, 0, id(1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			jalur := &domain.JalurBelajar{Kelas: []domain.KelasJalur{{IDKelas: 1, Urutan: 1}, {IDKelas: 2, Urutan: 2}, {IDKelas: 3, Urutan: 3}}}
+			susunProgressJalur(jalur, tt.progress)
+			r := jalur.Progress
+			if r.Status != tt.status || r.Persentase != tt.persentase || r.KelasSelesai != tt.selesai || r.JumlahKelas != 3 {
+				t.Errorf("progress = %s %v%%, selesai %d/%d; ingin %s %v%%, selesai %d/3", r.Status, r.Persentase, r.KelasSelesai, r.JumlahKelas, tt.status, tt.persentase, tt.selesai)
+			}
+			switch {
+			case tt.berikutnya == nil && r.IDKelasBerikutnya != nil:
+				t.Errorf("IDKelasBerikutnya = %d, ingin nil", *r.IDKelasBerikutnya)
+			case tt.berikutnya != nil && (r.IDKelasBerikutnya == nil || *r.IDKelasBerikutnya != *tt.berikutnya):
+				t.Errorf("IDKelasBerikutnya = %v, ingin %d", r.IDKelasBerikutnya, *tt.berikutnya)
+			}
+		})
+	}
+}
+
+// repoMulaiKelasUji hanya mengimplementasikan metode yang dipanggil MulaiKelas;
+// metode lain memicu panic lewat interface nil yang disematkan.
+type repoMulaiKelasUji struct {
+	domain.EdukasiRepository
+	progress  []domain.ProgressKelas
+	prasyarat []domain.PrasyaratKelas
+	tersimpan *domain.ProgressKelas
+}
+
+func (r *repoMulaiKelasUji) DetailKelas(_ context.Context, id int64) (*domain.Kelas, error) {
+	return &domain.Kelas{ID: id}, nil
+}
+
+func (r *repoMulaiKelasUji) AmbilProgressKelas(_ context.Context, _, idKelas int64) (*domain.ProgressKelas, error) {
+	for i := range r.progress {
+		if r.progress[i].IDKelas == idKelas {
+			return &r.progress[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *repoMulaiKelasUji) DaftarProgress(context.Context, int64) ([]domain.ProgressKelas, error) {
+	return r.progress, nil
+}
+
+func (r *repoMulaiKelasUji) DaftarPrasyaratKelas(context.Context, int64) ([]domain.PrasyaratKelas, error) {
+	return append([]domain.PrasyaratKelas(nil), r.prasyarat...), nil
+}
+
+func (r *repoMulaiKelasUji) DaftarMateriByKelas(context.Context, int64) ([]domain.Materi, error) {
+	return []domain.Materi{{ID: 1, DurasiMenit: 10}}, nil
+}
+
+func (r *repoMulaiKelasUji) DaftarProgressMateri(context.Context, int64, int64) ([]domain.ProgressMateri, error) {
+	return nil, nil
+}
+
+func (r *repoMulaiKelasUji) DaftarUjianByKelas(context.Context, int64) ([]domain.Ujian, error) {
+	return nil, nil
+}
+
+func (r *repoMulaiKelasUji) DaftarUjianLulus(context.Context, int64, int64) ([]int64, error) {
+	return nil, nil
+}
+
+func (r *repoMulaiKelasUji) SimpanProgress(_ context.Context, progress *domain.ProgressKelas) error {
+	r.tersimpan = progress
+	return nil
+}
+
+func TestMulaiKelasPrasyarat(t *testing.T) {
+	prasyarat := []domain.PrasyaratKelas{
+		{IDKelas: 3, IDPrasyarat: 1, JudulPrasyarat: "Dasar Fikih Muamalah"},
+		{IDKelas: 3, IDPrasyarat: 2, JudulPrasyarat: "Akad Syariah"},
+	}
+	tests := []struct {
+		nama     string
+		progress []domain.ProgressKelas
+		ditolak  string
+	}{
+		{
+			nama:     "prasyarat belum selesai",
+			progress: []domain.ProgressKelas{{IDKelas: 1, Status: domain.ProgressSelesai}, {IDKelas: 2, Status: domain.ProgressBerjalan}},
+			ditolak:  "Akad Syariah",
+		},
+		{
+			nama:    "belum memulai prasyarat",
+			ditolak: "Dasar Fikih Muamalah, Akad Syariah",
+		},
+		{
+			nama:     "semua prasyarat selesai",
+			progress: []domain.ProgressKelas{{IDKelas: 1, Status: domain.ProgressSelesai}, {IDKelas: 2, Status: domain.ProgressSelesai}},
+		},
+		{
+			// Peserta lama tidak terkunci oleh prasyarat yang ditambahkan kemudian.
+			nama:     "kelas sudah dimulai",
+			progress: []domain.ProgressKelas{{IDKelas: 3, Status: domain.ProgressBerjalan}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			repo := &repoMulaiKelasUji{progress: tt.progress, prasyarat: prasyarat}
+			err := NewEdukasiUsecase(repo, "").MulaiKelas(context.Background(), 7, 3)
+			if tt.ditolak != "" {
+				if !errors.Is(err, domain.ErrPrasyaratBelumSelesai) || !strings.HasSuffix(err.Error(), ": "+tt.ditolak) {
+					t.Fatalf("MulaiKelas = %v, ingin ditolak karena %s", err, tt.ditolak)
+				}
+				if repo.tersimpan != nil {
+					t.Error("progress tersimpan walau prasyarat belum selesai")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MulaiKelas = %v", err)
+			}
+			if repo.tersimpan == nil || repo.tersimpan.IDKelas != 3 || repo.tersimpan.Status != domain.ProgressBerjalan {
+				t.Errorf("progress tersimpan = %+v, ingin kelas 3 berjalan", repo.tersimpan)
+			}
+		})
+	}
+}
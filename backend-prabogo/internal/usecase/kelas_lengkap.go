@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+// KelasLengkap menyusun kelas beserta modul, materi dan ujiannya dengan jumlah
+// kueri tetap. idPengguna 0 berarti pemanggil belum login sehingga status
+// belajar tidak disertakan. Mengembalikan nil bila kelas tidak ditemukan.
+func (u *EdukasiUsecase) KelasLengkap(ctx context.Context, idKelas, idPengguna int64) (*domain.KelasLengkap, error) {
+	kelas, err := u.repo.DetailKelas(ctx, idKelas)
+	if err != nil || kelas == nil {
+		return nil, err
+	}
+	modul, err := u.repo.DaftarModulByKelas(ctx, idKelas)
+	if err != nil {
+		return nil, err
+	}
+	materi, err := u.repo.DaftarMateriByKelas(ctx, idKelas)
+	if err != nil {
+		return nil, err
+	}
+	ujian, err := u.repo.DaftarUjianByKelas(ctx, idKelas)
+	if err != nil {
+		return nil, err
+	}
+
+	prasyarat, err := u.repo.DaftarPrasyaratKelas(ctx, idKelas)
+	if err != nil {
+		return nil, err
+	}
+
+	hasil := susunKelasLengkap(kelas, modul, materi, ujian, prasyarat)
+	if idPengguna == 0 {
+		return hasil, nil
+	}
+
+	progressMateri, err := u.repo.DaftarProgressMateri(ctx, idPengguna, idKelas)
+	if err != nil {
+		return nil, err
+	}
+	lulus, err := u.repo.DaftarUjianLulus(ctx, idPengguna, idKelas)
+	if err != nil {
+		return nil, err
+	}
+	if hasil.Progress, err = u.repo.AmbilProgressKelas(ctx, idPengguna, idKelas); err != nil {
+		return nil, err
+	}
+	if len(hasil.Prasyarat) > 0 {
+		progressKelas, err := u.repo.DaftarProgress(ctx, idPengguna)
+		if err != nil {
+			return nil, err
+		}
+		tandaiPrasyaratSelesai(hasil.Prasyarat, progressKelas)
+	}
+
+	progress := make(map[int64]*domain.ProgressMateri, len(progressMateri))
+	for i := range progressMateri {
+		progress[progressMateri[i].IDMateri] = &progressMateri[i]
+	}
+	for i := range hasil.Modul {
+		selesai := true
+		for j := range hasil.Modul[i].Materi {
+			p := progress[hasil.Modul[i].Materi[j].ID]
+			hasil.Modul[i].Materi[j].Progress = p
+			if p == nil || !p.Selesai {
+				selesai = false
+			}
+		}
+		hasil.Modul[i].Selesai = &selesai
+	}
+	sudahLulus := make(map[int64]bool, len(lulus))
+	for _, id := range lulus {
+		sudahLulus[id] = true
+	}
+	for i := range hasil.Ujian {
+		l := sudahLulus[hasil.Ujian[i].ID]
+		hasil.Ujian[i].Lulus = &l
+	}
+	return hasil, nil
+}
+
+func susunKelasLengkap(kelas *domain.Kelas, modul []domain.Modul, materi []domain.Materi, ujian []domain.Ujian, prasyarat []domain.PrasyaratKelas) *domain.KelasLengkap {
+	hasil := &domain.KelasLengkap{
+		Kelas:     *kelas,
+		Modul:     make([]domain.ModulLengkap, 0, len(modul)),
+		Ujian:     make([]domain.UjianLengkap, 0, len(ujian)),
+		Prasyarat: append([]domain.PrasyaratKelas{}, prasyarat...),
+	}
+	indeks := make(map[int64]int, len(modul))
+	for _, m := range modul {
+		indeks[m.ID] = len(hasil.Modul)
+		hasil.Modul = append(hasil.Modul, domain.ModulLengkap{Modul: m, Materi: []domain.MateriLengkap{}})
+	}
+	for _, m := range materi {
+		if i, ok := indeks[m.IDModul]; ok {
+			hasil.Modul[i].Materi = append(hasil.Modul[i].Materi, domain.MateriLengkap{Materi: m})
+		}
+	}
+	for _, uj := range ujian {
+		hasil.Ujian = append(hasil.Ujian, domain.UjianLengkap{Ujian: uj})
+	}
+	return hasil
+}
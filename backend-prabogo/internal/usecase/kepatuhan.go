@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+const maksAlternatif = 3
+
+type KepatuhanUsecase struct {
+	portofolio *PortofolioUsecase
+	screener   domain.ScreenerRepository
+}
+
+func NewKepatuhanUsecase(portofolio *PortofolioUsecase, screener domain.ScreenerRepository) *KepatuhanUsecase {
+	return &KepatuhanUsecase{portofolio: portofolio, screener: screener}
+}
+
+// Laporan mencocokkan setiap kepemilikan dengan hasil screener berdasarkan simbol.
+// Aset yang belum disaring dihitung sebagai diragukan sampai ada hasil screening,
+// kecuali emas yang halal secara zatnya.
+func (u *KepatuhanUsecase) Laporan(ctx context.Context, idPengguna int64, mataUang string) (*domain.KepatuhanPortofolio, error) {
+	tampilan, err := u.portofolio.MataUangTampilan(ctx, idPengguna, mataUang)
+	if err != nil {
+		return nil, err
+	}
+	posisi, err := u.portofolio.Daftar(ctx, idPengguna, tampilan, "", false)
+	if err != nil {
+		return nil, err
+	}
+	daftarScreener, err := u.screener.DaftarScreener(ctx, "semua", "")
+	if err != nil {
+		return nil, err
+	}
+	daftarPasar, err := u.screener.DaftarPasar(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return susunKepatuhan(posisi, daftarScreener, daftarPasar, tampilan), nil
+}
+
+// susunKepatuhan menyusun laporan dari posisi yang sudah dinilai dalam mata
+// uang tampilan beserta seluruh data screener dan pasar.
+func susunKepatuhan(posisi []domain.Portofolio, daftarScreener []domain.Screener, daftarPasar []domain.Pasar, tampilan string) *domain.KepatuhanPortofolio {
+	screener := make(map[string]domain.Screener, len(daftarScreener))
+	for _, s := range daftarScreener {
+		screener[strings.ToUpper(s.Simbol)] = s
+	}
+	kelasPasar := make(map[string]string, len(daftarPasar))
+	for _, p := range daftarPasar {
+		kelasPasar[strings.ToUpper(p.Simbol)] = p.KategoriAset
+	}
+	dimiliki := make(map[string]bool, len(posisi))
+	for _, p := range posisi {
+		dimiliki[strings.ToUpper(p.Simbol)] = true
+	}
+
+	laporan := &domain.KepatuhanPortofolio{
+		MataUang:       tampilan,
+		Aset:           []domain.KepatuhanAset{},
+		PerluDivestasi: []domain.KepatuhanAset{},
+	}
+	var nilaiBelumDisaring domain.Uang
+	for _, p := range posisi {
+		aset := domain.KepatuhanAset{
+			IDPortofolio:      p.ID,
+			NamaAset:          p.NamaAset,
+			Simbol:            p.Simbol,
+			Status:            domain.KepatuhanBelumDisaring,
+			Nilai:             p.NilaiTampilan,
+			KursTidakTersedia: p.KursTidakTersedia,
+		}
+		// Kelas aset diambil dari data screener atau pasar, bukan dari kategori
+		// kepemilikan yang berisi label syariah bebas isian pengguna.
+		s, disaring := screener[strings.ToUpper(p.Simbol)]
+		kelasAset := kelasPasar[strings.ToUpper(p.Simbol)]
+		if disaring {
+			kelasAset = s.KategoriAset
+		}
+		switch {
+		case disaring:
+			aset.KategoriScreener = s.Kategori
+			aset.Sektor = s.Sektor
+			aset.SkorSyariah = s.SkorSyariah
+			aset.Keterangan = s.Keterangan
+			aset.Status = statusKepatuhan(s.Kategori)
+		case simbolEmas[strings.ToUpper(p.Simbol)]:
+			aset.Status = domain.KepatuhanPatuh
+			aset.Keterangan = "Emas termasuk harta zakawi yang halal dimiliki"
+		}
+
+		laporan.TotalNilai += aset.Nilai
+		switch aset.Status {
+		case domain.KepatuhanPatuh:
+			laporan.NilaiPatuh += aset.Nilai
+		case domain.KepatuhanTidakPatuh:
+			laporan.NilaiTidakPatuh += aset.Nilai
+		default:
+			laporan.NilaiDiragukan += aset.Nilai
+			if aset.Status == domain.KepatuhanBelumDisaring {
+				nilaiBelumDisaring += aset.Nilai
+			}
+		}
+		if aset.Status != domain.KepatuhanPatuh {
+			aset.Alternatif = alternatifPatuh(daftarScreener, kelasAset, aset.Sektor, dimiliki)
+		}
+		laporan.Aset = append(laporan.Aset, aset)
+	}
+
+	for i := range laporan.Aset {
+		laporan.Aset[i].Persen = persen(laporan.Aset[i].Nilai, laporan.TotalNilai)
+		if laporan.Aset[i].Status == domain.KepatuhanTidakPatuh {
+			laporan.PerluDivestasi = append(laporan.PerluDivestasi, laporan.Aset[i])
+		}
+	}
+	sort.SliceStable(laporan.PerluDivestasi, func(i, j int) bool {
+		return laporan.PerluDivestasi[i].Nilai > laporan.PerluDivestasi[j].Nilai
+	})
+	laporan.PersenPatuh = persen(laporan.NilaiPatuh, laporan.TotalNilai)
+	laporan.PersenDiragukan = persen(laporan.NilaiDiragukan, laporan.TotalNilai)
+	laporan.PersenTidakPatuh = persen(laporan.NilaiTidakPatuh, laporan.TotalNilai)
+	laporan.PersenBelumDisaring = persen(nilaiBelumDisaring, laporan.TotalNilai)
+	return laporan
+}
+
+func statusKepatuhan(kategori string) string {
+	switch kategori {
+	case domain.ScreenerHalal:
+		return domain.KepatuhanPatuh
+	case domain.ScreenerTidakDirekomendasikan:
+		return domain.KepatuhanTidakPatuh
+	}
+	return domain.KepatuhanDiragukan
+}
+
+// alternatifPatuh memilih aset halal yang belum dimiliki dari kelas aset yang
+// sama dengan kepemilikan, diurutkan dari sektor yang sama lalu skor syariah
+// tertinggi. Sektor hanya mempertajam urutan sehingga saham tidak pernah
+// ditawarkan sebagai pengganti kripto. Simbol yang tidak dikenal screener
+// maupun pasar tidak memiliki kelas aset; semua kelas aset dipertimbangkan.
+func alternatifPatuh(daftar []domain.Screener, kelasAset, sektor string, dimiliki map[string]bool) []domain.Screener {
+	kelasAset = strings.TrimSpace(kelasAset)
+	var hasil []domain.Screener
+	for _, s := range daftar {
+		if s.Kategori != domain.ScreenerHalal || dimiliki[strings.ToUpper(s.Simbol)] {
+			continue
+		}
+		if kelasAset != "" && !strings.EqualFold(s.KategoriAset, kelasAset) {
+			continue
+		}
+		hasil = append(hasil, s)
+	}
+	sektorSama := func(s domain.Screener) bool {
+		return sektor != "" && strings.EqualFold(s.Sektor, sektor)
+	}
+	sort.SliceStable(hasil, func(i, j int) bool {
+		if a, b := sektorSama(hasil[i]), sektorSama(hasil[j]); a != b {
+			return a
+		}
+		return hasil[i].SkorSyariah > hasil[j].SkorSyariah
+	})
+	if len(hasil) > maksAlternatif {
+		hasil = hasil[:maksAlternatif]
+	}
+	return hasil
+}
+
+// persen mengembalikan bagian nilai terhadap total dalam persen dengan dua desimal.
+func persen(nilai, total domain.Uang) float64 {
+	return math.Round(nilai.Rasio(total)*10000) / 100
+}
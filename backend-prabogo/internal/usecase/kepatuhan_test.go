@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func TestAlternatifPatuh(t *testing.T) {
+	daftar := []domain.Screener{
+		{Simbol: "SKC", Kategori: domain.ScreenerHalal, KategoriAset: "kripto", Sektor: "keuangan", SkorSyariah: 91},
+		{Simbol: "AMAN", Kategori: domain.ScreenerHalal, KategoriAset: "kripto", Sektor: "pembayaran", SkorSyariah: 88},
+		{Simbol: "MZN", Kategori: domain.ScreenerProses, KategoriAset: "kripto", Sektor: "keuangan", SkorSyariah: 72},
+		{Simbol: "BRIS", Kategori: domain.ScreenerHalal, KategoriAset: "saham", Sektor: "keuangan", SkorSyariah: 95},
+		{Simbol: "ZKT", Kategori: domain.ScreenerHalal, KategoriAset: "kripto", Sektor: "keuangan", SkorSyariah: 60},
+	}
+	simbol := func(daftar []domain.Screener) []string {
+		hasil := []string{}
+		for _, s := range daftar {
+			hasil = append(hasil, s.Simbol)
+		}
+		return hasil
+	}
+	tests := []struct {
+		nama      string
+		kelasAset string
+		sektor    string
+		dimiliki  map[string]bool
+		ingin     []string
+	}{
+		// Saham keuangan berskor tertinggi tidak ditawarkan untuk kepemilikan kripto.
+		{"kelas aset sama, sektor diutamakan", "Kripto", "keuangan", nil, []string{"SKC", "ZKT", "AMAN"}},
+		{"sektor lain tetap mengisi sisa", "kripto", "pembayaran", map[string]bool{"SKC": true}, []string{"AMAN", "ZKT"}},
+		{"tanpa sektor urut skor", "saham", "", nil, []string{"BRIS"}},
+		{"tanpa kelas aset semua dipertimbangkan", "", "", nil, []string{"BRIS", "SKC", "AMAN"}},
+		{"kelas aset tanpa alternatif", "emas", "", nil, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			got := simbol(alternatifPatuh(daftar, tt.kelasAset, tt.sektor, tt.dimiliki))
+			if len(got) != len(tt.ingin) {
+				t.Fatalf("alternatif = %v, ingin %v", got, tt.ingin)
+			}
+			for i := range got {
+				if got[i] != tt.ingin[i] {
+					t.Fatalf("alternatif = %v, ingin %v", got, tt.ingin)
+				}
+			}
+		})
+	}
+}
+
+// Data berbentuk seed/seed.sql: kategori kepemilikan berisi label syariah
+// ('halal', 'proses'), sedangkan kelas aset screener dan pasar memakai nilai
+// bawaan migrasi ('kripto').
+func TestSusunKepatuhanDataSeed(t *testing.T) {
+	screener := []domain.Screener{
+		{Simbol: "AMAN", Kategori: domain.ScreenerHalal, KategoriAset: "kripto", Sektor: "pembayaran", SkorSyariah: 88.5},
+		{Simbol: "MZN", Kategori: domain.ScreenerProses, KategoriAset: "kripto", Sektor: "keuangan", SkorSyariah: 72.3},
+		{Simbol: "RBX", Kategori: domain.ScreenerTidakDirekomendasikan, KategoriAset: "kripto", Sektor: "keuangan", SkorSyariah: 35.1},
+		{Simbol: "SKC", Kategori: domain.ScreenerHalal, KategoriAset: "kripto", Sektor: "keuangan", SkorSyariah: 91.2},
+		{Simbol: "BRIS", Kategori: domain.ScreenerHalal, KategoriAset: "saham", Sektor: "keuangan", SkorSyariah: 95},
+	}
+	pasar := []domain.Pasar{
+		{Simbol: "AMAN", KategoriAset: "kripto"},
+		{Simbol: "MZN", KategoriAset: "kripto"},
+		{Simbol: "SKC", KategoriAset: "kripto"},
+		{Simbol: "TLKM", KategoriAset: "saham"},
+	}
+	posisi := []domain.Portofolio{
+		{ID: 1, Simbol: "AMAN", Kategori: "halal", NilaiTampilan: uangUji(t, "1000")},
+		{ID: 2, Simbol: "MZN", Kategori: "proses", NilaiTampilan: uangUji(t, "500")},
+		// Belum disaring tetapi kelas asetnya diketahui dari data pasar.
+		{ID: 3, Simbol: "TLKM", Kategori: "halal", NilaiTampilan: uangUji(t, "300")},
+		// Tidak dikenal sama sekali: semua kelas aset dipertimbangkan.
+		{ID: 4, Simbol: "XYZ", Kategori: "kripto", NilaiTampilan: uangUji(t, "200")},
+	}
+
+	laporan := susunKepatuhan(posisi, screener, pasar, domain.MataUangIDR)
+	ingin := map[string][]string{
+		"AMAN": nil,
+		"MZN":  {"SKC"},
+		"TLKM": {"BRIS"},
+		"XYZ":  {"BRIS", "SKC"},
+	}
+	if len(laporan.Aset) != len(ingin) {
+		t.Fatalf("aset = %d, ingin %d", len(laporan.Aset), len(ingin))
+	}
+	for _, aset := range laporan.Aset {
+		var got []string
+		for _, s := range aset.Alternatif {
+			got = append(got, s.Simbol)
+		}
+		if strings.Join(got, ",") != strings.Join(ingin[aset.Simbol], ",") {
+			t.Errorf("alternatif %s = %v, ingin %v", aset.Simbol, got, ingin[aset.Simbol])
+		}
+	}
+	if laporan.NilaiPatuh != uangUji(t, "1000") || laporan.NilaiDiragukan != uangUji(t, "1000") {
+		t.Errorf("nilai patuh %s diragukan %s, ingin 1000 dan 1000", laporan.NilaiPatuh, laporan.NilaiDiragukan)
+	}
+}
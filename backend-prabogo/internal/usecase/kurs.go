@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+type KursUsecase struct {
+	repo     domain.KursRepository
+	penyedia domain.PenyediaKurs
+	dasar    string
+	mataUang []string
+}
+
+func NewKursUsecase(repo domain.KursRepository, penyedia domain.PenyediaKurs, dasar string, mataUang []string) *KursUsecase {
+	return &KursUsecase{
+		repo:     repo,
+		penyedia: penyedia,
+		dasar:    domain.NormalisasiMataUang(dasar, domain.MataUangUSD),
+		mataUang: mataUang,
+	}
+}
+
+func (u *KursUsecase) Daftar(ctx context.Context) ([]domain.Kurs, error) {
+	return u.repo.DaftarKursTerbaru(ctx)
+}
+
+func (u *KursUsecase) Simpan(ctx context.Context, kurs *domain.Kurs) error {
+	kurs.MataUangAsal = domain.NormalisasiMataUang(kurs.MataUangAsal, "")
+	kurs.MataUangTujuan = domain.NormalisasiMataUang(kurs.MataUangTujuan, "")
+	if kurs.MataUangAsal == "" || kurs.MataUangTujuan == "" {
+		return errors.New("kode mata uang tidak valid")
+	}
+	if kurs.MataUangAsal == kurs.MataUangTujuan {
+		return errors.New("mata uang asal dan tujuan tidak boleh sama")
+	}
+	if kurs.Nilai <= 0 {
+		return errors.New("nilai kurs harus lebih dari nol")
+	}
+	if kurs.Sumber == "" {
+		kurs.Sumber = "manual"
+	}
+	if kurs.Tanggal.IsZero() {
+		kurs.Tanggal = time.Now()
+	}
+	return u.repo.SimpanKurs(ctx, kurs)
+}
+
+// Sinkronkan mengambil kurs terbaru dari penyedia dan menyimpan pasangan
+// dasar→mata uang yang dikonfigurasi. Mengembalikan jumlah kurs yang disimpan.
+func (u *KursUsecase) Sinkronkan(ctx context.Context) (int, error) {
+	if u.penyedia == nil {
+		return 0, errors.New("penyedia kurs belum dikonfigurasi")
+	}
+	rates, err := u.penyedia.AmbilKurs(ctx, u.dasar)
+	if err != nil {
+		return 0, err
+	}
+
+	tersimpan := 0
+	sekarang := time.Now()
+	for _, kode := range u.mataUang {
+		kode = domain.NormalisasiMataUang(kode, "")
+		if kode == "" || kode == u.dasar {
+			continue
+		}
+		nilai, ok := rates[kode]
+		if !ok || nilai <= 0 {
+			continue
+		}
+		kurs := &domain.Kurs{
+			MataUangAsal:   u.dasar,
+			MataUangTujuan: kode,
+			Nilai:          nilai,
+			Sumber:         u.penyedia.Nama(),
+			Tanggal:        sekarang,
+		}
+		if err := u.repo.SimpanKurs(ctx, kurs); err != nil {
+			return tersimpan, err
+		}
+		tersimpan++
+	}
+	return tersimpan, nil
+}
+
+// Konverter memuat semua kurs terbaru sekali sehingga konversi berikutnya tidak
+// menyentuh database.
+func (u *KursUsecase) Konverter(ctx context.Context) (*KonverterKurs, error) {
+	daftar, err := u.repo.DaftarKursTerbaru(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.konverter(daftar), nil
+}
+
+// KonverterPada memuat kurs yang berlaku pada tanggal tertentu, yaitu kurs
+// terakhir yang tercatat pada atau sebelum tanggal tersebut.
+func (u *KursUsecase) KonverterPada(ctx context.Context, tanggal time.Time) (*KonverterKurs, error) {
+	daftar, err := u.repo.DaftarKursPada(ctx, tanggal)
+	if err != nil {
+		return nil, err
+	}
+	return u.konverter(daftar), nil
+}
+
+func (u *KursUsecase) konverter(daftar []domain.Kurs) *KonverterKurs {
+	k := &KonverterKurs{dasar: u.dasar, kurs: make(map[[2]string]float64, len(daftar))}
+	for _, item := range daftar {
+		k.kurs[[2]string{item.MataUangAsal, item.MataUangTujuan}] = item.Nilai
+	}
+	return k
+}
+
+type KonverterKurs struct {
+	dasar string
+	kurs  map[[2]string]float64
+}
+
+// Konversi mengubah nilai dari satu mata uang ke mata uang lain memakai kurs
+// langsung, kebalikannya, atau kurs silang melalui mata uang dasar.
+func (k *KonverterKurs) Konversi(nilai domain.Uang, dari, ke string) (domain.Uang, error) {
+	if dari == ke {
+		return nilai, nil
+	}
+	if _, langsung := k.kurs[[2]string{dari, ke}]; !langsung {
+		// Bagi dengan kurs kebalikan agar tidak membawa galat dari 1/kurs.
+		if faktor, ok := k.kurs[[2]string{ke, dari}]; ok && faktor > 0 {
+			return nilai.Bagi(faktor)
+		}
+	}
+	faktor, err := k.Faktor(dari, ke)
+	if err != nil {
+		return 0, err
+	}
+	return nilai.Kali(faktor)
+}
+
+// KonversiHarga sama dengan Konversi untuk harga per unit sehingga skala harga
+// yang lebih halus tetap terjaga.
+func (k *KonverterKurs) KonversiHarga(harga domain.Harga, dari, ke string) (domain.Harga, error) {
+	if dari == ke {
+		return harga, nil
+	}
+	if _, langsung := k.kurs[[2]string{dari, ke}]; !langsung {
+		if faktor, ok := k.kurs[[2]string{ke, dari}]; ok && faktor > 0 {
+			return harga.BagiFaktor(faktor)
+		}
+	}
+	faktor, err := k.Faktor(dari, ke)
+	if err != nil {
+		return 0, err
+	}
+	return harga.KaliFaktor(faktor)
+}
+
+func (k *KonverterKurs) Faktor(dari, ke string) (float64, error) {
+	if dari == ke {
+		return 1, nil
+	}
+	if nilai, ok := k.kurs[[2]string{dari, ke}]; ok {
+		return nilai, nil
+	}
+	if nilai, ok := k.kurs[[2]string{ke, dari}]; ok && nilai > 0 {
+		return 1 / nilai, nil
+	}
+	dasarKeDari, ok1 := k.kurs[[2]string{k.dasar, dari}]
+	dasarKeKe, ok2 := k.kurs[[2]string{k.dasar, ke}]
+	if dari == k.dasar {
+		dasarKeDari, ok1 = 1, true
+	}
+	if ke == k.dasar {
+		dasarKeKe, ok2 = 1, true
+	}
+	if ok1 && ok2 && dasarKeDari > 0 {
+		return dasarKeKe / dasarKeDari, nil
+	}
+	return 0, fmt.Errorf("kurs %s ke %s tidak tersedia", dari, ke)
+}
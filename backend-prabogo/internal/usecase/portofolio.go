@@ -2,32 +2,389 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/averroes/backend-prabogo/internal/domain"
 )
 
 type PortofolioUsecase struct {
-	repo domain.PortofolioRepository
+	repo           domain.PortofolioRepository
+	penggunaRepo   domain.AuthRepository
+	kurs           *KursUsecase
+	batasUsiaHarga time.Duration
 }
 
-func NewPortofolioUsecase(repo domain.PortofolioRepository) *PortofolioUsecase {
-	return &PortofolioUsecase{repo: repo}
+func NewPortofolioUsecase(repo domain.PortofolioRepository, penggunaRepo domain.AuthRepository, kurs *KursUsecase, batasUsiaHarga time.Duration) *PortofolioUsecase {
+	if batasUsiaHarga <= 0 {
+		batasUsiaHarga = 24 * time.Hour
+	}
+	return &PortofolioUsecase{repo: repo, penggunaRepo: penggunaRepo, kurs: kurs, batasUsiaHarga: batasUsiaHarga}
 }
 
-func (u *PortofolioUsecase) Daftar(ctx context.Context, idPengguna int64) ([]domain.Portofolio, error) {
-	return u.repo.DaftarPortofolio(ctx, idPengguna)
+// MataUangTampilan memilih mata uang tampilan: pilihan eksplisit dari permintaan,
+// lalu preferensi pengguna, lalu IDR.
+func (u *PortofolioUsecase) MataUangTampilan(ctx context.Context, idPengguna int64, pilihan string) (string, error) {
+	if kode := domain.NormalisasiMataUang(pilihan, ""); kode != "" {
+		return kode, nil
+	}
+	pengguna, err := u.penggunaRepo.AmbilPenggunaByID(ctx, idPengguna)
+	if err != nil {
+		return "", err
+	}
+	if pengguna == nil {
+		return domain.MataUangIDR, nil
+	}
+	return domain.NormalisasiMataUang(pengguna.MataUang, domain.MataUangIDR), nil
 }
 
-func (u *PortofolioUsecase) Tambah(ctx context.Context, portofolio *domain.Portofolio) error {
-	portofolio.DibuatPada = time.Now()
-	return u.repo.SimpanPortofolio(ctx, portofolio)
+// Posisi menurunkan semua posisi pengguna dari buku besar transaksi dan
+// menilainya dengan harga terbaru, dalam mata uang asal masing-masing.
+func (u *PortofolioUsecase) Posisi(ctx context.Context, idPengguna int64, metode string) ([]domain.Portofolio, error) {
+	transaksi, err := u.repo.DaftarTransaksiPortofolio(ctx, idPengguna, "")
+	if err != nil {
+		return nil, err
+	}
+	posisi, err := hitungPosisi(transaksi, metode)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.tetapkanIDPosisi(ctx, idPengguna, posisi); err != nil {
+		return nil, err
+	}
+	if err := u.nilaikan(ctx, idPengguna, posisi); err != nil {
+		return nil, err
+	}
+	return posisi, nil
 }
 
-func (u *PortofolioUsecase) Perbarui(ctx context.Context, portofolio *domain.Portofolio) error {
-	return u.repo.PerbaruiPortofolio(ctx, portofolio)
+func (u *PortofolioUsecase) Daftar(ctx context.Context, idPengguna int64, mataUang string, metode string, termasukTutup bool) ([]domain.Portofolio, error) {
+	tampilan, err := u.MataUangTampilan(ctx, idPengguna, mataUang)
+	if err != nil {
+		return nil, err
+	}
+	posisi, err := u.Posisi(ctx, idPengguna, metode)
+	if err != nil {
+		return nil, err
+	}
+	konverter, err := u.kurs.Konverter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]domain.Portofolio, 0, len(posisi))
+	for _, item := range posisi {
+		if item.Jumlah == 0 && !termasukTutup {
+			continue
+		}
+		item.MataUang = domain.NormalisasiMataUang(item.MataUang, domain.MataUangIDR)
+		// Posisi tanpa kurs ke mata uang tampilan tetap ditampilkan dengan nilai
+		// aslinya dan ditandai, alih-alih menggagalkan seluruh daftar.
+		nilai, err := konverter.Konversi(item.NilaiSaatIni, item.MataUang, tampilan)
+		if err != nil {
+			item.KursTidakTersedia = true
+			nilai = 0
+		}
+		item.NilaiTampilan = nilai.Bulatkan(tampilan)
+		item.MataUangTampilan = tampilan
+		items = append(items, item)
+	}
+	return items, nil
 }
 
+// Tambah mencatat pembelian awal dari payload portofolio lama dan mengembalikan posisi hasilnya.
+func (u *PortofolioUsecase) Tambah(ctx context.Context, portofolio *domain.Portofolio) (*domain.Portofolio, error) {
+	transaksi := &domain.TransaksiPortofolio{
+		IDPengguna: portofolio.IDPengguna,
+		Jenis:      domain.TransaksiBeli,
+		NamaAset:   portofolio.NamaAset,
+		Simbol:     portofolio.Simbol,
+		Kategori:   portofolio.Kategori,
+		Jumlah:     portofolio.Jumlah,
+		Harga:      portofolio.HargaBeli,
+		MataUang:   portofolio.MataUang,
+	}
+	if err := u.TambahTransaksi(ctx, transaksi); err != nil {
+		return nil, err
+	}
+	return u.cariPosisi(ctx, transaksi.IDPengguna, func(p domain.Portofolio) bool {
+		return p.Simbol == transaksi.Simbol && p.MataUang == transaksi.MataUang
+	})
+}
+
+// Perbarui mengubah nama aset dan kategori sebuah posisi. Perubahan jumlah
+// tidak menimpa riwayat, melainkan dicatat sebagai transfer penyesuaian.
+func (u *PortofolioUsecase) Perbarui(ctx context.Context, portofolio *domain.Portofolio) (*domain.Portofolio, error) {
+	posisi, err := u.cariPosisiID(ctx, portofolio.IDPengguna, portofolio.ID)
+	if err != nil {
+		return nil, err
+	}
+	if posisi == nil {
+		return nil, errors.New("portofolio tidak ditemukan")
+	}
+
+	namaAset := strings.TrimSpace(portofolio.NamaAset)
+	if namaAset == "" {
+		namaAset = posisi.NamaAset
+	}
+	kategori := strings.TrimSpace(portofolio.Kategori)
+	if kategori == "" {
+		kategori = posisi.Kategori
+	}
+	if err := u.repo.PerbaruiAsetPortofolio(ctx, posisi.IDPengguna, posisi.Simbol, posisi.MataUang, namaAset, kategori); err != nil {
+		return nil, err
+	}
+
+	if selisih := portofolio.Jumlah - posisi.Jumlah; portofolio.Jumlah > 0 && math.Abs(selisih) > toleransiJumlah {
+		penyesuaian := &domain.TransaksiPortofolio{
+			IDPengguna: posisi.IDPengguna,
+			Jenis:      domain.TransaksiTransferMasuk,
+			NamaAset:   namaAset,
+			Simbol:     posisi.Simbol,
+			Kategori:   kategori,
+			Jumlah:     selisih,
+			Harga:      portofolio.HargaBeli,
+			MataUang:   posisi.MataUang,
+			Catatan:    "Penyesuaian jumlah",
+		}
+		if penyesuaian.Harga <= 0 {
+			penyesuaian.Harga = posisi.HargaBeli
+		}
+		if selisih < 0 {
+			penyesuaian.Jenis = domain.TransaksiTransferKeluar
+			penyesuaian.Jumlah = -selisih
+		}
+		if err := u.TambahTransaksi(ctx, penyesuaian); err != nil {
+			return nil, err
+		}
+	}
+
+	// Payload lama masih mengirim nilai_saat_ini; nilai itu hanya dipakai
+	// sebagai penilaian manual bila aset tidak punya harga pasar.
+	if portofolio.NilaiSaatIni > 0 {
+		ada, err := u.punyaHargaPasar(ctx, posisi.Simbol)
+		if err != nil {
+			return nil, err
+		}
+		if !ada {
+			if _, err := u.AturNilaiManual(ctx, posisi.IDPengguna, posisi.ID, 0, portofolio.NilaiSaatIni, ""); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return u.cariPosisiID(ctx, portofolio.IDPengguna, portofolio.ID)
+}
+
+// Hapus menghapus seluruh transaksi sebuah posisi.
 func (u *PortofolioUsecase) Hapus(ctx context.Context, id int64, idPengguna int64) error {
-	return u.repo.HapusPortofolio(ctx, id, idPengguna)
+	posisi, err := u.cariPosisiID(ctx, idPengguna, id)
+	if err != nil || posisi == nil {
+		return err
+	}
+	if err := u.repo.HapusNilaiManual(ctx, idPengguna, posisi.Simbol, posisi.MataUang); err != nil {
+		return err
+	}
+	return u.repo.HapusPosisiPortofolio(ctx, idPengguna, posisi.Simbol, posisi.MataUang)
+}
+
+func (u *PortofolioUsecase) DaftarTransaksi(ctx context.Context, idPengguna int64, simbol string) ([]domain.TransaksiPortofolio, error) {
+	return u.repo.DaftarTransaksiPortofolio(ctx, idPengguna, strings.ToUpper(strings.TrimSpace(simbol)))
+}
+
+func (u *PortofolioUsecase) TambahTransaksi(ctx context.Context, transaksi *domain.TransaksiPortofolio) error {
+	if err := normalisasiTransaksi(transaksi); err != nil {
+		return err
+	}
+	daftar, err := u.repo.DaftarTransaksiPortofolio(ctx, transaksi.IDPengguna, transaksi.Simbol)
+	if err != nil {
+		return err
+	}
+	if err := validasiTransaksiBaru(transaksi, daftar); err != nil {
+		return err
+	}
+	transaksi.DibuatPada = time.Now()
+	return u.repo.SimpanTransaksiPortofolio(ctx, transaksi)
+}
+
+// validasiTransaksiBaru melengkapi nama dan kategori aset dari transaksi
+// sebelumnya lalu memastikan buku besar simbol tersebut tetap valid setelah
+// transaksi ditambahkan. Transaksi harus sudah dinormalisasi.
+func validasiTransaksiBaru(transaksi *domain.TransaksiPortofolio, daftar []domain.TransaksiPortofolio) error {
+	for i := len(daftar) - 1; i >= 0; i-- {
+		if daftar[i].MataUang != transaksi.MataUang {
+			continue
+		}
+		if transaksi.NamaAset == "" {
+			transaksi.NamaAset = daftar[i].NamaAset
+		}
+		if transaksi.Kategori == "" {
+			transaksi.Kategori = daftar[i].Kategori
+		}
+		break
+	}
+	if transaksi.NamaAset == "" {
+		transaksi.NamaAset = transaksi.Simbol
+	}
+
+	// Transaksi baru belum punya ID; beri ID terbesar agar urut paling akhir
+	// di antara transaksi bertanggal sama, seperti setelah tersimpan.
+	baru := *transaksi
+	baru.ID = math.MaxInt64
+	_, err := hitungPosisi(append(daftar, baru), domain.MetodeFIFO)
+	return err
+}
+
+func (u *PortofolioUsecase) PerbaruiTransaksi(ctx context.Context, transaksi *domain.TransaksiPortofolio) error {
+	lama, err := u.repo.AmbilTransaksiPortofolio(ctx, transaksi.ID, transaksi.IDPengguna)
+	if err != nil {
+		return err
+	}
+	if lama == nil {
+		return errors.New("transaksi tidak ditemukan")
+	}
+	if err := normalisasiTransaksi(transaksi); err != nil {
+		return err
+	}
+	if transaksi.NamaAset == "" {
+		transaksi.NamaAset = lama.NamaAset
+	}
+	if transaksi.Kategori == "" {
+		transaksi.Kategori = lama.Kategori
+	}
+	transaksi.DibuatPada = lama.DibuatPada
+
+	// Periksa buku besar simbol lama (tanpa transaksi ini) dan simbol baru
+	// (dengan transaksi ini) agar tidak ada penjualan yang melebihi kepemilikan.
+	for _, simbol := range []string{lama.Simbol, transaksi.Simbol} {
+		daftar, err := u.repo.DaftarTransaksiPortofolio(ctx, transaksi.IDPengguna, simbol)
+		if err != nil {
+			return err
+		}
+		var hasil []domain.TransaksiPortofolio
+		for _, t := range daftar {
+			if t.ID != transaksi.ID {
+				hasil = append(hasil, t)
+			}
+		}
+		if simbol == transaksi.Simbol {
+			hasil = append(hasil, *transaksi)
+		}
+		if _, err := hitungPosisi(hasil, domain.MetodeFIFO); err != nil {
+			return err
+		}
+	}
+	return u.repo.PerbaruiTransaksiPortofolio(ctx, transaksi)
+}
+
+func (u *PortofolioUsecase) HapusTransaksi(ctx context.Context, id int64, idPengguna int64) error {
+	lama, err := u.repo.AmbilTransaksiPortofolio(ctx, id, idPengguna)
+	if err != nil || lama == nil {
+		return err
+	}
+	daftar, err := u.repo.DaftarTransaksiPortofolio(ctx, idPengguna, lama.Simbol)
+	if err != nil {
+		return err
+	}
+	var sisa []domain.TransaksiPortofolio
+	for _, t := range daftar {
+		if t.ID != id {
+			sisa = append(sisa, t)
+		}
+	}
+	if _, err := hitungPosisi(sisa, domain.MetodeFIFO); err != nil {
+		return fmt.Errorf("transaksi tidak dapat dihapus: %w", err)
+	}
+	return u.repo.HapusTransaksiPortofolio(ctx, id, idPengguna)
+}
+
+// tetapkanIDPosisi memberi setiap posisi ID stabil dari tabel kunci posisi
+// sehingga ID tidak berubah ketika transaksi pertamanya dihapus. Posisi yang
+// belum terdaftar didaftarkan saat pertama kali diturunkan.
+func (u *PortofolioUsecase) tetapkanIDPosisi(ctx context.Context, idPengguna int64, posisi []domain.Portofolio) error {
+	daftar, err := u.repo.DaftarKunciPosisiPortofolio(ctx, idPengguna)
+	if err != nil {
+		return err
+	}
+	ids := idPosisi(daftar)
+	for i := range posisi {
+		kunci := kunciPosisi(posisi[i].Simbol, posisi[i].MataUang)
+		if id, ok := ids[kunci]; ok {
+			posisi[i].ID = id
+			continue
+		}
+		baru := &domain.KunciPosisiPortofolio{
+			IDPengguna: idPengguna,
+			Simbol:     strings.ToUpper(posisi[i].Simbol),
+			MataUang:   posisi[i].MataUang,
+			DibuatPada: time.Now(),
+		}
+		if err := u.repo.SimpanKunciPosisiPortofolio(ctx, baru); err != nil {
+			return err
+		}
+		ids[kunci] = baru.ID
+		posisi[i].ID = baru.ID
+	}
+	return nil
+}
+
+// cariPosisiID mencari posisi dari ID mana pun yang terikat padanya, termasuk
+// ID baris portofolio lama yang kini tergabung dalam posisi yang sama.
+func (u *PortofolioUsecase) cariPosisiID(ctx context.Context, idPengguna, id int64) (*domain.Portofolio, error) {
+	daftar, err := u.repo.DaftarKunciPosisiPortofolio(ctx, idPengguna)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range daftar {
+		if k.ID != id {
+			continue
+		}
+		kunci := kunciPosisi(k.Simbol, k.MataUang)
+		return u.cariPosisi(ctx, idPengguna, func(p domain.Portofolio) bool {
+			return kunciPosisi(p.Simbol, p.MataUang) == kunci
+		})
+	}
+	return nil, nil
+}
+
+func (u *PortofolioUsecase) cariPosisi(ctx context.Context, idPengguna int64, cocok func(domain.Portofolio) bool) (*domain.Portofolio, error) {
+	posisi, err := u.Posisi(ctx, idPengguna, domain.MetodeFIFO)
+	if err != nil {
+		return nil, err
+	}
+	for i := range posisi {
+		if cocok(posisi[i]) {
+			return &posisi[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func normalisasiTransaksi(transaksi *domain.TransaksiPortofolio) error {
+	transaksi.Jenis = strings.ToLower(strings.TrimSpace(transaksi.Jenis))
+	if !domain.JenisTransaksiValid(transaksi.Jenis) {
+		return errors.New("jenis transaksi harus beli, jual, dividen, transfer_masuk atau transfer_keluar")
+	}
+	transaksi.Simbol = strings.ToUpper(strings.TrimSpace(transaksi.Simbol))
+	if transaksi.Simbol == "" {
+		return errors.New("simbol wajib diisi")
+	}
+	transaksi.NamaAset = strings.TrimSpace(transaksi.NamaAset)
+	transaksi.Kategori = strings.TrimSpace(transaksi.Kategori)
+	transaksi.MataUang = domain.NormalisasiMataUang(transaksi.MataUang, domain.MataUangIDR)
+	if transaksi.Jumlah < 0 || transaksi.Harga < 0 || transaksi.Biaya < 0 {
+		return errors.New("jumlah, harga dan biaya tidak boleh negatif")
+	}
+	if transaksi.Jenis == domain.TransaksiDividen {
+		if transaksi.Harga == 0 {
+			return errors.New("nilai dividen wajib diisi pada kolom harga")
+		}
+	} else if transaksi.Jumlah == 0 {
+		return errors.New("jumlah transaksi wajib lebih dari nol")
+	}
+	if transaksi.Tanggal.IsZero() {
+		transaksi.Tanggal = time.Now()
+	}
+	return nil
 }
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+// simbolEmas adalah simbol kepemilikan emas yang dinilai per gram dari harga_emas.
+var simbolEmas = map[string]bool{"EMAS": true, "XAU": true}
+
+type acuanHarga struct {
+	pasar map[string]domain.Pasar
+	emas  *domain.HargaEmas
+}
+
+type hargaAcuan struct {
+	harga    domain.Harga
+	mataUang string
+	waktu    time.Time
+	sumber   string
+}
+
+func (u *PortofolioUsecase) muatAcuanHarga(ctx context.Context) (*acuanHarga, error) {
+	daftar, err := u.repo.DaftarPasar(ctx)
+	if err != nil {
+		return nil, err
+	}
+	emas, err := u.repo.HargaEmasTerbaru(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a := &acuanHarga{pasar: make(map[string]domain.Pasar, len(daftar)), emas: emas}
+	for _, p := range daftar {
+		a.pasar[strings.ToUpper(p.Simbol)] = p
+	}
+	return a, nil
+}
+
+func (a *acuanHarga) cari(simbol string) (hargaAcuan, bool) {
+	simbol = strings.ToUpper(simbol)
+	if p, ok := a.pasar[simbol]; ok && p.Harga > 0 {
+		return hargaAcuan{
+			harga:    p.Harga,
+			mataUang: domain.NormalisasiMataUang(p.MataUang, domain.MataUangUSD),
+			waktu:    p.DiperbaruiPada,
+			sumber:   domain.SumberHargaPasar,
+		}, true
+	}
+	if simbolEmas[simbol] && a.emas != nil && a.emas.HargaPerGram > 0 {
+		// harga_emas hanya bertanggal, jadi dianggap berlaku sampai akhir hari itu.
+		return hargaAcuan{
+			harga:    a.emas.HargaPerGram.Harga(),
+			mataUang: domain.NormalisasiMataUang(a.emas.MataUang, domain.MataUangIDR),
+			waktu:    a.emas.Tanggal.Add(24 * time.Hour),
+			sumber:   domain.SumberHargaEmas,
+		}, true
+	}
+	return hargaAcuan{}, false
+}
+
+func (u *PortofolioUsecase) punyaHargaPasar(ctx context.Context, simbol string) (bool, error) {
+	acuan, err := u.muatAcuanHarga(ctx)
+	if err != nil {
+		return false, err
+	}
+	_, ada := acuan.cari(simbol)
+	return ada, nil
+}
+
+// nilaikan mengisi harga dan nilai posisi dari harga pasar atau harga emas
+// terbaru. Penilaian manual hanya dipakai bila simbol tidak punya harga pasar;
+// tanpa keduanya harga transaksi terakhir dipakai dan ditandai tidak tersedia.
+func (u *PortofolioUsecase) nilaikan(ctx context.Context, idPengguna int64, posisi []domain.Portofolio) error {
+	if len(posisi) == 0 {
+		return nil
+	}
+	acuan, err := u.muatAcuanHarga(ctx)
+	if err != nil {
+		return err
+	}
+	daftarManual, err := u.repo.DaftarNilaiManual(ctx, idPengguna)
+	if err != nil {
+		return err
+	}
+	manual := make(map[string]domain.NilaiManualPortofolio, len(daftarManual))
+	for _, m := range daftarManual {
+		manual[kunciPosisi(m.Simbol, m.MataUang)] = m
+	}
+	konverter, err := u.kurs.Konverter(ctx)
+	if err != nil {
+		return err
+	}
+
+	sekarang := time.Now()
+	for i := range posisi {
+		p := &posisi[i]
+		p.SumberHarga = domain.SumberHargaTransaksi
+		p.StatusHarga = domain.StatusHargaTidakTersedia
+		p.HargaDiperbaruiPada = nil
+
+		if h, ok := acuan.cari(p.Simbol); ok {
+			if harga, err := konverter.KonversiHarga(h.harga, h.mataUang, p.MataUang); err == nil {
+				waktu := h.waktu
+				p.HargaTerakhir = harga
+				p.SumberHarga = h.sumber
+				p.StatusHarga = domain.StatusHargaTerkini
+				if sekarang.Sub(waktu) > u.batasUsiaHarga {
+					p.StatusHarga = domain.StatusHargaUsang
+				}
+				p.HargaDiperbaruiPada = &waktu
+			}
+		} else if m, ok := manual[kunciPosisi(p.Simbol, p.MataUang)]; ok {
+			waktu := m.DiperbaruiPada
+			p.HargaTerakhir = m.Harga
+			p.SumberHarga = domain.SumberHargaManual
+			p.StatusHarga = domain.StatusHargaManual
+			p.HargaDiperbaruiPada = &waktu
+		}
+
+		if p.Jumlah > 0 {
+			if p.NilaiSaatIni, err = p.HargaTerakhir.Kali(p.Jumlah); err != nil {
+				return fmt.Errorf("nilai %s: %w", p.Simbol, err)
+			}
+			p.LabaBelumRealisasi = p.NilaiSaatIni - p.TotalModal
+		}
+	}
+	return nil
+}
+
+// AturNilaiManual menyimpan penilaian manual per unit untuk aset tanpa harga
+// pasar, misalnya properti. Bila harga kosong, nilai total dibagi jumlah unit.
+func (u *PortofolioUsecase) AturNilaiManual(ctx context.Context, idPengguna int64, id int64, harga domain.Harga, nilaiTotal domain.Uang, catatan string) (*domain.Portofolio, error) {
+	posisi, err := u.cariPosisiID(ctx, idPengguna, id)
+	if err != nil {
+		return nil, err
+	}
+	if posisi == nil {
+		return nil, errors.New("portofolio tidak ditemukan")
+	}
+	if posisi.SumberHarga == domain.SumberHargaPasar || posisi.SumberHarga == domain.SumberHargaEmas {
+		return nil, errors.New("aset ini dinilai otomatis dari harga pasar")
+	}
+	if harga <= 0 {
+		if nilaiTotal <= 0 || posisi.Jumlah <= 0 {
+			return nil, errors.New("harga atau nilai_saat_ini wajib lebih dari nol")
+		}
+		if harga, err = nilaiTotal.PerUnit(posisi.Jumlah); err != nil {
+			return nil, err
+		}
+	}
+	nilai := &domain.NilaiManualPortofolio{
+		IDPengguna:     idPengguna,
+		Simbol:         posisi.Simbol,
+		MataUang:       posisi.MataUang,
+		Harga:          harga,
+		Catatan:        strings.TrimSpace(catatan),
+		DiperbaruiPada: time.Now(),
+	}
+	if err := u.repo.SimpanNilaiManual(ctx, nilai); err != nil {
+		return nil, err
+	}
+	return u.cariPosisiID(ctx, idPengguna, id)
+}
+
+func (u *PortofolioUsecase) HapusNilaiManual(ctx context.Context, idPengguna int64, id int64) error {
+	posisi, err := u.cariPosisiID(ctx, idPengguna, id)
+	if err != nil || posisi == nil {
+		return err
+	}
+	return u.repo.HapusNilaiManual(ctx, idPengguna, posisi.Simbol, posisi.MataUang)
+}
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+// toleransiJumlah menyerap galat float64 pada jumlah unit kripto berpresisi 8 digit.
+const toleransiJumlah = 1e-9
+
+type lotPosisi struct {
+	jumlah float64
+	modal  domain.Uang
+}
+
+type akumulasiPosisi struct {
+	posisi domain.Portofolio
+	lot    []lotPosisi
+}
+
+func kunciPosisi(simbol, mataUang string) string {
+	return strings.ToUpper(simbol) + "|" + mataUang
+}
+
+// idPosisi memetakan kunci posisi ke ID terkecil yang terikat padanya.
+func idPosisi(daftar []domain.KunciPosisiPortofolio) map[string]int64 {
+	hasil := make(map[string]int64, len(daftar))
+	for _, k := range daftar {
+		kunci := kunciPosisi(k.Simbol, k.MataUang)
+		if id, ok := hasil[kunci]; !ok || k.ID < id {
+			hasil[kunci] = k.ID
+		}
+	}
+	return hasil
+}
+
+func normalisasiMetode(metode string) string {
+	if metode == domain.MetodeRataRata {
+		return domain.MetodeRataRata
+	}
+	return domain.MetodeFIFO
+}
+
+// hitungPosisi menurunkan kepemilikan dari buku besar transaksi. Posisi
+// diidentifikasi oleh simbol dan mata uang; ID-nya diberikan terpisah oleh
+// idPosisi. Penjualan melebihi kepemilikan pada tanggalnya menghasilkan error.
+func hitungPosisi(daftar []domain.TransaksiPortofolio, metode string) ([]domain.Portofolio, error) {
+	metode = normalisasiMetode(metode)
+	transaksi := append([]domain.TransaksiPortofolio(nil), daftar...)
+	sort.SliceStable(transaksi, func(i, j int) bool {
+		if transaksi[i].Tanggal.Equal(transaksi[j].Tanggal) {
+			return transaksi[i].ID < transaksi[j].ID
+		}
+		return transaksi[i].Tanggal.Before(transaksi[j].Tanggal)
+	})
+
+	var urutan []string
+	akumulasi := map[string]*akumulasiPosisi{}
+	for _, t := range transaksi {
+		kunci := kunciPosisi(t.Simbol, t.MataUang)
+		a, ok := akumulasi[kunci]
+		if !ok {
+			a = &akumulasiPosisi{posisi: domain.Portofolio{
+				IDPengguna: t.IDPengguna,
+				Simbol:     t.Simbol,
+				MataUang:   t.MataUang,
+				DibuatPada: t.Tanggal,
+				Metode:     metode,
+			}}
+			akumulasi[kunci] = a
+			urutan = append(urutan, kunci)
+		}
+		a.posisi.NamaAset = t.NamaAset
+		a.posisi.Kategori = t.Kategori
+		a.posisi.JumlahTransaksi++
+
+		switch t.Jenis {
+		case domain.TransaksiBeli, domain.TransaksiTransferMasuk:
+			nilai, err := nilaiTransaksi(t)
+			if err != nil {
+				return nil, err
+			}
+			a.tambah(t.Jumlah, nilai+t.Biaya, metode)
+		case domain.TransaksiJual, domain.TransaksiTransferKeluar:
+			if t.Jumlah > a.jumlah()+toleransiJumlah {
+				return nil, fmt.Errorf("jumlah %s yang keluar pada %s melebihi kepemilikan", t.Simbol, t.Tanggal.Format("2006-01-02"))
+			}
+			modalKeluar, err := a.ambil(t.Jumlah)
+			if err != nil {
+				return nil, fmt.Errorf("modal %s yang keluar pada %s: %w", t.Simbol, t.Tanggal.Format("2006-01-02"), err)
+			}
+			if t.Jenis == domain.TransaksiJual {
+				nilai, err := nilaiTransaksi(t)
+				if err != nil {
+					return nil, err
+				}
+				a.posisi.LabaRealisasi += nilai - t.Biaya - modalKeluar
+			} else {
+				a.posisi.LabaRealisasi -= t.Biaya
+			}
+		case domain.TransaksiDividen:
+			total := t.Harga.Uang()
+			if t.Jumlah > 0 {
+				var err error
+				if total, err = nilaiTransaksi(t); err != nil {
+					return nil, err
+				}
+			}
+			a.posisi.Dividen += total - t.Biaya
+		}
+		if t.Jenis != domain.TransaksiDividen && t.Harga > 0 {
+			a.posisi.HargaTerakhir = t.Harga
+		}
+	}
+
+	hasil := make([]domain.Portofolio, 0, len(urutan))
+	for _, kunci := range urutan {
+		a := akumulasi[kunci]
+		p := a.posisi
+		p.Jumlah = a.jumlah()
+		if p.Jumlah > toleransiJumlah {
+			for _, l := range a.lot {
+				p.TotalModal += l.modal
+			}
+			var err error
+			if p.HargaBeli, err = p.TotalModal.PerUnit(p.Jumlah); err != nil {
+				return nil, fmt.Errorf("harga beli %s: %w", p.Simbol, err)
+			}
+			if p.NilaiSaatIni, err = p.HargaTerakhir.Kali(p.Jumlah); err != nil {
+				return nil, fmt.Errorf("nilai %s: %w", p.Simbol, err)
+			}
+			p.LabaBelumRealisasi = p.NilaiSaatIni - p.TotalModal
+		} else {
+			p.Jumlah = 0
+		}
+		hasil = append(hasil, p)
+	}
+	sort.SliceStable(hasil, func(i, j int) bool {
+		return hasil[i].DibuatPada.After(hasil[j].DibuatPada)
+	})
+	return hasil, nil
+}
+
+// nilaiTransaksi adalah harga dikali jumlah unit transaksi.
+func nilaiTransaksi(t domain.TransaksiPortofolio) (domain.Uang, error) {
+	nilai, err := t.Harga.Kali(t.Jumlah)
+	if err != nil {
+		return 0, fmt.Errorf("nilai transaksi %s pada %s: %w", t.Simbol, t.Tanggal.Format("2006-01-02"), err)
+	}
+	return nilai, nil
+}
+
+func (a *akumulasiPosisi) jumlah() float64 {
+	var total float64
+	for _, l := range a.lot {
+		total += l.jumlah
+	}
+	return total
+}
+
+// tambah mencatat lot baru; pada metode rata-rata semua lot dilebur menjadi satu.
+func (a *akumulasiPosisi) tambah(jumlah float64, modal domain.Uang, metode string) {
+	if metode == domain.MetodeRataRata && len(a.lot) > 0 {
+		a.lot[0].jumlah += jumlah
+		a.lot[0].modal += modal
+		return
+	}
+	a.lot = append(a.lot, lotPosisi{jumlah: jumlah, modal: modal})
+}
+
+// ambil mengeluarkan unit dari lot terlama dan mengembalikan harga pokoknya.
+func (a *akumulasiPosisi) ambil(jumlah float64) (domain.Uang, error) {
+	var modal domain.Uang
+	for jumlah > toleransiJumlah && len(a.lot) > 0 {
+		l := &a.lot[0]
+		if jumlah >= l.jumlah-toleransiJumlah {
+			modal += l.modal
+			jumlah -= l.jumlah
+			a.lot = a.lot[1:]
+			continue
+		}
+		bagian, err := l.modal.Kali(jumlah / l.jumlah)
+		if err != nil {
+			return 0, err
+		}
+		modal += bagian
+		l.modal -= bagian
+		l.jumlah -= jumlah
+		jumlah = 0
+	}
+	return modal, nil
+}
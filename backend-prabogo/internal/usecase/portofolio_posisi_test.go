@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func uangUji(t *testing.T, s string) domain.Uang {
+	t.Helper()
+	u, err := domain.UangDariString(s)
+	if err != nil {
+		t.Fatalf("UangDariString(%q): %v", s, err)
+	}
+	return u
+}
+
+func hargaUji(t *testing.T, s string) domain.Harga {
+	t.Helper()
+	h, err := domain.HargaDariString(s)
+	if err != nil {
+		t.Fatalf("HargaDariString(%q): %v", s, err)
+	}
+	return h
+}
+
+func transaksiUji(t *testing.T, id int64, hari int, jenis string, jumlah float64, harga, biaya string) domain.TransaksiPortofolio {
+	t.Helper()
+	return domain.TransaksiPortofolio{
+		ID:       id,
+		Jenis:    jenis,
+		Simbol:   "AMAN",
+		MataUang: "IDR",
+		Jumlah:   jumlah,
+		Harga:    hargaUji(t, harga),
+		Biaya:    uangUji(t, biaya),
+		Tanggal:  time.Date(2024, 1, hari, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestHitungPosisiMetode(t *testing.T) {
+	tests := []struct {
+		nama          string
+		metode        string
+		labaRealisasi string
+		totalModal    string
+		hargaBeli     string
+	}{
+		{nama: "fifo mengambil lot terlama", metode: domain.MetodeFIFO, labaRealisasi: "2500", totalModal: "1000", hargaBeli: "200"},
+		{nama: "rata-rata melebur semua lot", metode: domain.MetodeRataRata, labaRealisasi: "2250", totalModal: "750", hargaBeli: "150"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			daftar := []domain.TransaksiPortofolio{
+				transaksiUji(t, 1, 1, domain.TransaksiBeli, 10, "100", "0"),
+				transaksiUji(t, 2, 2, domain.TransaksiBeli, 10, "200", "0"),
+				transaksiUji(t, 3, 3, domain.TransaksiJual, 15, "300", "0"),
+			}
+			posisi, err := hitungPosisi(daftar, tt.metode)
+			if err != nil {
+				t.Fatalf("hitungPosisi: %v", err)
+			}
+			if len(posisi) != 1 {
+				t.Fatalf("jumlah posisi = %d, ingin 1", len(posisi))
+			}
+			p := posisi[0]
+			if p.Jumlah != 5 {
+				t.Errorf("Jumlah = %v, ingin 5", p.Jumlah)
+			}
+			if p.LabaRealisasi != uangUji(t, tt.labaRealisasi) {
+				t.Errorf("LabaRealisasi = %s, ingin %s", p.LabaRealisasi, tt.labaRealisasi)
+			}
+			if p.TotalModal != uangUji(t, tt.totalModal) {
+				t.Errorf("TotalModal = %s, ingin %s", p.TotalModal, tt.totalModal)
+			}
+			if p.HargaBeli != hargaUji(t, tt.hargaBeli) {
+				t.Errorf("HargaBeli = %s, ingin %s", p.HargaBeli, tt.hargaBeli)
+			}
+			if p.Metode != tt.metode {
+				t.Errorf("Metode = %q, ingin %q", p.Metode, tt.metode)
+			}
+		})
+	}
+}
+
+func TestHitungPosisiJualSebagian(t *testing.T) {
+	daftar := []domain.TransaksiPortofolio{
+		transaksiUji(t, 1, 1, domain.TransaksiBeli, 4, "50", "2"),
+		transaksiUji(t, 2, 2, domain.TransaksiJual, 1, "60", "1"),
+	}
+	posisi, err := hitungPosisi(daftar, domain.MetodeFIFO)
+	if err != nil {
+		t.Fatalf("hitungPosisi: %v", err)
+	}
+	p := posisi[0]
+	// Modal 4 unit 202 (termasuk biaya), satu unit keluar membawa modal 50.5.
+	if p.Jumlah != 3 {
+		t.Errorf("Jumlah = %v, ingin 3", p.Jumlah)
+	}
+	if want := uangUji(t, "8.5"); p.LabaRealisasi != want {
+		t.Errorf("LabaRealisasi = %s, ingin %s", p.LabaRealisasi, want)
+	}
+	if want := uangUji(t, "151.5"); p.TotalModal != want {
+		t.Errorf("TotalModal = %s, ingin %s", p.TotalModal, want)
+	}
+	if want := uangUji(t, "180"); p.NilaiSaatIni != want {
+		t.Errorf("NilaiSaatIni = %s, ingin %s", p.NilaiSaatIni, want)
+	}
+}
+
+func TestHitungPosisiJualHabis(t *testing.T) {
+	daftar := []domain.TransaksiPortofolio{
+		transaksiUji(t, 1, 1, domain.TransaksiBeli, 0.3, "1000", "0"),
+		transaksiUji(t, 2, 2, domain.TransaksiBeli, 0.1, "1000", "0"),
+		transaksiUji(t, 3, 3, domain.TransaksiJual, 0.4, "1500", "0"),
+	}
+	posisi, err := hitungPosisi(daftar, domain.MetodeFIFO)
+	if err != nil {
+		t.Fatalf("hitungPosisi: %v", err)
+	}
+	p := posisi[0]
+	if p.Jumlah != 0 || p.TotalModal != 0 {
+		t.Errorf("posisi tertutup = jumlah %v modal %s, ingin 0", p.Jumlah, p.TotalModal)
+	}
+	if want := uangUji(t, "200"); p.LabaRealisasi != want {
+		t.Errorf("LabaRealisasi = %s, ingin %s", p.LabaRealisasi, want)
+	}
+}
+
+func TestHitungPosisiJualMelebihiKepemilikan(t *testing.T) {
+	tests := []struct {
+		nama   string
+		daftar func(t *testing.T) []domain.TransaksiPortofolio
+	}{
+		{
+			nama: "jual lebih banyak dari yang dibeli",
+			daftar: func(t *testing.T) []domain.TransaksiPortofolio {
+				return []domain.TransaksiPortofolio{
+					transaksiUji(t, 1, 1, domain.TransaksiBeli, 2, "100", "0"),
+					transaksiUji(t, 2, 2, domain.TransaksiJual, 3, "100", "0"),
+				}
+			},
+		},
+		{
+			nama: "jual sebelum tanggal beli",
+			daftar: func(t *testing.T) []domain.TransaksiPortofolio {
+				return []domain.TransaksiPortofolio{
+					transaksiUji(t, 1, 5, domain.TransaksiBeli, 2, "100", "0"),
+					transaksiUji(t, 2, 1, domain.TransaksiJual, 1, "100", "0"),
+				}
+			},
+		},
+		{
+			nama: "transfer keluar melebihi kepemilikan",
+			daftar: func(t *testing.T) []domain.TransaksiPortofolio {
+				return []domain.TransaksiPortofolio{
+					transaksiUji(t, 1, 1, domain.TransaksiBeli, 1, "100", "0"),
+					transaksiUji(t, 2, 2, domain.TransaksiTransferKeluar, 1.5, "0", "0"),
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			for _, metode := range []string{domain.MetodeFIFO, domain.MetodeRataRata} {
+				if _, err := hitungPosisi(tt.daftar(t), metode); err == nil {
+					t.Errorf("metode %s: hitungPosisi tidak mengembalikan error", metode)
+				}
+			}
+		})
+	}
+}
+
+func TestHitungPosisiNilaiMeluap(t *testing.T) {
+	daftar := []domain.TransaksiPortofolio{
+		transaksiUji(t, 1, 1, domain.TransaksiBeli, 1e6, "10000000000", "0"),
+	}
+	if _, err := hitungPosisi(daftar, domain.MetodeFIFO); !errors.Is(err, domain.ErrMelampauiBatas) {
+		t.Errorf("hitungPosisi = %v, ingin ErrMelampauiBatas", err)
+	}
+}
+
+func TestIDPosisiStabil(t *testing.T) {
+	// Dua baris portofolio lama bersimbol sama (ID 7 dan 3) tergabung menjadi
+	// satu posisi; posisi baru terdaftar kemudian dengan ID 12.
+	kunci := []domain.KunciPosisiPortofolio{
+		{ID: 3, Simbol: "AMAN", MataUang: "IDR"},
+		{ID: 7, Simbol: "aman", MataUang: "IDR"},
+		{ID: 12, Simbol: "AMAN", MataUang: "USD"},
+	}
+	ids := idPosisi(kunci)
+	if ids[kunciPosisi("AMAN", "IDR")] != 3 || ids[kunciPosisi("aman", "USD")] != 12 {
+		t.Fatalf("idPosisi = %v, ingin AMAN|IDR=3 dan AMAN|USD=12", ids)
+	}
+
+	// Menghapus transaksi pertama tidak boleh mengubah kunci posisi.
+	daftar := []domain.TransaksiPortofolio{
+		transaksiUji(t, 1, 1, domain.TransaksiBeli, 10, "100", "0"),
+		transaksiUji(t, 2, 2, domain.TransaksiBeli, 5, "120", "0"),
+	}
+	for _, transaksi := range [][]domain.TransaksiPortofolio{daftar, daftar[1:]} {
+		posisi, err := hitungPosisi(transaksi, domain.MetodeFIFO)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id := ids[kunciPosisi(posisi[0].Simbol, posisi[0].MataUang)]; id != 3 {
+			t.Errorf("ID posisi dengan %d transaksi = %d, ingin 3", len(transaksi), id)
+		}
+	}
+}
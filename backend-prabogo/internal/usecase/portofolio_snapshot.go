@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+// susunSnapshot menghitung total nilai portofolio pengguna hari ini dalam mata
+// uang tampilannya tanpa menyimpannya. Posisi tanpa kurs ditandai lewat
+// AsetTanpaKurs alih-alih menggagalkan snapshot.
+func (u *PortofolioUsecase) susunSnapshot(ctx context.Context, idPengguna int64) (*domain.SnapshotPortofolio, error) {
+	tampilan, err := u.MataUangTampilan(ctx, idPengguna, "")
+	if err != nil {
+		return nil, err
+	}
+	posisi, err := u.Daftar(ctx, idPengguna, tampilan, "", false)
+	if err != nil {
+		return nil, err
+	}
+	konverter, err := u.kurs.Konverter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sekarang := time.Now()
+	snapshot := &domain.SnapshotPortofolio{
+		IDPengguna: idPengguna,
+		Tanggal:    awalHari(sekarang),
+		MataUang:   tampilan,
+		JumlahAset: len(posisi),
+		DibuatPada: sekarang,
+	}
+	for _, p := range posisi {
+		// Aset tanpa kurs tidak ikut dinilai tetapi dihitung agar snapshot
+		// tersebut dikenali sebagai batas bawah.
+		if p.KursTidakTersedia {
+			snapshot.AsetTanpaKurs++
+			continue
+		}
+		snapshot.TotalNilai += p.NilaiTampilan
+		modal, err := konverter.Konversi(p.TotalModal, p.MataUang, tampilan)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.TotalModal += modal.Bulatkan(tampilan)
+	}
+	return snapshot, nil
+}
+
+// RiwayatNilai mengembalikan deret snapshot harian yang dikonversi ke mata uang
+// tampilan memakai kurs terbaru.
+func (u *PortofolioUsecase) RiwayatNilai(ctx context.Context, idPengguna int64, mataUang string, dari, sampai time.Time) ([]domain.SnapshotPortofolio, error) {
+	tampilan, err := u.MataUangTampilan(ctx, idPengguna, mataUang)
+	if err != nil {
+		return nil, err
+	}
+	items, err := u.repo.DaftarSnapshotPortofolio(ctx, idPengguna, dari, sampai)
+	if err != nil {
+		return nil, err
+	}
+	konverter, err := u.kurs.Konverter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		asal := domain.NormalisasiMataUang(items[i].MataUang, domain.MataUangIDR)
+		nilai, err := konverter.Konversi(items[i].TotalNilai, asal, tampilan)
+		if err != nil {
+			return nil, err
+		}
+		modal, err := konverter.Konversi(items[i].TotalModal, asal, tampilan)
+		if err != nil {
+			return nil, err
+		}
+		items[i].TotalNilai = nilai.Bulatkan(tampilan)
+		items[i].TotalModal = modal.Bulatkan(tampilan)
+		if items[i].NilaiZakat != nil {
+			zakat, err := konverter.Konversi(*items[i].NilaiZakat, asal, tampilan)
+			if err != nil {
+				return nil, err
+			}
+			zakat = zakat.Bulatkan(tampilan)
+			items[i].NilaiZakat = &zakat
+		}
+		items[i].MataUang = tampilan
+	}
+	return items, nil
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+// batasTontonSelesai adalah porsi durasi video yang dianggap sudah ditonton
+// penuh; kredit penutup biasanya tidak ikut ditonton.
+const batasTontonSelesai = 0.9
+
+// TandaiMateriSelesai menandai materi selesai lalu menghitung ulang progress kelasnya.
+func (u *EdukasiUsecase) TandaiMateriSelesai(ctx context.Context, idPengguna, idMateri int64) (*domain.ProgressMateriKelas, error) {
+	return u.simpanProgressMateri(ctx, idPengguna, idMateri, func(p *domain.ProgressMateri, _ *domain.Materi) {
+		p.Selesai = true
+	})
+}
+
+// SimpanPosisiVideo mencatat posisi tonton terakhir. Video yang ditonton
+// sampai batasTontonSelesai dari durasinya otomatis ditandai selesai.
+func (u *EdukasiUsecase) SimpanPosisiVideo(ctx context.Context, idPengguna, idMateri int64, posisiDetik int) (*domain.ProgressMateriKelas, error) {
+	if posisiDetik < 0 {
+		return nil, errors.New("posisi_detik tidak boleh negatif")
+	}
+	return u.simpanProgressMateri(ctx, idPengguna, idMateri, func(p *domain.ProgressMateri, materi *domain.Materi) {
+		p.PosisiDetik = posisiDetik
+		durasi := float64(materi.DurasiMenit * 60)
+		if durasi > 0 && float64(posisiDetik) >= durasi*batasTontonSelesai {
+			p.Selesai = true
+		}
+	})
+}
+
+func (u *EdukasiUsecase) simpanProgressMateri(ctx context.Context, idPengguna, idMateri int64, ubah func(*domain.ProgressMateri, *domain.Materi)) (*domain.ProgressMateriKelas, error) {
+	materi, err := u.repo.DetailMateri(ctx, idMateri)
+	if err != nil {
+		return nil, err
+	}
+	if materi == nil {
+		return nil, errors.New("materi tidak ditemukan")
+	}
+	modul, err := u.repo.DetailModul(ctx, materi.IDModul)
+	if err != nil {
+		return nil, err
+	}
+	if modul == nil {
+		return nil, errors.New("modul materi tidak ditemukan")
+	}
+	if err := u.wajibTerdaftar(ctx, idPengguna, modul.IDKelas); err != nil {
+		return nil, err
+	}
+
+	progress, err := u.repo.AmbilProgressMateri(ctx, idPengguna, idMateri)
+	if err != nil {
+		return nil, err
+	}
+	if progress == nil {
+		progress = &domain.ProgressMateri{IDPengguna: idPengguna, IDMateri: idMateri}
+	}
+	sekarang := time.Now()
+	sudahSelesai := progress.Selesai
+	ubah(progress, materi)
+	if progress.Selesai && !sudahSelesai {
+		progress.SelesaiPada = &sekarang
+	}
+	progress.DiperbaruiPada = sekarang
+	if err := u.repo.SimpanProgressMateri(ctx, progress); err != nil {
+		return nil, err
+	}
+
+	kelas, err := u.perbaruiProgressKelas(ctx, idPengguna, modul.IDKelas)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ProgressMateriKelas{Materi: *progress, Kelas: *kelas}, nil
+}
+
+// perbaruiProgressKelas menghitung ulang progress kelas lalu menerbitkan
+// sertifikat bila kelas selesai.
+func (u *EdukasiUsecase) perbaruiProgressKelas(ctx context.Context, idPengguna, idKelas int64) (*domain.ProgressKelas, error) {
+	materi, err := u.repo.DaftarMateriByKelas(ctx, idKelas)
+	if err != nil {
+		return nil, err
+	}
+	progressMateri, err := u.repo.DaftarProgressMateri(ctx, idPengguna, idKelas)
+	if err != nil {
+		return nil, err
+	}
+	ujian, err := u.repo.DaftarUjianByKelas(ctx, idKelas)
+	if err != nil {
+		return nil, err
+	}
+	lulus, err := u.repo.DaftarUjianLulus(ctx, idPengguna, idKelas)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &domain.ProgressKelas{
+		IDPengguna:          idPengguna,
+		IDKelas:             idKelas,
+		TerakhirDiaksesPada: time.Now(),
+	}
+	progress.Persentase, progress.Status = hitungProgressKelas(materi, progressMateri, ujian, lulus)
+	if err := u.repo.SimpanProgress(ctx, progress); err != nil {
+		return nil, err
+	}
+	if progress.Status == domain.ProgressSelesai {
+		if _, err := u.terbitkanSertifikat(ctx, idPengguna, idKelas); err != nil {
+			return nil, err
+		}
+	}
+	return progress, nil
+}
+
+// hitungProgressKelas menghitung persentase dari materi yang selesai dengan
+// bobot DurasiMenit. Bila seluruh materi tidak memiliki durasi, setiap materi
+// berbobot sama. Kelas selesai bila semua materi selesai dan semua ujian wajib
+// lulus; ujian tanpa soal tidak bisa dikerjakan sehingga tidak diwajibkan.
+func hitungProgressKelas(materi []domain.Materi, progressMateri []domain.ProgressMateri, ujian []domain.Ujian, lulus []int64) (float64, string) {
+	selesai := make(map[int64]bool, len(progressMateri))
+	for _, p := range progressMateri {
+		if p.Selesai {
+			selesai[p.IDMateri] = true
+		}
+	}
+	var totalBobot, bobotSelesai int
+	for _, m := range materi {
+		totalBobot += m.DurasiMenit
+	}
+	semuaMateri := true
+	for _, m := range materi {
+		bobot := m.DurasiMenit
+		if totalBobot == 0 {
+			bobot = 1
+		}
+		if selesai[m.ID] {
+			bobotSelesai += bobot
+		} else {
+			semuaMateri = false
+		}
+	}
+	if totalBobot == 0 {
+		totalBobot = len(materi)
+	}
+
+	sudahLulus := make(map[int64]bool, len(lulus))
+	for _, id := range lulus {
+		sudahLulus[id] = true
+	}
+	var ujianWajib int
+	semuaUjian := true
+	for _, uj := range ujian {
+		if uj.JumlahSoal == 0 {
+			continue
+		}
+		ujianWajib++
+		if !sudahLulus[uj.ID] {
+			semuaUjian = false
+		}
+	}
+
+	if (len(materi) > 0 || ujianWajib > 0) && semuaMateri && semuaUjian {
+		return 100, domain.ProgressSelesai
+	}
+	var persentase float64
+	if totalBobot > 0 {
+		persentase = math.Round(float64(bobotSelesai)/float64(totalBobot)*10000) / 100
+	}
+	return persentase, domain.ProgressBerjalan
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func TestHitungProgressKelas(t *testing.T) {
+	berdurasi := []domain.Materi{{ID: 1, DurasiMenit: 10}, {ID: 2, DurasiMenit: 30}, {ID: 3, DurasiMenit: 60}}
+	tanpaDurasi := []domain.Materi{{ID: 1}, {ID: 2}, {ID: 3}}
+	selesai := func(id ...int64) []domain.ProgressMateri {
+		var daftar []domain.ProgressMateri
+		for _, i := range id {
+			daftar = append(daftar, domain.ProgressMateri{IDMateri: i, Selesai: true})
+		}
+		return daftar
+	}
+	ujianBersoal := domain.Ujian{ID: 7, JumlahSoal: 10}
+	ujianKosong := domain.Ujian{ID: 8}
+
+	tests := []struct {
+		nama       string
+		materi     []domain.Materi
+		progress   []domain.ProgressMateri
+		ujian      []domain.Ujian
+		lulus      []int64
+		persentase float64
+		status     string
+	}{
+		{
+			nama:       "berbobot durasi",
+			materi:     berdurasi,
+			progress:   append(selesai(1, 2), domain.ProgressMateri{IDMateri: 3, PosisiDetik: 600}),
+			persentase: 40,
+			status:     domain.ProgressBerjalan,
+		},
+		{
+			nama:       "semua durasi nol berbobot sama",
+			materi:     tanpaDurasi,
+			progress:   selesai(2),
+			persentase: 33.33,
+			status:     domain.ProgressBerjalan,
+		},
+		{
+			nama:       "materi selesai tetapi ujian belum lulus",
+			materi:     berdurasi,
+			progress:   selesai(1, 2, 3),
+			ujian:      []domain.Ujian{ujianBersoal},
+			persentase: 100,
+			status:     domain.ProgressBerjalan,
+		},
+		{
+			nama:       "materi selesai dan ujian lulus",
+			materi:     berdurasi,
+			progress:   selesai(1, 2, 3),
+			ujian:      []domain.Ujian{ujianBersoal},
+			lulus:      []int64{7},
+			persentase: 100,
+			status:     domain.ProgressSelesai,
+		},
+		{
+			nama:       "ujian tanpa soal tidak diwajibkan",
+			materi:     tanpaDurasi,
+			progress:   selesai(1, 2, 3),
+			ujian:      []domain.Ujian{ujianBersoal, ujianKosong},
+			lulus:      []int64{7},
+			persentase: 100,
+			status:     domain.ProgressSelesai,
+		},
+		{
+			nama:       "kelas hanya berisi ujian tanpa soal",
+			ujian:      []domain.Ujian{ujianKosong},
+			persentase: 0,
+			status:     domain.ProgressBerjalan,
+		},
+		{
+			nama:       "kelas hanya berisi ujian yang lulus",
+			ujian:      []domain.Ujian{ujianBersoal},
+			lulus:      []int64{7},
+			persentase: 100,
+			status:     domain.ProgressSelesai,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			persentase, status := hitungProgressKelas(tt.materi, tt.progress, tt.ujian, tt.lulus)
+			if persentase != tt.persentase || status != tt.status {
+				t.Errorf("progress = %v %s, ingin %v %s", persentase, status, tt.persentase, tt.status)
+			}
+		})
+	}
+}
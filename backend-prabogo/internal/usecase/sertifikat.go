@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+// alfabetKodeSertifikat adalah Base32 Crockford tanpa I, L, O dan U agar kode
+// mudah diketik ulang dari sertifikat cetak.
+const alfabetKodeSertifikat = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// panjangKodeSertifikat memberi 80 bit acak; kode tidak dapat ditebak dari ID,
+// pengguna maupun tanggal terbit.
+const panjangKodeSertifikat = 16
+
+func (u *EdukasiUsecase) SertifikatSaya(ctx context.Context, idPengguna int64) ([]domain.Sertifikat, error) {
+	return u.repo.DaftarSertifikatPengguna(ctx, idPengguna)
+}
+
+// VerifikasiSertifikat mencari sertifikat berdasarkan kode untuk pemeriksaan publik.
+func (u *EdukasiUsecase) VerifikasiSertifikat(ctx context.Context, kode string) (*domain.VerifikasiSertifikat, error) {
+	kode = strings.TrimSpace(kode)
+	if kode == "" {
+		return nil, errors.New("kode sertifikat wajib diisi")
+	}
+	data, err := u.repo.AmbilSertifikatByKode(ctx, kode)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errors.New("sertifikat tidak ditemukan")
+	}
+	data.URLVerifikasi = u.urlVerifikasi(data.Kode)
+	return data, nil
+}
+
+func (u *EdukasiUsecase) urlVerifikasi(kode string) string {
+	return u.urlPublik + "/api/v1/sertifikat/verifikasi/" + url.PathEscape(kode)
+}
+
+// terbitkanSertifikat membuat sertifikat kelas bila belum ada. Indeks unik
+// pengguna dan kelas mencegah sertifikat ganda saat dua permintaan bersamaan.
+func (u *EdukasiUsecase) terbitkanSertifikat(ctx context.Context, idPengguna, idKelas int64) (*domain.Sertifikat, error) {
+	ada, err := u.repo.AmbilSertifikatKelas(ctx, idPengguna, idKelas)
+	if err != nil || ada != nil {
+		return ada, err
+	}
+	const percobaanMaksimal = 3
+	for i := 0; ; i++ {
+		kode, err := buatKodeSertifikat()
+		if err != nil {
+			return nil, err
+		}
+		sertifikat := &domain.Sertifikat{IDPengguna: idPengguna, IDKelas: idKelas, Kode: kode, TanggalTerbit: time.Now()}
+		errSimpan := u.repo.SimpanSertifikat(ctx, sertifikat)
+		if errSimpan == nil {
+			return sertifikat, nil
+		}
+		ada, err := u.repo.AmbilSertifikatKelas(ctx, idPengguna, idKelas)
+		if err != nil {
+			return nil, err
+		}
+		if ada != nil {
+			return ada, nil
+		}
+		if i+1 == percobaanMaksimal {
+			return nil, errSimpan
+		}
+	}
+}
+
+// buatKodeSertifikat menghasilkan kode seperti "AVR-7KQ2-M9XD-4TPW-HZ3C".
+func buatKodeSertifikat() (string, error) {
+	acak := make([]byte, panjangKodeSertifikat)
+	if _, err := rand.Read(acak); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString("AVR")
+	for i, v := range acak {
+		if i%4 == 0 {
+			b.WriteByte('-')
+		}
+		b.WriteByte(alfabetKodeSertifikat[v&31])
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/averroes/backend-prabogo/pkg/pdf"
+	"github.com/averroes/backend-prabogo/pkg/qrcode"
+)
+
+// Tata letak sertifikat A4 mendatar dalam titik.
+const (
+	marginSertifikat = 36.0
+	ukuranQR         = 110.0
+	zonaTenangQR     = 4
+)
+
+// PDFSertifikat menyusun sertifikat PDF dengan kode QR yang mengarah ke URL
+// verifikasi publik. Mengembalikan isi berkas dan nama berkas yang disarankan.
+func (u *EdukasiUsecase) PDFSertifikat(ctx context.Context, kode string) ([]byte, string, error) {
+	data, err := u.VerifikasiSertifikat(ctx, kode)
+	if err != nil {
+		return nil, "", err
+	}
+	qr, err := qrcode.Buat(data.URLVerifikasi, qrcode.KoreksiM)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dok := pdf.BaruLanskap("Sertifikat "+data.JudulKelas, "Averroes")
+	hal := dok.TambahHalaman()
+	lebar, tinggi := dok.Lebar(), dok.Tinggi()
+	tengah := lebar / 2
+
+	hal.Warna(0.09, 0.36, 0.27)
+	bingkai(hal, marginSertifikat, lebar, tinggi, 3)
+	bingkai(hal, marginSertifikat+8, lebar, tinggi, 0.75)
+
+	hal.TeksTengah(tengah, 140, pdf.Tebal, 34, "SERTIFIKAT")
+	hal.Warna(0.2, 0.2, 0.2)
+	hal.TeksTengah(tengah, 165, pdf.Reguler, 12, "Averroes - Edukasi Muamalah Digital")
+	hal.TeksTengah(tengah, 215, pdf.Reguler, 14, "Diberikan kepada")
+	hal.Warna(0, 0, 0)
+	hal.TeksTengah(tengah, 255, pdf.Tebal, 28, data.NamaPeserta)
+	hal.Garis(tengah-180, 268, tengah+180, 268, 0.5)
+	hal.Warna(0.2, 0.2, 0.2)
+	hal.TeksTengah(tengah, 300, pdf.Reguler, 14, "atas keberhasilan menyelesaikan seluruh materi dan ujian kelas")
+	hal.Warna(0, 0, 0)
+	y := 335.0
+	for _, baris := range pdf.PecahBaris(pdf.Tebal, 20, data.JudulKelas, lebar-2*marginSertifikat-120) {
+		hal.TeksTengah(tengah, y, pdf.Tebal, 20, baris)
+		y += 26
+	}
+	hal.Warna(0.2, 0.2, 0.2)
+	hal.TeksTengah(tengah, y+14, pdf.Reguler, 12, "Diterbitkan pada "+formatTanggalIndonesia(tanggalLokal(data.TanggalTerbit)))
+
+	// Kode QR di pojok kanan bawah; modul gelap yang bersebelahan dalam satu
+	// baris digabung menjadi satu kotak agar isi halaman tetap ringkas.
+	qrX := lebar - marginSertifikat - 24 - ukuranQR
+	qrY := tinggi - marginSertifikat - 24 - ukuranQR
+	modul := ukuranQR / float64(qr.Ukuran()+2*zonaTenangQR)
+	hal.Warna(0, 0, 0)
+	for baris := 0; baris < qr.Ukuran(); baris++ {
+		for kolom := 0; kolom < qr.Ukuran(); {
+			if !qr.Gelap(kolom, baris) {
+				kolom++
+				continue
+			}
+			awal := kolom
+			for kolom < qr.Ukuran() && qr.Gelap(kolom, baris) {
+				kolom++
+			}
+			hal.Kotak(qrX+float64(zonaTenangQR+awal)*modul, qrY+float64(zonaTenangQR+baris)*modul, float64(kolom-awal)*modul, modul, true)
+		}
+	}
+
+	kiri := marginSertifikat + 30
+	hal.Warna(0.2, 0.2, 0.2)
+	hal.Teks(kiri, tinggi-marginSertifikat-76, pdf.Tebal, 10, "Kode verifikasi: "+data.Kode)
+	hal.Teks(kiri, tinggi-marginSertifikat-60, pdf.Reguler, ukuranCatatan, "Pindai kode QR atau buka tautan berikut untuk memeriksa keaslian sertifikat:")
+	hal.Teks(kiri, tinggi-marginSertifikat-48, pdf.Reguler, ukuranCatatan, data.URLVerifikasi)
+
+	isi, err := dok.Bytes()
+	if err != nil {
+		return nil, "", err
+	}
+	return isi, fmt.Sprintf("sertifikat-%s.pdf", data.Kode), nil
+}
+
+// bingkai menggambar tepi persegi berjarak jarak dari tepi halaman.
+func bingkai(hal *pdf.Halaman, jarak, lebar, tinggi, tebal float64) {
+	kanan, bawah := lebar-jarak, tinggi-jarak
+	hal.Garis(jarak, jarak, kanan, jarak, tebal)
+	hal.Garis(kanan, jarak, kanan, bawah, tebal)
+	hal.Garis(kanan, bawah, jarak, bawah, tebal)
+	hal.Garis(jarak, bawah, jarak, jarak, tebal)
+}
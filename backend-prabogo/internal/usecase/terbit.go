@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+// masaBerlakuPratinjau adalah umur token pratinjau sejak diterbitkan.
+const masaBerlakuPratinjau = 7 * 24 * time.Hour
+
+// siapkanStatusKonten menormalkan dan memvalidasi status terbit. Konten baru
+// tanpa status dimulai dari draf; pada pembaruan, status kosong berarti
+// status lama dipertahankan.
+func siapkanStatusKonten(status *string, baru bool) error {
+	*status = strings.ToLower(strings.TrimSpace(*status))
+	if *status == "" {
+		if baru {
+			*status = domain.KontenDraf
+		}
+		return nil
+	}
+	if !domain.StatusKontenValid(*status) {
+		return errors.New("status harus draf, review, terbit atau arsip")
+	}
+	return nil
+}
+
+// BuatTokenPratinjau menerbitkan token baru bagi editor untuk melihat kelas
+// beserta draf modul dan materinya. Token lama otomatis tidak berlaku.
+func (u *AdminUsecase) BuatTokenPratinjau(ctx context.Context, idKelas int64) (*domain.PratinjauKelas, error) {
+	acak := make([]byte, 24)
+	if _, err := rand.Read(acak); err != nil {
+		return nil, err
+	}
+	pratinjau := &domain.PratinjauKelas{
+		IDKelas:        idKelas,
+		Token:          hex.EncodeToString(acak),
+		KadaluarsaPada: time.Now().Add(masaBerlakuPratinjau),
+	}
+	ada, err := u.repo.SimpanTokenPratinjau(ctx, idKelas, pratinjau.Token, pratinjau.KadaluarsaPada)
+	if err != nil {
+		return nil, err
+	}
+	if !ada {
+		return nil, errors.New("kelas tidak ditemukan")
+	}
+	return pratinjau, nil
+}
+
+// PratinjauKelas menyusun pohon kelas lengkap termasuk konten yang belum
+// terbit. Mengembalikan nil bila token tidak dikenal atau kedaluwarsa.
+func (u *EdukasiUsecase) PratinjauKelas(ctx context.Context, token string) (*domain.KelasLengkap, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, nil
+	}
+	kelas, err := u.repo.DetailKelasPratinjau(ctx, token)
+	if err != nil || kelas == nil {
+		return nil, err
+	}
+	modul, err := u.repo.DaftarModulPratinjau(ctx, kelas.ID)
+	if err != nil {
+		return nil, err
+	}
+	materi, err := u.repo.DaftarMateriPratinjau(ctx, kelas.ID)
+	if err != nil {
+		return nil, err
+	}
+	ujian, err := u.repo.DaftarUjianByKelas(ctx, kelas.ID)
+	if err != nil {
+		return nil, err
+	}
+	prasyarat, err := u.repo.DaftarPrasyaratKelas(ctx, kelas.ID)
+	if err != nil {
+		return nil, err
+	}
+	return susunKelasLengkap(kelas, modul, materi, ujian, prasyarat), nil
+}
@@ -0,0 +1,442 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+// toleransiWaktuUjian memberi kelonggaran jaringan bagi jawaban yang dikirim
+// tepat saat batas waktu berakhir.
+const toleransiWaktuUjian = 30 * time.Second
+
+// MulaiUjian melanjutkan percobaan yang masih berjalan atau membuat percobaan
+// baru dengan soal yang diundi dari bank soal. Percobaan baru ditolak dengan
+// domain.ErrPercobaanDibatasi bila batas percobaan tercapai atau jeda
+// antarpercobaan belum berakhir, dan dengan domain.ErrKelasBelumDimulai bila
+// kelasnya belum dimulai.
+func (u *EdukasiUsecase) MulaiUjian(ctx context.Context, idUjian, idPengguna int64) (*domain.LembarUjian, error) {
+	ujian, bank, err := u.bankSoal(ctx, idUjian)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.wajibTerdaftar(ctx, idPengguna, ujian.IDKelas); err != nil {
+		return nil, err
+	}
+	sekarang := time.Now()
+	terakhir, err := u.repo.AmbilPercobaanTerakhir(ctx, idUjian, idPengguna)
+	if err != nil {
+		return nil, err
+	}
+	if terakhir != nil && terakhir.Status == domain.PercobaanBerjalan {
+		if !waktuUjianHabis(terakhir, sekarang) {
+			return lembarUjian(ujian, terakhir, bank, sekarang), nil
+		}
+		if _, err := u.selesaikanPercobaan(ctx, ujian, terakhir, bank, sekarang); err != nil {
+			return nil, err
+		}
+	}
+	if len(bank) == 0 {
+		return nil, errors.New("ujian belum memiliki soal")
+	}
+	if err := u.cekBatasPercobaan(ctx, ujian, terakhir, idPengguna, sekarang); err != nil {
+		return nil, err
+	}
+
+	urutan := rand.Perm(len(bank))
+	jumlah := len(bank)
+	if ujian.SoalPerPercobaan > 0 && ujian.SoalPerPercobaan < jumlah {
+		jumlah = ujian.SoalPerPercobaan
+	}
+	percobaan := &domain.PercobaanUjian{
+		IDUjian:    idUjian,
+		IDPengguna: idPengguna,
+		Soal:       make([]int64, jumlah),
+		Jawaban:    []domain.JawabanSoal{},
+		Status:     domain.PercobaanBerjalan,
+		MulaiPada:  sekarang,
+	}
+	for i := 0; i < jumlah; i++ {
+		percobaan.Soal[i] = bank[urutan[i]].ID
+	}
+	if ujian.DurasiMenit > 0 {
+		batas := sekarang.Add(time.Duration(ujian.DurasiMenit) * time.Minute)
+		percobaan.BatasWaktu = &batas
+	}
+	if err := u.repo.SimpanPercobaanUjian(ctx, percobaan); err != nil {
+		return nil, err
+	}
+	return lembarUjian(ujian, percobaan, bank, sekarang), nil
+}
+
+// SimpanJawaban menyimpan jawaban sementara tanpa menilai percobaan.
+func (u *EdukasiUsecase) SimpanJawaban(ctx context.Context, idUjian, idPengguna int64, jawaban []domain.JawabanSoal) (*domain.PercobaanUjian, error) {
+	ujian, bank, err := u.bankSoal(ctx, idUjian)
+	if err != nil {
+		return nil, err
+	}
+	percobaan, err := u.percobaanBerjalan(ctx, idUjian, idPengguna)
+	if err != nil {
+		return nil, err
+	}
+	sekarang := time.Now()
+	if waktuUjianHabis(percobaan, sekarang) {
+		if _, err := u.selesaikanPercobaan(ctx, ujian, percobaan, bank, sekarang); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("waktu ujian sudah habis, jawaban tersimpan telah dinilai")
+	}
+	gabungJawaban(percobaan, jawaban)
+	if err := u.repo.PerbaruiPercobaanUjian(ctx, percobaan); err != nil {
+		return nil, err
+	}
+	return percobaan, nil
+}
+
+// KirimUjian menilai percobaan yang berjalan. Jawaban yang dikirim setelah
+// batas waktu diabaikan dan penilaian memakai jawaban yang sudah tersimpan.
+func (u *EdukasiUsecase) KirimUjian(ctx context.Context, idUjian, idPengguna int64, jawaban []domain.JawabanSoal) (*domain.HasilUjian, error) {
+	ujian, bank, err := u.bankSoal(ctx, idUjian)
+	if err != nil {
+		return nil, err
+	}
+	percobaan, err := u.percobaanBerjalan(ctx, idUjian, idPengguna)
+	if err != nil {
+		return nil, err
+	}
+	sekarang := time.Now()
+	if !waktuUjianHabis(percobaan, sekarang) {
+		gabungJawaban(percobaan, jawaban)
+	}
+	hasil, err := u.selesaikanPercobaan(ctx, ujian, percobaan, bank, sekarang)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.lengkapiHasil(ctx, ujian, hasil, idPengguna); err != nil {
+		return nil, err
+	}
+	return hasil, nil
+}
+
+// HasilUjian mengembalikan hasil percobaan terakhir peserta. Percobaan yang
+// waktunya sudah habis dinilai terlebih dahulu.
+func (u *EdukasiUsecase) HasilUjian(ctx context.Context, idUjian, idPengguna int64) (*domain.HasilUjian, error) {
+	ujian, bank, err := u.bankSoal(ctx, idUjian)
+	if err != nil {
+		return nil, err
+	}
+	percobaan, err := u.repo.AmbilPercobaanTerakhir(ctx, idUjian, idPengguna)
+	if err != nil {
+		return nil, err
+	}
+	if percobaan == nil {
+		return nil, errors.New("belum ada percobaan untuk ujian ini")
+	}
+	var hasil *domain.HasilUjian
+	sekarang := time.Now()
+	switch {
+	case percobaan.Status == domain.PercobaanSelesai:
+		hasil = hasilTersimpan(ujian, percobaan, bank)
+	case waktuUjianHabis(percobaan, sekarang):
+		if hasil, err = u.selesaikanPercobaan(ctx, ujian, percobaan, bank, sekarang); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("percobaan ujian masih berjalan")
+	}
+	if err := u.lengkapiHasil(ctx, ujian, hasil, idPengguna); err != nil {
+		return nil, err
+	}
+	return hasil, nil
+}
+
+func (u *EdukasiUsecase) cekBatasPercobaan(ctx context.Context, ujian *domain.Ujian, terakhir *domain.PercobaanUjian, idPengguna int64, sekarang time.Time) error {
+	var jumlah int
+	if ujian.MaksPercobaan > 0 {
+		var err error
+		if jumlah, err = u.repo.HitungPercobaanUjian(ctx, ujian.ID, idPengguna); err != nil {
+			return err
+		}
+	}
+	return batasPercobaan(ujian, jumlah, terakhir, sekarang)
+}
+
+// batasPercobaan menolak percobaan baru bila jumlah percobaan sudah mencapai
+// MaksPercobaan atau jeda sejak percobaan terakhir selesai belum lewat.
+func batasPercobaan(ujian *domain.Ujian, jumlah int, terakhir *domain.PercobaanUjian, sekarang time.Time) error {
+	if ujian.MaksPercobaan > 0 && jumlah >= ujian.MaksPercobaan {
+		return fmt.Errorf("%w: batas %d percobaan sudah tercapai", domain.ErrPercobaanDibatasi, ujian.MaksPercobaan)
+	}
+	if ujian.JedaMenit > 0 && terakhir != nil && terakhir.SelesaiPada != nil {
+		boleh := terakhir.SelesaiPada.Add(time.Duration(ujian.JedaMenit) * time.Minute)
+		if sekarang.Before(boleh) {
+			return fmt.Errorf("%w: ujian dapat diulang pada %s", domain.ErrPercobaanDibatasi, boleh.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// lengkapiHasil mengisi jumlah dan sisa percobaan, lalu mengosongkan kunci
+// jawaban dan pembahasan selama peserta belum lulus dan masih bisa mencoba
+// lagi agar kunci tidak bisa dipakai pada percobaan berikutnya.
+func (u *EdukasiUsecase) lengkapiHasil(ctx context.Context, ujian *domain.Ujian, hasil *domain.HasilUjian, idPengguna int64) error {
+	var err error
+	if hasil.JumlahPercobaan, err = u.repo.HitungPercobaanUjian(ctx, ujian.ID, idPengguna); err != nil {
+		return err
+	}
+	habis := false
+	if ujian.MaksPercobaan > 0 {
+		sisa := max(ujian.MaksPercobaan-hasil.JumlahPercobaan, 0)
+		hasil.SisaPercobaan = &sisa
+		habis = sisa == 0
+	}
+	lulus := hasil.Percobaan.Lulus
+	if !lulus && !habis {
+		daftarLulus, err := u.repo.DaftarUjianLulus(ctx, idPengguna, ujian.IDKelas)
+		if err != nil {
+			return err
+		}
+		lulus = slices.Contains(daftarLulus, ujian.ID)
+	}
+	hasil.KunciDitampilkan = lulus || habis
+	if !hasil.KunciDitampilkan {
+		for i := range hasil.Rincian {
+			hasil.Rincian[i].JawabanBenar = ""
+			hasil.Rincian[i].Pembahasan = ""
+		}
+	}
+	return nil
+}
+
+func (u *EdukasiUsecase) bankSoal(ctx context.Context, idUjian int64) (*domain.Ujian, []domain.SoalUjian, error) {
+	ujian, err := u.repo.DetailUjian(ctx, idUjian)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ujian == nil {
+		return nil, nil, errors.New("ujian tidak ditemukan")
+	}
+	bank, err := u.repo.DaftarSoalUjian(ctx, idUjian)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ujian, bank, nil
+}
+
+func (u *EdukasiUsecase) percobaanBerjalan(ctx context.Context, idUjian, idPengguna int64) (*domain.PercobaanUjian, error) {
+	percobaan, err := u.repo.AmbilPercobaanTerakhir(ctx, idUjian, idPengguna)
+	if err != nil {
+		return nil, err
+	}
+	if percobaan == nil || percobaan.Status != domain.PercobaanBerjalan {
+		return nil, errors.New("tidak ada percobaan ujian yang berjalan")
+	}
+	return percobaan, nil
+}
+
+func (u *EdukasiUsecase) selesaikanPercobaan(ctx context.Context, ujian *domain.Ujian, percobaan *domain.PercobaanUjian, bank []domain.SoalUjian, sekarang time.Time) (*domain.HasilUjian, error) {
+	selesai := sekarang
+	if percobaan.BatasWaktu != nil && selesai.After(*percobaan.BatasWaktu) {
+		selesai = *percobaan.BatasWaktu
+	}
+	percobaan.Status = domain.PercobaanSelesai
+	percobaan.SelesaiPada = &selesai
+	hasil := nilaiPercobaan(ujian, percobaan, bank)
+	percobaan.Skor = hasil.Percobaan.Skor
+	percobaan.Lulus = hasil.Percobaan.Lulus
+	percobaan.Rincian = slices.Clone(hasil.Rincian)
+	if err := u.repo.PerbaruiPercobaanUjian(ctx, percobaan); err != nil {
+		return nil, err
+	}
+	if _, err := u.perbaruiProgressKelas(ctx, percobaan.IDPengguna, ujian.IDKelas); err != nil {
+		return nil, err
+	}
+	return hasil, nil
+}
+
+func waktuUjianHabis(percobaan *domain.PercobaanUjian, sekarang time.Time) bool {
+	return percobaan.BatasWaktu != nil && sekarang.After(percobaan.BatasWaktu.Add(toleransiWaktuUjian))
+}
+
+// gabungJawaban menimpa jawaban per soal; jawaban untuk soal di luar
+// percobaan diabaikan.
+func gabungJawaban(percobaan *domain.PercobaanUjian, jawaban []domain.JawabanSoal) {
+	diundi := make(map[int64]bool, len(percobaan.Soal))
+	for _, id := range percobaan.Soal {
+		diundi[id] = true
+	}
+	for _, j := range jawaban {
+		if !diundi[j.IDSoal] {
+			continue
+		}
+		j.Teks = strings.TrimSpace(j.Teks)
+		ditimpa := false
+		for i := range percobaan.Jawaban {
+			if percobaan.Jawaban[i].IDSoal == j.IDSoal {
+				percobaan.Jawaban[i] = j
+				ditimpa = true
+				break
+			}
+		}
+		if !ditimpa {
+			percobaan.Jawaban = append(percobaan.Jawaban, j)
+		}
+	}
+}
+
+// lembarUjian menyusun soal percobaan tanpa kunci jawaban. Opsi pilihan ganda
+// diacak dengan benih dari ID percobaan agar urutannya tetap saat dilanjutkan.
+func lembarUjian(ujian *domain.Ujian, percobaan *domain.PercobaanUjian, bank []domain.SoalUjian, sekarang time.Time) *domain.LembarUjian {
+	soalByID := make(map[int64]domain.SoalUjian, len(bank))
+	for _, s := range bank {
+		soalByID[s.ID] = s
+	}
+	lembar := &domain.LembarUjian{Ujian: *ujian, Percobaan: *percobaan, Soal: []domain.SoalPeserta{}}
+	for _, id := range percobaan.Soal {
+		s, ok := soalByID[id]
+		if !ok {
+			continue
+		}
+		tampil := domain.SoalPeserta{ID: s.ID, Tipe: s.Tipe, Pertanyaan: s.Pertanyaan, Bobot: s.Bobot}
+		for _, o := range s.Opsi {
+			tampil.Opsi = append(tampil.Opsi, domain.OpsiPeserta{ID: o.ID, Teks: o.Teks})
+		}
+		if s.Tipe == domain.SoalPilihanGanda {
+			acak := rand.New(rand.NewPCG(uint64(percobaan.ID), uint64(s.ID)))
+			acak.Shuffle(len(tampil.Opsi), func(i, j int) { tampil.Opsi[i], tampil.Opsi[j] = tampil.Opsi[j], tampil.Opsi[i] })
+		}
+		lembar.Soal = append(lembar.Soal, tampil)
+	}
+	if percobaan.BatasWaktu != nil {
+		sisa := int64(percobaan.BatasWaktu.Sub(sekarang).Seconds())
+		if sisa < 0 {
+			sisa = 0
+		}
+		lembar.SisaDetik = &sisa
+	}
+	return lembar
+}
+
+// nilaiPercobaan menilai jawaban terhadap kunci di bank soal tanpa mengubah
+// percobaan. Skor adalah persentase bobot soal yang dijawab benar; soal yang
+// sudah dihapus dari bank tidak ikut dihitung.
+func nilaiPercobaan(ujian *domain.Ujian, percobaan *domain.PercobaanUjian, bank []domain.SoalUjian) *domain.HasilUjian {
+	soalByID := make(map[int64]domain.SoalUjian, len(bank))
+	for _, s := range bank {
+		soalByID[s.ID] = s
+	}
+	jawabanByID := make(map[int64]domain.JawabanSoal, len(percobaan.Jawaban))
+	for _, j := range percobaan.Jawaban {
+		jawabanByID[j.IDSoal] = j
+	}
+	hasil := &domain.HasilUjian{Ujian: *ujian, Rincian: []domain.HasilSoal{}}
+	var totalBobot, bobotBenar int
+	for _, id := range percobaan.Soal {
+		s, ok := soalByID[id]
+		if !ok {
+			continue
+		}
+		jawaban := jawabanByID[id]
+		jawaban.IDSoal = id
+		benar := jawabanBenar(s, jawaban)
+		hasil.Rincian = append(hasil.Rincian, domain.HasilSoal{
+			IDSoal:       s.ID,
+			Tipe:         s.Tipe,
+			Pertanyaan:   s.Pertanyaan,
+			Jawaban:      jawaban,
+			JawabanBenar: kunciJawaban(s),
+			Benar:        benar,
+			Bobot:        s.Bobot,
+			Pembahasan:   s.Pembahasan,
+		})
+		totalBobot += s.Bobot
+		if benar {
+			bobotBenar += s.Bobot
+			hasil.JumlahBenar++
+		}
+	}
+	hasil.JumlahSoal = len(hasil.Rincian)
+	hasil.Percobaan = *percobaan
+	hasil.Percobaan.Skor = 0
+	if totalBobot > 0 {
+		hasil.Percobaan.Skor = math.Round(float64(bobotBenar)/float64(totalBobot)*10000) / 100
+	}
+	hasil.Percobaan.Lulus = hasil.Percobaan.Skor >= float64(nilaiLulus(ujian))
+	return hasil
+}
+
+// hasilTersimpan menyusun hasil percobaan yang sudah selesai dari rincian
+// yang disimpan saat dikirim sehingga tanda benar per soal tetap sesuai skor
+// walau kunci jawaban diubah setelahnya. Percobaan lama tanpa rincian dinilai
+// ulang terhadap bank soal dengan skor tersimpan dipertahankan.
+func hasilTersimpan(ujian *domain.Ujian, percobaan *domain.PercobaanUjian, bank []domain.SoalUjian) *domain.HasilUjian {
+	if percobaan.Rincian == nil {
+		hasil := nilaiPercobaan(ujian, percobaan, bank)
+		hasil.Percobaan = *percobaan
+		return hasil
+	}
+	hasil := &domain.HasilUjian{
+		Ujian:      *ujian,
+		Percobaan:  *percobaan,
+		Rincian:    slices.Clone(percobaan.Rincian),
+		JumlahSoal: len(percobaan.Rincian),
+	}
+	for _, r := range hasil.Rincian {
+		if r.Benar {
+			hasil.JumlahBenar++
+		}
+	}
+	return hasil
+}
+
+func nilaiLulus(ujian *domain.Ujian) int {
+	if ujian.NilaiLulus > 0 {
+		return ujian.NilaiLulus
+	}
+	return domain.NilaiLulusBawaan
+}
+
+func jawabanBenar(soal domain.SoalUjian, jawaban domain.JawabanSoal) bool {
+	if soal.Tipe == domain.SoalIsian {
+		teks := normalisasiJawaban(jawaban.Teks)
+		if teks == "" {
+			return false
+		}
+		for _, kunci := range strings.Split(soal.JawabanIsian, "|") {
+			if normalisasiJawaban(kunci) == teks {
+				return true
+			}
+		}
+		return false
+	}
+	for _, o := range soal.Opsi {
+		if o.ID == jawaban.IDOpsi {
+			return o.Benar
+		}
+	}
+	return false
+}
+
+func kunciJawaban(soal domain.SoalUjian) string {
+	if soal.Tipe == domain.SoalIsian {
+		kunci, _, _ := strings.Cut(soal.JawabanIsian, "|")
+		return strings.TrimSpace(kunci)
+	}
+	for _, o := range soal.Opsi {
+		if o.Benar {
+			return o.Teks
+		}
+	}
+	return ""
+}
+
+func normalisasiJawaban(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func soalPilihanUji(id int64, tipe string, benar int64, bobot int) domain.SoalUjian {
+	return domain.SoalUjian{
+		ID:    id,
+		Tipe:  tipe,
+		Bobot: bobot,
+		Opsi: []domain.OpsiSoal{
+			{ID: id*10 + 1, Teks: "A", Benar: benar == id*10+1},
+			{ID: id*10 + 2, Teks: "B", Benar: benar == id*10+2},
+		},
+	}
+}
+
+func TestJawabanBenar(t *testing.T) {
+	pilihan := soalPilihanUji(1, domain.SoalPilihanGanda, 12, 1)
+	benarSalah := soalPilihanUji(2, domain.SoalBenarSalah, 21, 1)
+	isian := domain.SoalUjian{ID: 3, Tipe: domain.SoalIsian, JawabanIsian: "Riba Fadl| riba  al-fadl |"}
+
+	tests := []struct {
+		nama    string
+		soal    domain.SoalUjian
+		jawaban domain.JawabanSoal
+		ingin   bool
+	}{
+		{"pilihan ganda benar", pilihan, domain.JawabanSoal{IDOpsi: 12}, true},
+		{"pilihan ganda salah", pilihan, domain.JawabanSoal{IDOpsi: 11}, false},
+		{"pilihan ganda opsi soal lain", pilihan, domain.JawabanSoal{IDOpsi: 21}, false},
+		{"pilihan ganda kosong", pilihan, domain.JawabanSoal{}, false},
+		{"benar salah benar", benarSalah, domain.JawabanSoal{IDOpsi: 21}, true},
+		{"benar salah salah", benarSalah, domain.JawabanSoal{IDOpsi: 22}, false},
+		{"isian kunci pertama", isian, domain.JawabanSoal{Teks: "riba fadl"}, true},
+		{"isian alternatif kedua", isian, domain.JawabanSoal{Teks: "Riba Al-Fadl"}, true},
+		{"isian spasi dan huruf besar", isian, domain.JawabanSoal{Teks: "  RIBA \t  FADL "}, true},
+		{"isian salah", isian, domain.JawabanSoal{Teks: "riba nasiah"}, false},
+		// Alternatif kosong di akhir kunci tidak membuat jawaban kosong benar.
+		{"isian kosong", isian, domain.JawabanSoal{Teks: "   "}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			if got := jawabanBenar(tt.soal, tt.jawaban); got != tt.ingin {
+				t.Errorf("jawabanBenar = %v, ingin %v", got, tt.ingin)
+			}
+		})
+	}
+}
+
+func TestNilaiPercobaanBerbobot(t *testing.T) {
+	bank := []domain.SoalUjian{
+		soalPilihanUji(1, domain.SoalPilihanGanda, 11, 1),
+		soalPilihanUji(2, domain.SoalPilihanGanda, 21, 2),
+		{ID: 3, Tipe: domain.SoalIsian, JawabanIsian: "mudharabah", Bobot: 3},
+		soalPilihanUji(4, domain.SoalBenarSalah, 41, 5),
+	}
+	percobaan := &domain.PercobaanUjian{
+		// Soal 4 tidak diundi; soal 9 sudah dihapus dari bank.
+		Soal: []int64{1, 2, 3, 9},
+		Jawaban: []domain.JawabanSoal{
+			{IDSoal: 1, IDOpsi: 12},
+			{IDSoal: 2, IDOpsi: 21},
+			{IDSoal: 3, Teks: "Mudharabah"},
+			{IDSoal: 4, IDOpsi: 41},
+		},
+	}
+
+	tests := []struct {
+		nama       string
+		nilaiLulus int
+		lulus      bool
+	}{
+		{"di atas nilai lulus", 80, true},
+		{"di bawah nilai lulus", 90, false},
+		{"nilai lulus bawaan", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			hasil := nilaiPercobaan(&domain.Ujian{NilaiLulus: tt.nilaiLulus}, percobaan, bank)
+			// Benar 2+3 dari total bobot 1+2+3.
+			if hasil.Percobaan.Skor != 83.33 {
+				t.Errorf("skor = %v, ingin 83.33", hasil.Percobaan.Skor)
+			}
+			if hasil.JumlahSoal != 3 || hasil.JumlahBenar != 2 {
+				t.Errorf("benar %d dari %d soal, ingin 2 dari 3", hasil.JumlahBenar, hasil.JumlahSoal)
+			}
+			if hasil.Percobaan.Lulus != tt.lulus {
+				t.Errorf("lulus = %v, ingin %v", hasil.Percobaan.Lulus, tt.lulus)
+			}
+		})
+	}
+	if percobaan.Skor != 0 {
+		t.Error("nilaiPercobaan mengubah percobaan")
+	}
+}
+
+func TestGabungJawaban(t *testing.T) {
+	percobaan := &domain.PercobaanUjian{
+		Soal:    []int64{1, 2},
+		Jawaban: []domain.JawabanSoal{{IDSoal: 1, IDOpsi: 11}},
+	}
+	gabungJawaban(percobaan, []domain.JawabanSoal{
+		{IDSoal: 1, IDOpsi: 12},
+		{IDSoal: 2, Teks: "  ijarah "},
+		{IDSoal: 7, IDOpsi: 71},
+	})
+	ingin := []domain.JawabanSoal{{IDSoal: 1, IDOpsi: 12}, {IDSoal: 2, Teks: "ijarah"}}
+	if len(percobaan.Jawaban) != len(ingin) {
+		t.Fatalf("jawaban = %+v, ingin %+v", percobaan.Jawaban, ingin)
+	}
+	for i := range ingin {
+		if percobaan.Jawaban[i] != ingin[i] {
+			t.Errorf("jawaban ke-%d = %+v, ingin %+v", i, percobaan.Jawaban[i], ingin[i])
+		}
+	}
+}
+
+func TestWaktuUjianHabis(t *testing.T) {
+	batas := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		nama     string
+		batas    *time.Time
+		sekarang time.Time
+		ingin    bool
+	}{
+		{"tanpa batas waktu", nil, batas.Add(24 * time.Hour), false},
+		{"sebelum batas", &batas, batas.Add(-time.Minute), false},
+		{"dalam toleransi", &batas, batas.Add(toleransiWaktuUjian), false},
+		{"lewat toleransi", &batas, batas.Add(toleransiWaktuUjian + time.Second), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			if got := waktuUjianHabis(&domain.PercobaanUjian{BatasWaktu: tt.batas}, tt.sekarang); got != tt.ingin {
+				t.Errorf("waktuUjianHabis = %v, ingin %v", got, tt.ingin)
+			}
+		})
+	}
+}
+
+func TestBatasPercobaan(t *testing.T) {
+	sekarang := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	selesai := sekarang.Add(-30 * time.Minute)
+	terakhir := &domain.PercobaanUjian{Status: domain.PercobaanSelesai, SelesaiPada: &selesai}
+
+	tests := []struct {
+		nama     string
+		ujian    domain.Ujian
+		jumlah   int
+		terakhir *domain.PercobaanUjian
+		ditolak  bool
+	}{
+		{"tanpa batas", domain.Ujian{}, 10, terakhir, false},
+		{"belum mencapai maksimal", domain.Ujian{MaksPercobaan: 3}, 2, terakhir, false},
+		{"maksimal tercapai", domain.Ujian{MaksPercobaan: 3}, 3, terakhir, true},
+		{"jeda belum lewat", domain.Ujian{JedaMenit: 60}, 1, terakhir, true},
+		{"jeda sudah lewat", domain.Ujian{JedaMenit: 30}, 1, terakhir, false},
+		{"percobaan pertama tanpa jeda", domain.Ujian{JedaMenit: 60}, 0, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			err := batasPercobaan(&tt.ujian, tt.jumlah, tt.terakhir, sekarang)
+			if tt.ditolak != (err != nil) {
+				t.Fatalf("batasPercobaan = %v, ingin ditolak %v", err, tt.ditolak)
+			}
+			if err != nil && !errors.Is(err, domain.ErrPercobaanDibatasi) {
+				t.Errorf("error %v bukan ErrPercobaanDibatasi", err)
+			}
+		})
+	}
+}
+
+func TestHasilTersimpanTidakDinilaiUlang(t *testing.T) {
+	bank := []domain.SoalUjian{soalPilihanUji(1, domain.SoalPilihanGanda, 11, 1)}
+	percobaan := &domain.PercobaanUjian{
+		Soal:    []int64{1},
+		Jawaban: []domain.JawabanSoal{{IDSoal: 1, IDOpsi: 11}},
+		Status:  domain.PercobaanSelesai,
+	}
+	dinilai := nilaiPercobaan(&domain.Ujian{}, percobaan, bank)
+	percobaan.Skor = dinilai.Percobaan.Skor
+	percobaan.Rincian = dinilai.Rincian
+
+	// Admin mengubah kunci jawaban setelah percobaan dikirim.
+	bank[0] = soalPilihanUji(1, domain.SoalPilihanGanda, 12, 1)
+	hasil := hasilTersimpan(&domain.Ujian{}, percobaan, bank)
+	if hasil.Percobaan.Skor != 100 || hasil.JumlahBenar != 1 || !hasil.Rincian[0].Benar {
+		t.Errorf("hasil = skor %v, benar %d, rincian %+v; ingin sesuai penilaian saat dikirim", hasil.Percobaan.Skor, hasil.JumlahBenar, hasil.Rincian)
+	}
+
+	// Percobaan lama tanpa rincian dinilai ulang tetapi skornya dipertahankan.
+	percobaan.Rincian = nil
+	hasil = hasilTersimpan(&domain.Ujian{}, percobaan, bank)
+	if hasil.Percobaan.Skor != 100 || hasil.Rincian[0].Benar {
+		t.Errorf("percobaan lama = skor %v, benar %v; ingin skor tersimpan dan penilaian ulang", hasil.Percobaan.Skor, hasil.Rincian[0].Benar)
+	}
+}
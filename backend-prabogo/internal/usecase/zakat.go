@@ -2,70 +2,170 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/averroes/backend-prabogo/internal/domain"
 )
 
 type ZakatUsecase struct {
-	portofolioRepo domain.PortofolioRepository
-	repo           domain.ZakatRepository
+	portofolio  *PortofolioUsecase
+	repo        domain.ZakatRepository
+	riwayat     domain.RiwayatHargaRepository
+	konfigurasi domain.KonfigurasiRepository
+	kurs        *KursUsecase
+	harga       *HargaLogamUsecase
 }
 
-func NewZakatUsecase(portofolioRepo domain.PortofolioRepository, repo domain.ZakatRepository) *ZakatUsecase {
-	return &ZakatUsecase{portofolioRepo: portofolioRepo, repo: repo}
+func NewZakatUsecase(portofolio *PortofolioUsecase, repo domain.ZakatRepository, riwayat domain.RiwayatHargaRepository, konfigurasi domain.KonfigurasiRepository, kurs *KursUsecase, harga *HargaLogamUsecase) *ZakatUsecase {
+	return &ZakatUsecase{portofolio: portofolio, repo: repo, riwayat: riwayat, konfigurasi: konfigurasi, kurs: kurs, harga: harga}
 }
 
-func (u *ZakatUsecase) Ringkasan(ctx context.Context, idPengguna int64) (*domain.ZakatRingkasan, error) {
-	portofolio, err := u.portofolioRepo.DaftarPortofolio(ctx, idPengguna)
+// Ringkasan hanya menghitung; perhitungan disimpan lewat Simpan. Zakat maal
+// wajib bila harta hari ini mencapai nisab dan telah genap satu haul. Standar
+// nisab kosong mengikuti preferensi pengguna atau konfigurasi. Dasar zakat
+// adalah harta bersih: portofolio ditambah kas dan tabungan di luar
+// portofolio, dikurangi utang jatuh tempo.
+func (u *ZakatUsecase) Ringkasan(ctx context.Context, idPengguna int64, mataUang, standarNisab string) (*domain.ZakatRingkasan, error) {
+	ringkasan, _, err := u.ringkasan(ctx, idPengguna, mataUang, standarNisab)
+	return ringkasan, err
+}
+
+func (u *ZakatUsecase) ringkasan(ctx context.Context, idPengguna int64, mataUang, standarNisab string) (*domain.ZakatRingkasan, []titikHaul, error) {
+	tampilan, err := u.portofolio.MataUangTampilan(ctx, idPengguna, mataUang)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	portofolio, err := u.portofolio.Daftar(ctx, idPengguna, tampilan, "", false)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	total := 0.0
+	var aset domain.Uang
+	rincian := make([]domain.RincianZakat, 0, len(portofolio))
 	for _, item := range portofolio {
-		total += item.NilaiSaatIni
+		aset += item.NilaiTampilan
+		rincian = append(rincian, domain.RincianZakat{
+			Kelompok:          domain.RincianAset,
+			Simbol:            item.Simbol,
+			NamaAset:          item.NamaAset,
+			Kategori:          item.Kategori,
+			Jumlah:            item.Jumlah,
+			Nilai:             item.NilaiTampilan,
+			KursTidakTersedia: item.KursTidakTersedia,
+		})
 	}
 
-	hargaEmas, err := u.repo.HargaEmasTerbaru(ctx)
+	konverter, err := u.kurs.Konverter(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	asetLain, pengurang, rincianLain, err := u.rincianHarta(ctx, idPengguna, tampilan, konverter)
+	if err != nil {
+		return nil, nil, err
 	}
-	if hargaEmas == nil {
-		hargaEmas = &domain.HargaEmas{HargaPerGram: 0}
+	aset += asetLain
+	rincian = append(rincian, rincianLain...)
+	total := aset - pengurang
+	if total < 0 {
+		total = 0
 	}
 
-	nisab := hargaEmas.HargaPerGram * 85
-	persen := 0.025
-	zakat := total * persen
-	wajib := total >= nisab && nisab > 0
+	parameter, err := u.parameter(ctx, idPengguna, standarNisab, tampilan, konverter)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	ringkasan := &domain.ZakatRingkasan{
-		TotalNilai:    total,
-		Nisab:         nisab,
-		PersenZakat:   persen * 100,
-		ZakatTerhitung: zakat,
-		WajibZakat:    wajib,
+	nisab, err := nisabTahunan(parameter)
+	if err != nil {
+		return nil, nil, err
+	}
+	zakat, err := hitungZakat(parameter, total)
+	if err != nil {
+		return nil, nil, err
+	}
+	mencapai := total >= nisab && nisab > 0
+	haul, tercapai, err := u.statusHaul(ctx, idPengguna, parameter, konverter, total, nisab, asetLain-pengurang, time.Now())
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if wajib {
-		_ = u.repo.SimpanRiwayatZakat(ctx, &domain.ZakatRiwayat{
-			IDPengguna:    idPengguna,
-			TotalNilai:    total,
-			Nisab:         nisab,
-			PersenZakat:   persen * 100,
-			ZakatTerhitung: zakat,
-			DibuatPada:    time.Now(),
-		})
+	return &domain.ZakatRingkasan{
+		TotalAset:        aset,
+		TotalPengurang:   pengurang,
+		TotalNilai:       total,
+		Nisab:            nisab,
+		PersenZakat:      parameter.PersenZakat,
+		ZakatTerhitung:   zakat,
+		WajibZakat:       mencapai && haul.HaulTercapai,
+		MataUang:         tampilan,
+		HargaEmasPerGram: parameter.HargaEmasPerGram,
+		MencapaiNisab:    mencapai,
+		Parameter:        parameter,
+		Haul:             haul,
+		Rincian:          rincian,
+		Peringatan:       peringatanKurs(rincian, tampilan),
+	}, tercapai, nil
+}
+
+// peringatanKurs melaporkan harta yang tidak ikut dihitung karena kursnya ke
+// mata uang tampilan belum tersedia.
+func peringatanKurs(rincian []domain.RincianZakat, tampilan string) []string {
+	var nama []string
+	for _, r := range rincian {
+		if r.KursTidakTersedia {
+			nama = append(nama, r.NamaAset)
+		}
+	}
+	if len(nama) == 0 {
+		return nil
 	}
+	return []string{fmt.Sprintf("Kurs ke %s belum tersedia untuk %s; nilainya tidak dihitung sehingga zakat bisa lebih rendah dari seharusnya", tampilan, strings.Join(nama, ", "))}
+}
 
-	return ringkasan, nil
+// Simpan mencatat perhitungan saat ini beserta rinciannya. Penyimpanan ulang
+// di hari yang sama memperbarui catatan hari itu alih-alih menambah baris.
+func (u *ZakatUsecase) Simpan(ctx context.Context, idPengguna int64, mataUang, standarNisab string) (*domain.ZakatRiwayat, error) {
+	ringkasan, err := u.Ringkasan(ctx, idPengguna, mataUang, standarNisab)
+	if err != nil {
+		return nil, err
+	}
+	sekarang := time.Now()
+	riwayat := &domain.ZakatRiwayat{
+		IDPengguna:       idPengguna,
+		TotalNilai:       ringkasan.TotalNilai,
+		Nisab:            ringkasan.Nisab,
+		PersenZakat:      ringkasan.PersenZakat,
+		ZakatTerhitung:   ringkasan.ZakatTerhitung,
+		MataUang:         ringkasan.MataUang,
+		Jenis:            domain.ZakatMaal,
+		Tanggal:          awalHari(sekarang),
+		WajibZakat:       ringkasan.WajibZakat,
+		HargaEmasPerGram: ringkasan.HargaEmasPerGram,
+		Rincian:          ringkasan.Rincian,
+		Parameter:        ringkasan.Parameter,
+		DibuatPada:       sekarang,
+	}
+	if err := u.repo.SimpanRiwayatZakat(ctx, riwayat); err != nil {
+		return nil, err
+	}
+	return riwayat, nil
 }
 
-func (u *ZakatUsecase) Riwayat(ctx context.Context, idPengguna int64) ([]domain.ZakatRiwayat, error) {
-	return u.repo.DaftarRiwayatZakat(ctx, idPengguna)
+func (u *ZakatUsecase) Haul(ctx context.Context, idPengguna int64, mataUang, standarNisab string) (*domain.StatusHaul, error) {
+	ringkasan, err := u.Ringkasan(ctx, idPengguna, mataUang, standarNisab)
+	if err != nil {
+		return nil, err
+	}
+	return ringkasan.Haul, nil
 }
 
-func (u *ZakatUsecase) HargaEmas(ctx context.Context) (*domain.HargaEmas, error) {
-	return u.repo.HargaEmasTerbaru(ctx)
+func (u *ZakatUsecase) Riwayat(ctx context.Context, idPengguna int64, jenis string) ([]domain.ZakatRiwayat, error) {
+	jenis = strings.ToLower(strings.TrimSpace(jenis))
+	if jenis != "" && !domain.JenisZakatValid(jenis) {
+		return nil, errors.New("jenis zakat tidak dikenal")
+	}
+	return u.repo.DaftarRiwayatZakat(ctx, idPengguna, jenis)
 }
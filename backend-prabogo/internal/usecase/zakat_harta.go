@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func (u *ZakatUsecase) DaftarHarta(ctx context.Context, idPengguna int64) ([]domain.HartaZakat, error) {
+	return u.repo.DaftarHartaZakat(ctx, idPengguna)
+}
+
+func (u *ZakatUsecase) TambahHarta(ctx context.Context, harta *domain.HartaZakat) (*domain.HartaZakat, error) {
+	if err := u.validasiHarta(ctx, harta); err != nil {
+		return nil, err
+	}
+	sekarang := time.Now()
+	harta.DibuatPada = sekarang
+	harta.DiperbaruiPada = sekarang
+	if err := u.repo.SimpanHartaZakat(ctx, harta); err != nil {
+		return nil, err
+	}
+	return harta, nil
+}
+
+func (u *ZakatUsecase) PerbaruiHarta(ctx context.Context, harta *domain.HartaZakat) (*domain.HartaZakat, error) {
+	lama, err := u.repo.AmbilHartaZakat(ctx, harta.ID, harta.IDPengguna)
+	if err != nil {
+		return nil, err
+	}
+	if lama == nil {
+		return nil, errors.New("harta tidak ditemukan")
+	}
+	if err := u.validasiHarta(ctx, harta); err != nil {
+		return nil, err
+	}
+	harta.DibuatPada = lama.DibuatPada
+	harta.DiperbaruiPada = time.Now()
+	if err := u.repo.PerbaruiHartaZakat(ctx, harta); err != nil {
+		return nil, err
+	}
+	return harta, nil
+}
+
+func (u *ZakatUsecase) HapusHarta(ctx context.Context, id int64, idPengguna int64) error {
+	harta, err := u.repo.AmbilHartaZakat(ctx, id, idPengguna)
+	if err != nil {
+		return err
+	}
+	if harta == nil {
+		return errors.New("harta tidak ditemukan")
+	}
+	return u.repo.HapusHartaZakat(ctx, id, idPengguna)
+}
+
+// validasiHarta menurunkan kelompok dari jenis sehingga klien tidak dapat
+// mencatat utang sebagai aset atau sebaliknya.
+func (u *ZakatUsecase) validasiHarta(ctx context.Context, harta *domain.HartaZakat) error {
+	harta.Jenis = strings.ToLower(strings.TrimSpace(harta.Jenis))
+	harta.Kelompok = domain.KelompokHarta(harta.Jenis)
+	if harta.Kelompok == "" {
+		return errors.New("jenis harta harus kas, tabungan, deposito, piutang, utang, atau cicilan")
+	}
+	harta.Nama = strings.TrimSpace(harta.Nama)
+	if harta.Nama == "" {
+		return errors.New("nama harta wajib diisi")
+	}
+	if harta.Nilai < 0 {
+		return errors.New("nilai harta tidak boleh negatif")
+	}
+	harta.Catatan = strings.TrimSpace(harta.Catatan)
+	if harta.MataUang == "" {
+		pengguna, err := u.portofolio.penggunaRepo.AmbilPenggunaByID(ctx, harta.IDPengguna)
+		if err != nil {
+			return err
+		}
+		harta.MataUang = domain.MataUangIDR
+		if pengguna != nil && pengguna.MataUang != "" {
+			harta.MataUang = pengguna.MataUang
+		}
+		return nil
+	}
+	harta.MataUang = domain.NormalisasiMataUang(harta.MataUang, "")
+	if harta.MataUang == "" {
+		return errors.New("kode mata uang harus tiga huruf ISO 4217")
+	}
+	return nil
+}
+
+// rincianHarta mengonversi harta non-portofolio ke mata uang tampilan dan
+// mengembalikan total aset, total pengurang, serta baris rinciannya. Nilai
+// pengurang pada rincian disimpan negatif agar jumlah rincian sama dengan
+// harta bersih. Harta tanpa kurs bernilai nol dan ditandai KursTidakTersedia.
+func (u *ZakatUsecase) rincianHarta(ctx context.Context, idPengguna int64, tampilan string, konverter *KonverterKurs) (domain.Uang, domain.Uang, []domain.RincianZakat, error) {
+	daftar, err := u.repo.DaftarHartaZakat(ctx, idPengguna)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	var aset, pengurang domain.Uang
+	rincian := make([]domain.RincianZakat, 0, len(daftar))
+	for _, harta := range daftar {
+		nilai, err := konverter.Konversi(harta.Nilai, domain.NormalisasiMataUang(harta.MataUang, tampilan), tampilan)
+		baris := domain.RincianZakat{
+			Kelompok:          harta.Kelompok,
+			NamaAset:          harta.Nama,
+			Kategori:          harta.Jenis,
+			Nilai:             nilai,
+			KursTidakTersedia: err != nil,
+		}
+		if harta.Kelompok == domain.RincianPengurang {
+			pengurang += nilai
+			baris.Nilai = -nilai
+		} else {
+			aset += nilai
+		}
+		rincian = append(rincian, baris)
+	}
+	return aset, pengurang, rincian, nil
+}
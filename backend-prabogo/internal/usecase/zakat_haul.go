@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+	"github.com/averroes/backend-prabogo/pkg/hijri"
+)
+
+type titikHaul struct {
+	tanggal time.Time
+	nilai   domain.Uang
+	nisab   domain.Uang
+	// sebagian menandai snapshot yang tidak menilai semua aset karena kurs
+	// belum ada; nilainya hanya batas bawah.
+	sebagian bool
+}
+
+// SimpanSnapshot mencatat nilai portofolio hari ini beserta harta bersih
+// zakatnya (portofolio ditambah harta di luar portofolio dikurangi utang) agar
+// haul ditelusuri dari dasar yang sama dengan perhitungan zakat. Pemanggilan
+// berulang di hari yang sama menimpa snapshot hari itu. Aset dan harta tanpa
+// kurs tidak ikut dinilai dan dicatat pada AsetTanpaKurs.
+func (u *ZakatUsecase) SimpanSnapshot(ctx context.Context, idPengguna int64) (*domain.SnapshotPortofolio, error) {
+	snapshot, err := u.portofolio.susunSnapshot(ctx, idPengguna)
+	if err != nil {
+		return nil, err
+	}
+	konverter, err := u.kurs.Konverter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	aset, pengurang, rincian, err := u.rincianHarta(ctx, idPengguna, snapshot.MataUang, konverter)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rincian {
+		if r.KursTidakTersedia {
+			snapshot.AsetTanpaKurs++
+		}
+	}
+	nilaiZakat := max(snapshot.TotalNilai+aset-pengurang, 0)
+	snapshot.NilaiZakat = &nilaiZakat
+	if err := u.portofolio.repo.SimpanSnapshotPortofolio(ctx, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// SnapshotSemua menjalankan SimpanSnapshot untuk setiap pengguna yang memiliki
+// transaksi portofolio atau harta zakat. Kegagalan satu pengguna tidak
+// menghentikan pengguna lain; seluruh error digabung dan dikembalikan bersama
+// jumlah snapshot yang berhasil.
+func (u *ZakatUsecase) SnapshotSemua(ctx context.Context) (int, error) {
+	daftar, err := u.portofolio.repo.DaftarIDPenggunaPortofolio(ctx)
+	if err != nil {
+		return 0, err
+	}
+	pemilikHarta, err := u.repo.DaftarIDPenggunaHartaZakat(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range pemilikHarta {
+		if !slices.Contains(daftar, id) {
+			daftar = append(daftar, id)
+		}
+	}
+	berhasil := 0
+	var errs []error
+	for _, id := range daftar {
+		if _, err := u.SimpanSnapshot(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("snapshot pengguna %d: %w", id, err))
+			continue
+		}
+		berhasil++
+	}
+	return berhasil, errors.Join(errs...)
+}
+
+// statusHaul menelusuri harta bersih zakat dari snapshot harian dengan nisab
+// pada tanggal masing-masing (harga logam standar nisab yang berlaku saat
+// itu). Snapshot lama yang belum menyimpan harta bersih disesuaikan dengan
+// hartaLain, yaitu harta di luar portofolio dikurangi utang saat ini. Nilai
+// hari ini memakai hasil perhitungan terkini alih-alih snapshot hari ini.
+func (u *ZakatUsecase) statusHaul(ctx context.Context, idPengguna int64, parameter *domain.ParameterZakat, konverter *KonverterKurs, totalHariIni, nisabHariIni, hartaLain domain.Uang, sekarang time.Time) (*domain.StatusHaul, []titikHaul, error) {
+	tampilan := parameter.MataUang
+	snapshot, err := u.portofolio.repo.DaftarSnapshotPortofolio(ctx, idPengguna, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, nil, err
+	}
+	harga, err := u.riwayat.DaftarHargaLogam(ctx, parameter.StandarNisab, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nisabPada := func(tanggal time.Time) (domain.Uang, error) {
+		var acuan *domain.HargaEmas
+		for i := range harga {
+			if tanggalLokal(harga[i].Tanggal).After(tanggal) {
+				break
+			}
+			acuan = &harga[i]
+		}
+		if acuan == nil && len(harga) > 0 {
+			acuan = &harga[0]
+		}
+		if acuan == nil {
+			return nisabHariIni, nil
+		}
+		perGram, err := konverter.Konversi(acuan.HargaPerGram, domain.NormalisasiMataUang(acuan.MataUang, domain.MataUangIDR), tampilan)
+		if err != nil {
+			return 0, err
+		}
+		nisab, err := perGram.Kali(gramNisab(parameter))
+		if err != nil {
+			return 0, err
+		}
+		return nisab.Bulatkan(tampilan), nil
+	}
+
+	hariIni := awalHari(sekarang)
+	titik := make([]titikHaul, 0, len(snapshot)+1)
+	for _, s := range snapshot {
+		tanggal := tanggalLokal(s.Tanggal)
+		if !tanggal.Before(hariIni) {
+			continue
+		}
+		asal := domain.NormalisasiMataUang(s.MataUang, domain.MataUangIDR)
+		var nilai domain.Uang
+		if s.NilaiZakat != nil {
+			if nilai, err = konverter.Konversi(*s.NilaiZakat, asal, tampilan); err != nil {
+				return nil, nil, err
+			}
+		} else {
+			portofolio, err := konverter.Konversi(s.TotalNilai, asal, tampilan)
+			if err != nil {
+				return nil, nil, err
+			}
+			nilai = max(portofolio+hartaLain, 0)
+		}
+		nisab, err := nisabPada(tanggal)
+		if err != nil {
+			return nil, nil, err
+		}
+		titik = append(titik, titikHaul{tanggal: tanggal, nilai: nilai, nisab: nisab, sebagian: s.AsetTanpaKurs > 0})
+	}
+	titik = append(titik, titikHaul{tanggal: hariIni, nilai: totalHariIni, nisab: nisabHariIni})
+	status, tercapai := hitungHaul(titik, hariIni)
+	return status, tercapai, nil
+}
+
+// hitungHaul menerapkan syarat haul: harta harus tetap di atas nisab selama
+// satu tahun Hijriah. Turun di bawah nisab sebelum jatuh tempo memutus haul;
+// setiap haul yang genap setahun langsung memulai tahun haul berikutnya.
+// HaulTercapai hanya berlaku selama rangkaian haul itu belum terputus; haul
+// lama sebelum harta turun di bawah nisab tetap dilaporkan sebagai
+// HaulTerakhir. Snapshot sebagian yang berada di bawah nisab dilewati karena
+// tidak membuktikan harta benar-benar turun. Selain status, dikembalikan setiap haul yang tercapai beserta
+// nilai harta pada titik pertama setelah jatuh tempo.
+func hitungHaul(titik []titikHaul, hariIni time.Time) (*domain.StatusHaul, []titikHaul) {
+	var awal, terakhir, putus time.Time
+	var tercapai []titikHaul
+	for _, t := range titik {
+		if t.nisab > 0 && t.nilai >= t.nisab {
+			if awal.IsZero() {
+				awal = t.tanggal
+			}
+			for {
+				tempo := hijri.SatuTahunSetelah(awal)
+				if t.tanggal.Before(tempo) {
+					break
+				}
+				terakhir, awal = tempo, tempo
+				tercapai = append(tercapai, titikHaul{tanggal: tempo, nilai: t.nilai, nisab: t.nisab})
+			}
+			continue
+		}
+		if t.sebagian {
+			continue
+		}
+		if !awal.IsZero() {
+			putus = t.tanggal
+		}
+		awal = time.Time{}
+	}
+
+	terputus := !putus.IsZero() && (terakhir.IsZero() || putus.After(terakhir))
+	status := &domain.StatusHaul{
+		TanggalHijriah: hijri.DariMasehi(hariIni).String(),
+		HaulTercapai:   !terakhir.IsZero() && !terputus,
+		HaulTerputus:   terputus,
+	}
+	if !awal.IsZero() {
+		tempo := hijri.SatuTahunSetelah(awal)
+		status.AwalHaul = &awal
+		status.AwalHaulHijriah = hijri.DariMasehi(awal).String()
+		status.JatuhTempo = &tempo
+		status.JatuhTempoHijriah = hijri.DariMasehi(tempo).String()
+		status.SisaHari = int(tempo.Sub(hariIni).Hours()/24 + 0.5)
+	}
+	if !terakhir.IsZero() {
+		status.HaulTerakhir = &terakhir
+		status.HaulTerakhirHijriah = hijri.DariMasehi(terakhir).String()
+	}
+	if status.HaulTerputus {
+		status.TerputusPada = &putus
+	}
+	return status, tercapai
+}
+
+// tanggalLokal membaca kolom DATE sebagai tanggal kalender di zona lokal.
+func tanggalLokal(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+	"github.com/averroes/backend-prabogo/pkg/hijri"
+)
+
+func TestHitungHaul(t *testing.T) {
+	const nisab = domain.Uang(1000)
+	mulai := time.Date(2023, 7, 19, 0, 0, 0, 0, time.Local)
+	hari := func(n int) time.Time { return mulai.AddDate(0, 0, n) }
+	tempo1 := hijri.SatuTahunSetelah(mulai)
+	tempo2 := hijri.SatuTahunSetelah(tempo1)
+
+	type titikUji struct {
+		hari  int
+		nilai domain.Uang
+	}
+	tests := []struct {
+		nama         string
+		titik        []titikUji
+		sebagian     map[int]bool // hari snapshot yang tidak menilai semua aset
+		hariIni      int
+		tercapai     bool
+		terputus     bool
+		jumlahHaul   int
+		awal         *time.Time
+		haulTerakhir *time.Time
+		terputusPada *time.Time
+	}{
+		{
+			nama:    "tidak pernah mencapai nisab",
+			titik:   []titikUji{{0, 500}, {200, 900}, {400, 999}},
+			hariIni: 400,
+		},
+		{
+			nama:    "haul berjalan belum genap setahun",
+			titik:   []titikUji{{0, 1500}, {100, 1200}, {300, 1000}},
+			hariIni: 300,
+			awal:    &mulai,
+		},
+		{
+			nama:         "haul genap setahun",
+			titik:        []titikUji{{0, 1500}, {200, 2000}, {360, 2500}},
+			hariIni:      360,
+			tercapai:     true,
+			jumlahHaul:   1,
+			awal:         &tempo1,
+			haulTerakhir: &tempo1,
+		},
+		{
+			nama:         "terputus sebelum jatuh tempo lalu mulai ulang",
+			titik:        []titikUji{{0, 1500}, {100, 800}, {200, 1500}, {360, 1500}},
+			hariIni:      360,
+			terputus:     true,
+			awal:         ptrWaktu(hari(200)),
+			terputusPada: ptrWaktu(hari(100)),
+		},
+		{
+			nama:         "haul tercapai lalu terputus dan mulai ulang",
+			titik:        []titikUji{{0, 1500}, {360, 1500}, {400, 500}, {450, 1500}},
+			hariIni:      450,
+			terputus:     true,
+			jumlahHaul:   1,
+			awal:         ptrWaktu(hari(450)),
+			haulTerakhir: &tempo1,
+			terputusPada: ptrWaktu(hari(400)),
+		},
+		{
+			nama:         "haul tercapai lalu turun di bawah nisab hari ini",
+			titik:        []titikUji{{0, 1500}, {360, 1500}, {400, 500}},
+			hariIni:      400,
+			terputus:     true,
+			jumlahHaul:   1,
+			haulTerakhir: &tempo1,
+			terputusPada: ptrWaktu(hari(400)),
+		},
+		{
+			// Aset berdenominasi asing belum berkurs sehingga snapshot hari
+			// ke-100 hanya batas bawah; haul tidak boleh dianggap terputus.
+			nama:         "snapshot sebagian di bawah nisab tidak memutus haul",
+			titik:        []titikUji{{0, 1500}, {100, 300}, {360, 1500}},
+			sebagian:     map[int]bool{100: true},
+			hariIni:      360,
+			tercapai:     true,
+			jumlahHaul:   1,
+			awal:         &tempo1,
+			haulTerakhir: &tempo1,
+		},
+		{
+			nama:     "snapshot sebagian di atas nisab tetap memulai haul",
+			titik:    []titikUji{{0, 500}, {100, 1200}, {300, 1000}},
+			sebagian: map[int]bool{100: true},
+			hariIni:  300,
+			awal:     ptrWaktu(hari(100)),
+		},
+		{
+			nama:         "dua haul berturut-turut",
+			titik:        []titikUji{{0, 1500}, {200, 1500}, {400, 1500}, {720, 1500}},
+			hariIni:      720,
+			tercapai:     true,
+			jumlahHaul:   2,
+			awal:         &tempo2,
+			haulTerakhir: &tempo2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			titik := make([]titikHaul, 0, len(tt.titik))
+			for _, ti := range tt.titik {
+				titik = append(titik, titikHaul{tanggal: hari(ti.hari), nilai: ti.nilai, nisab: nisab, sebagian: tt.sebagian[ti.hari]})
+			}
+			status, daftarHaul := hitungHaul(titik, hari(tt.hariIni))
+			if status.HaulTercapai != tt.tercapai {
+				t.Errorf("HaulTercapai = %v, ingin %v", status.HaulTercapai, tt.tercapai)
+			}
+			if status.HaulTerputus != tt.terputus {
+				t.Errorf("HaulTerputus = %v, ingin %v", status.HaulTerputus, tt.terputus)
+			}
+			if len(daftarHaul) != tt.jumlahHaul {
+				t.Errorf("jumlah haul tercapai = %d, ingin %d", len(daftarHaul), tt.jumlahHaul)
+			}
+			cekWaktu(t, "AwalHaul", status.AwalHaul, tt.awal)
+			cekWaktu(t, "HaulTerakhir", status.HaulTerakhir, tt.haulTerakhir)
+			cekWaktu(t, "TerputusPada", status.TerputusPada, tt.terputusPada)
+		})
+	}
+}
+
+func ptrWaktu(t time.Time) *time.Time {
+	return &t
+}
+
+func cekWaktu(t *testing.T, nama string, dapat, ingin *time.Time) {
+	t.Helper()
+	switch {
+	case dapat == nil && ingin == nil:
+	case dapat == nil || ingin == nil:
+		t.Errorf("%s = %v, ingin %v", nama, dapat, ingin)
+	case !dapat.Equal(*ingin):
+		t.Errorf("%s = %s, ingin %s", nama, dapat.Format("2006-01-02"), ingin.Format("2006-01-02"))
+	}
+}
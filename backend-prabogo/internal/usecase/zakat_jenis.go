@@ -0,0 +1,305 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+const (
+	kgFitrahPerJiwa   = 2.5
+	bulanPerTahunHaul = 12
+)
+
+// HitungPenghasilan menghitung zakat profesi bulanan dengan nisab tahunan
+// zakat maal yang dibagi dua belas. Kebutuhan pokok dan cicilan bersifat
+// opsional; bila kosong zakat dihitung dari penghasilan bruto.
+func (u *ZakatUsecase) HitungPenghasilan(ctx context.Context, idPengguna int64, masukan domain.MasukanZakatPenghasilan) (*domain.ZakatRiwayat, error) {
+	if masukan.PenghasilanBulanan < 0 || masukan.PenghasilanLain < 0 || masukan.KebutuhanPokok < 0 || masukan.Cicilan < 0 {
+		return nil, errors.New("nilai penghasilan dan pengurang tidak boleh negatif")
+	}
+	if masukan.PenghasilanBulanan == 0 && masukan.PenghasilanLain == 0 {
+		return nil, errors.New("penghasilan bulanan wajib diisi")
+	}
+	tampilan, parameter, err := u.persiapan(ctx, idPengguna, &masukan.MataUang, &masukan.StandarNisab)
+	if err != nil {
+		return nil, err
+	}
+
+	riwayat, err := zakatPenghasilan(parameter, masukan, tampilan)
+	if err != nil {
+		return nil, err
+	}
+	return u.simpanJenis(ctx, idPengguna, tampilan, riwayat, masukan)
+}
+
+func zakatPenghasilan(parameter *domain.ParameterZakat, masukan domain.MasukanZakatPenghasilan, tampilan string) (*domain.ZakatRiwayat, error) {
+	dasar := masukan.PenghasilanBulanan + masukan.PenghasilanLain - masukan.KebutuhanPokok - masukan.Cicilan
+	if dasar < 0 {
+		dasar = 0
+	}
+	nisab, err := nisabTahunan(parameter)
+	if err != nil {
+		return nil, err
+	}
+	if nisab, err = nisab.Bagi(bulanPerTahunHaul); err != nil {
+		return nil, err
+	}
+	nisab = nisab.Bulatkan(tampilan)
+	zakat, err := hitungZakat(parameter, dasar)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ZakatRiwayat{
+		Jenis:            domain.ZakatPenghasilan,
+		TotalNilai:       dasar,
+		Nisab:            nisab,
+		PersenZakat:      parameter.PersenZakat,
+		ZakatTerhitung:   zakat,
+		WajibZakat:       nisab > 0 && dasar >= nisab,
+		HargaEmasPerGram: parameter.HargaEmasPerGram,
+		Parameter:        parameter,
+		Rincian: []domain.RincianZakat{
+			{NamaAset: "Penghasilan bulanan", Kelompok: domain.RincianAset, Nilai: masukan.PenghasilanBulanan},
+			{NamaAset: "Penghasilan lain", Kelompok: domain.RincianAset, Nilai: masukan.PenghasilanLain},
+			{NamaAset: "Kebutuhan pokok", Kelompok: domain.RincianPengurang, Nilai: -masukan.KebutuhanPokok},
+			{NamaAset: "Cicilan", Kelompok: domain.RincianPengurang, Nilai: -masukan.Cicilan},
+		},
+	}, nil
+}
+
+// HitungPerdagangan menghitung zakat tijarah dari persediaan, kas usaha dan
+// piutang lancar dikurangi utang jatuh tempo, dengan nisab zakat maal.
+func (u *ZakatUsecase) HitungPerdagangan(ctx context.Context, idPengguna int64, masukan domain.MasukanZakatPerdagangan) (*domain.ZakatRiwayat, error) {
+	if masukan.Persediaan < 0 || masukan.KasUsaha < 0 || masukan.Piutang < 0 || masukan.Utang < 0 {
+		return nil, errors.New("nilai aset dan utang usaha tidak boleh negatif")
+	}
+	tampilan, parameter, err := u.persiapan(ctx, idPengguna, &masukan.MataUang, &masukan.StandarNisab)
+	if err != nil {
+		return nil, err
+	}
+
+	riwayat, err := zakatPerdagangan(parameter, masukan)
+	if err != nil {
+		return nil, err
+	}
+	return u.simpanJenis(ctx, idPengguna, tampilan, riwayat, masukan)
+}
+
+func zakatPerdagangan(parameter *domain.ParameterZakat, masukan domain.MasukanZakatPerdagangan) (*domain.ZakatRiwayat, error) {
+	dasar := masukan.Persediaan + masukan.KasUsaha + masukan.Piutang - masukan.Utang
+	if dasar < 0 {
+		dasar = 0
+	}
+	nisab, err := nisabTahunan(parameter)
+	if err != nil {
+		return nil, err
+	}
+	zakat, err := hitungZakat(parameter, dasar)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ZakatRiwayat{
+		Jenis:            domain.ZakatPerdagangan,
+		TotalNilai:       dasar,
+		Nisab:            nisab,
+		PersenZakat:      parameter.PersenZakat,
+		ZakatTerhitung:   zakat,
+		WajibZakat:       nisab > 0 && dasar >= nisab,
+		HargaEmasPerGram: parameter.HargaEmasPerGram,
+		Parameter:        parameter,
+		Rincian: []domain.RincianZakat{
+			{NamaAset: "Persediaan barang dagang", Kelompok: domain.RincianAset, Nilai: masukan.Persediaan},
+			{NamaAset: "Kas usaha", Kelompok: domain.RincianAset, Nilai: masukan.KasUsaha},
+			{NamaAset: "Piutang lancar", Kelompok: domain.RincianAset, Nilai: masukan.Piutang},
+			{NamaAset: "Utang jatuh tempo", Kelompok: domain.RincianPengurang, Nilai: -masukan.Utang},
+		},
+	}, nil
+}
+
+// HitungFitrah menghitung zakat fitrah per jiwa dari harga beras. Zakat
+// fitrah tidak bernisab sehingga Nisab dan PersenZakat dicatat nol.
+func (u *ZakatUsecase) HitungFitrah(ctx context.Context, idPengguna int64, masukan domain.MasukanZakatFitrah) (*domain.ZakatRiwayat, error) {
+	if masukan.JumlahJiwa <= 0 {
+		return nil, errors.New("jumlah jiwa minimal satu")
+	}
+	if masukan.HargaBerasPerKg <= 0 {
+		return nil, errors.New("harga beras per kg wajib diisi")
+	}
+	if masukan.KgPerJiwa < 0 {
+		return nil, errors.New("takaran per jiwa tidak boleh negatif")
+	}
+	if masukan.KgPerJiwa == 0 {
+		masukan.KgPerJiwa = kgFitrahPerJiwa
+	}
+	tampilan, err := u.portofolio.MataUangTampilan(ctx, idPengguna, masukan.MataUang)
+	if err != nil {
+		return nil, err
+	}
+	masukan.MataUang = tampilan
+
+	riwayat, err := zakatFitrah(masukan, tampilan)
+	if err != nil {
+		return nil, err
+	}
+	return u.simpanJenis(ctx, idPengguna, tampilan, riwayat, masukan)
+}
+
+// zakatFitrah membulatkan zakat per jiwa ke digit mata uang sebelum dikali
+// jumlah jiwa sehingga total selalu kelipatan zakat per jiwa.
+func zakatFitrah(masukan domain.MasukanZakatFitrah, tampilan string) (*domain.ZakatRiwayat, error) {
+	perJiwa, err := masukan.HargaBerasPerKg.Kali(masukan.KgPerJiwa)
+	if err != nil {
+		return nil, err
+	}
+	perJiwa = perJiwa.Bulatkan(tampilan)
+	total, err := perJiwa.Kali(float64(masukan.JumlahJiwa))
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ZakatRiwayat{
+		Jenis:          domain.ZakatFitrah,
+		TotalNilai:     total,
+		ZakatTerhitung: total,
+		WajibZakat:     true,
+		Rincian: []domain.RincianZakat{
+			{NamaAset: "Beras per jiwa (kg)", Kelompok: domain.RincianAset, Jumlah: masukan.KgPerJiwa, Nilai: perJiwa},
+			{NamaAset: "Jumlah jiwa", Kelompok: domain.RincianAset, Jumlah: float64(masukan.JumlahJiwa), Nilai: total},
+		},
+	}, nil
+}
+
+// HitungEmasPerak menghitung zakat emas dan perak simpanan. Masing-masing logam
+// dibandingkan dengan nisab gramnya sendiri dan hanya logam yang mencapai nisab
+// yang dizakati. Harga kosong memakai harga logam terbaru.
+func (u *ZakatUsecase) HitungEmasPerak(ctx context.Context, idPengguna int64, masukan domain.MasukanZakatEmasPerak) (*domain.ZakatRiwayat, error) {
+	if masukan.GramEmas < 0 || masukan.GramPerak < 0 || masukan.HargaEmasPerGram < 0 || masukan.HargaPerakPerGram < 0 {
+		return nil, errors.New("berat dan harga tidak boleh negatif")
+	}
+	if masukan.GramEmas == 0 && masukan.GramPerak == 0 {
+		return nil, errors.New("berat emas atau perak wajib diisi")
+	}
+	var standar string
+	tampilan, parameter, err := u.persiapan(ctx, idPengguna, &masukan.MataUang, &standar)
+	if err != nil {
+		return nil, err
+	}
+	if masukan.HargaEmasPerGram == 0 && masukan.GramEmas > 0 {
+		if masukan.HargaEmasPerGram = parameter.HargaEmasPerGram; masukan.HargaEmasPerGram == 0 {
+			return nil, errors.New("harga emas belum tersedia, isi harga emas per gram")
+		}
+	}
+	if masukan.HargaPerakPerGram == 0 && masukan.GramPerak > 0 {
+		if masukan.HargaPerakPerGram = parameter.HargaPerakPerGram; masukan.HargaPerakPerGram == 0 {
+			return nil, errors.New("harga perak belum tersedia, isi harga perak per gram")
+		}
+	}
+	riwayat, err := zakatEmasPerak(parameter, masukan, tampilan)
+	if err != nil {
+		return nil, err
+	}
+	return u.simpanJenis(ctx, idPengguna, tampilan, riwayat, masukan)
+}
+
+// zakatEmasPerak mencatat nisab tiap logam di rinciannya. Nisab ringkasan
+// mengikuti standar nisab parameter; logam yang tidak ditimbang dinilai
+// dengan harga parameter agar nisabnya tetap terisi.
+func zakatEmasPerak(parameter *domain.ParameterZakat, masukan domain.MasukanZakatEmasPerak, tampilan string) (*domain.ZakatRiwayat, error) {
+	hargaEmas, hargaPerak := masukan.HargaEmasPerGram, masukan.HargaPerakPerGram
+	if hargaEmas == 0 {
+		hargaEmas = parameter.HargaEmasPerGram
+	}
+	if hargaPerak == 0 {
+		hargaPerak = parameter.HargaPerakPerGram
+	}
+	nilai := func(harga domain.Uang, gram float64) (domain.Uang, error) {
+		hasil, err := harga.Kali(gram)
+		return hasil.Bulatkan(tampilan), err
+	}
+	nilaiEmas, err := nilai(hargaEmas, masukan.GramEmas)
+	if err != nil {
+		return nil, err
+	}
+	nilaiPerak, err := nilai(hargaPerak, masukan.GramPerak)
+	if err != nil {
+		return nil, err
+	}
+	nisabEmas, err := nilai(hargaEmas, parameter.GramNisabEmas)
+	if err != nil {
+		return nil, err
+	}
+	nisabPerak, err := nilai(hargaPerak, parameter.GramNisabPerak)
+	if err != nil {
+		return nil, err
+	}
+
+	var dasar domain.Uang
+	if masukan.GramEmas >= parameter.GramNisabEmas {
+		dasar += nilaiEmas
+	}
+	if masukan.GramPerak >= parameter.GramNisabPerak {
+		dasar += nilaiPerak
+	}
+	zakat, err := hitungZakat(parameter, dasar)
+	if err != nil {
+		return nil, err
+	}
+	nisab := nisabEmas
+	if parameter.StandarNisab == domain.NisabPerak {
+		nisab = nisabPerak
+	}
+	return &domain.ZakatRiwayat{
+		Jenis:            domain.ZakatEmasPerak,
+		TotalNilai:       nilaiEmas + nilaiPerak,
+		Nisab:            nisab,
+		PersenZakat:      parameter.PersenZakat,
+		ZakatTerhitung:   zakat,
+		WajibZakat:       dasar > 0,
+		HargaEmasPerGram: hargaEmas,
+		Parameter:        parameter,
+		Rincian: []domain.RincianZakat{
+			{Simbol: "EMAS", NamaAset: "Emas", Kelompok: domain.RincianAset, Jumlah: masukan.GramEmas, Nilai: nilaiEmas, Nisab: nisabEmas},
+			{Simbol: "PERAK", NamaAset: "Perak", Kelompok: domain.RincianAset, Jumlah: masukan.GramPerak, Nilai: nilaiPerak, Nisab: nisabPerak},
+		},
+	}, nil
+}
+
+// persiapan menetapkan mata uang dan standar nisab yang dipakai ke masukan
+// agar tersimpan apa adanya, lalu memuat parameter zakat.
+func (u *ZakatUsecase) persiapan(ctx context.Context, idPengguna int64, mataUang, standarNisab *string) (string, *domain.ParameterZakat, error) {
+	tampilan, err := u.portofolio.MataUangTampilan(ctx, idPengguna, *mataUang)
+	if err != nil {
+		return "", nil, err
+	}
+	konverter, err := u.kurs.Konverter(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	parameter, err := u.parameter(ctx, idPengguna, *standarNisab, tampilan, konverter)
+	if err != nil {
+		return "", nil, err
+	}
+	*mataUang = tampilan
+	*standarNisab = parameter.StandarNisab
+	return tampilan, parameter, nil
+}
+
+func (u *ZakatUsecase) simpanJenis(ctx context.Context, idPengguna int64, tampilan string, riwayat *domain.ZakatRiwayat, masukan interface{}) (*domain.ZakatRiwayat, error) {
+	data, err := json.Marshal(masukan)
+	if err != nil {
+		return nil, err
+	}
+	sekarang := time.Now()
+	riwayat.IDPengguna = idPengguna
+	riwayat.MataUang = tampilan
+	riwayat.Tanggal = awalHari(sekarang)
+	riwayat.Masukan = data
+	riwayat.DibuatPada = sekarang
+	if err := u.repo.SimpanRiwayatZakat(ctx, riwayat); err != nil {
+		return nil, err
+	}
+	return riwayat, nil
+}
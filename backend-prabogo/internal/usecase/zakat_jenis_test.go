@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func parameterZakatUji(t *testing.T, standar string) *domain.ParameterZakat {
+	return &domain.ParameterZakat{
+		StandarNisab:      standar,
+		GramNisabEmas:     85,
+		GramNisabPerak:    595,
+		PersenZakat:       2.5,
+		HargaEmasPerGram:  uangUji(t, "1000000"),
+		HargaPerakPerGram: uangUji(t, "15000"),
+		MataUang:          domain.MataUangIDR,
+	}
+}
+
+func cekRiwayat(t *testing.T, r *domain.ZakatRiwayat, total, nisab, zakat string, wajib bool) {
+	t.Helper()
+	if r.TotalNilai != uangUji(t, total) || r.Nisab != uangUji(t, nisab) || r.ZakatTerhitung != uangUji(t, zakat) || r.WajibZakat != wajib {
+		t.Errorf("total %s, nisab %s, zakat %s, wajib %v; ingin %s, %s, %s, %v", r.TotalNilai, r.Nisab, r.ZakatTerhitung, r.WajibZakat, total, nisab, zakat, wajib)
+	}
+}
+
+func TestZakatPenghasilan(t *testing.T) {
+	tests := []struct {
+		nama    string
+		standar string
+		masukan domain.MasukanZakatPenghasilan
+		total   string
+		nisab   string
+		zakat   string
+		wajib   bool
+	}{
+		{
+			// Nisab bulanan 85 gram x 1.000.000 / 12 = 7.083.333,33.
+			nama:    "bruto di atas nisab bulanan",
+			standar: domain.NisabEmas,
+			masukan: domain.MasukanZakatPenghasilan{PenghasilanBulanan: uangUji(t, "8000000")},
+			total:   "8000000", nisab: "7083333", zakat: "200000", wajib: true,
+		},
+		{
+			nama:    "neto di bawah nisab bulanan",
+			standar: domain.NisabEmas,
+			masukan: domain.MasukanZakatPenghasilan{
+				PenghasilanBulanan: uangUji(t, "9000000"),
+				PenghasilanLain:    uangUji(t, "1000000"),
+				KebutuhanPokok:     uangUji(t, "2000000"),
+				Cicilan:            uangUji(t, "1000000"),
+			},
+			total: "7000000", nisab: "7083333", zakat: "175000",
+		},
+		{
+			// 595 gram x 15.000 / 12 = 743.750.
+			nama:    "standar perak",
+			standar: domain.NisabPerak,
+			masukan: domain.MasukanZakatPenghasilan{PenghasilanBulanan: uangUji(t, "1000000")},
+			total:   "1000000", nisab: "743750", zakat: "25000", wajib: true,
+		},
+		{
+			nama:    "pengurang melebihi penghasilan",
+			standar: domain.NisabEmas,
+			masukan: domain.MasukanZakatPenghasilan{PenghasilanBulanan: uangUji(t, "1000000"), KebutuhanPokok: uangUji(t, "3000000")},
+			total:   "0", nisab: "7083333", zakat: "0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			r, err := zakatPenghasilan(parameterZakatUji(t, tt.standar), tt.masukan, domain.MataUangIDR)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cekRiwayat(t, r, tt.total, tt.nisab, tt.zakat, tt.wajib)
+		})
+	}
+}
+
+func TestZakatPerdagangan(t *testing.T) {
+	masukan := domain.MasukanZakatPerdagangan{
+		Persediaan: uangUji(t, "50000000"),
+		KasUsaha:   uangUji(t, "30000000"),
+		Piutang:    uangUji(t, "10000000"),
+		Utang:      uangUji(t, "5000000"),
+	}
+	r, err := zakatPerdagangan(parameterZakatUji(t, domain.NisabEmas), masukan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Tepat sama dengan nisab emas sudah wajib zakat.
+	cekRiwayat(t, r, "85000000", "85000000", "2125000", true)
+
+	masukan.Utang = uangUji(t, "5000001")
+	if r, err = zakatPerdagangan(parameterZakatUji(t, domain.NisabEmas), masukan); err != nil {
+		t.Fatal(err)
+	}
+	cekRiwayat(t, r, "84999999", "85000000", "2125000", false)
+}
+
+func TestZakatFitrah(t *testing.T) {
+	tests := []struct {
+		nama     string
+		mataUang string
+		masukan  domain.MasukanZakatFitrah
+		perJiwa  string
+		total    string
+	}{
+		{
+			// 15.333 x 2,5 = 38.332,5 dibulatkan per jiwa sebelum dikali.
+			nama:     "rupiah dibulatkan per jiwa",
+			mataUang: domain.MataUangIDR,
+			masukan:  domain.MasukanZakatFitrah{JumlahJiwa: 3, HargaBerasPerKg: uangUji(t, "15333"), KgPerJiwa: 2.5},
+			perJiwa:  "38333",
+			total:    "114999",
+		},
+		{
+			nama:     "dolar dua digit",
+			mataUang: "USD",
+			masukan:  domain.MasukanZakatFitrah{JumlahJiwa: 3, HargaBerasPerKg: uangUji(t, "1.237"), KgPerJiwa: 2.5},
+			perJiwa:  "3.09",
+			total:    "9.27",
+		},
+		{
+			nama:     "takaran 3,5 kg",
+			mataUang: domain.MataUangIDR,
+			masukan:  domain.MasukanZakatFitrah{JumlahJiwa: 1, HargaBerasPerKg: uangUji(t, "15000"), KgPerJiwa: 3.5},
+			perJiwa:  "52500",
+			total:    "52500",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			r, err := zakatFitrah(tt.masukan, tt.mataUang)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Rincian[0].Nilai != uangUji(t, tt.perJiwa) {
+				t.Errorf("per jiwa = %s, ingin %s", r.Rincian[0].Nilai, tt.perJiwa)
+			}
+			cekRiwayat(t, r, tt.total, "0", tt.total, true)
+		})
+	}
+}
+
+func TestZakatEmasPerak(t *testing.T) {
+	tests := []struct {
+		nama    string
+		standar string
+		masukan domain.MasukanZakatEmasPerak
+		total   string
+		nisab   string
+		zakat   string
+		wajib   bool
+	}{
+		{
+			nama:    "emas mencapai nisab, perak tidak",
+			standar: domain.NisabEmas,
+			masukan: domain.MasukanZakatEmasPerak{GramEmas: 100, GramPerak: 500},
+			total:   "107500000", nisab: "85000000", zakat: "2500000", wajib: true,
+		},
+		{
+			nama:    "nisab ringkasan mengikuti standar perak",
+			standar: domain.NisabPerak,
+			masukan: domain.MasukanZakatEmasPerak{GramEmas: 100, GramPerak: 500},
+			total:   "107500000", nisab: "8925000", zakat: "2500000", wajib: true,
+		},
+		{
+			nama:    "hanya perak dengan harga masukan",
+			standar: domain.NisabEmas,
+			masukan: domain.MasukanZakatEmasPerak{GramPerak: 600, HargaPerakPerGram: uangUji(t, "16000")},
+			total:   "9600000", nisab: "85000000", zakat: "240000", wajib: true,
+		},
+		{
+			nama:    "keduanya di bawah nisab",
+			standar: domain.NisabEmas,
+			masukan: domain.MasukanZakatEmasPerak{GramEmas: 50, GramPerak: 500},
+			total:   "57500000", nisab: "85000000", zakat: "0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			r, err := zakatEmasPerak(parameterZakatUji(t, tt.standar), tt.masukan, domain.MataUangIDR)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cekRiwayat(t, r, tt.total, tt.nisab, tt.zakat, tt.wajib)
+
+			hargaPerak := uangUji(t, "15000")
+			if tt.masukan.HargaPerakPerGram > 0 {
+				hargaPerak = tt.masukan.HargaPerakPerGram
+			}
+			nisabPerak, _ := hargaPerak.Kali(595)
+			ingin := map[string]domain.Uang{"EMAS": uangUji(t, "85000000"), "PERAK": nisabPerak}
+			for _, rincian := range r.Rincian {
+				if rincian.Nisab != ingin[rincian.Simbol] {
+					t.Errorf("nisab %s = %s, ingin %s", rincian.Simbol, rincian.Nisab, ingin[rincian.Simbol])
+				}
+			}
+		})
+	}
+}
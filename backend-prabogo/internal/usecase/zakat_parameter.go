@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+// Kunci konfigurasi aturan zakat. Nilai bawaan dipakai bila kunci tidak ada
+// atau isinya tidak valid.
+const (
+	kunciGramNisabEmas  = "zakat_nisab_gram_emas"
+	kunciGramNisabPerak = "zakat_nisab_gram_perak"
+	kunciPersenZakat    = "zakat_persen"
+	kunciStandarNisab   = "zakat_standar_nisab"
+	kunciPembulatan     = "zakat_pembulatan"
+)
+
+// Nisab zakat maal bawaan setara 85 gram emas atau 595 gram perak, dengan kadar 2,5%.
+const (
+	gramNisabEmas   = 85
+	gramNisabPerak  = 595
+	persenZakatMaal = 2.5
+)
+
+func normalisasiStandarNisab(standar string) string {
+	switch strings.ToLower(strings.TrimSpace(standar)) {
+	case domain.NisabEmas:
+		return domain.NisabEmas
+	case domain.NisabPerak:
+		return domain.NisabPerak
+	}
+	return ""
+}
+
+// parameter menyusun aturan zakat yang berlaku untuk pengguna. Standar nisab
+// dipilih dari permintaan, lalu preferensi pengguna, lalu konfigurasi.
+func (u *ZakatUsecase) parameter(ctx context.Context, idPengguna int64, pilihan, tampilan string, konverter *KonverterKurs) (*domain.ParameterZakat, error) {
+	if pilihan != "" && normalisasiStandarNisab(pilihan) == "" {
+		return nil, errors.New("standar nisab harus emas atau perak")
+	}
+	daftar, err := u.konfigurasi.DaftarKonfigurasi(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var preferensi string
+	if normalisasiStandarNisab(pilihan) == "" {
+		pengguna, err := u.portofolio.penggunaRepo.AmbilPenggunaByID(ctx, idPengguna)
+		if err != nil {
+			return nil, err
+		}
+		if pengguna != nil {
+			preferensi = pengguna.StandarNisab
+		}
+	}
+	emas, err := u.harga.Terkini(ctx, domain.NisabEmas)
+	if err != nil {
+		return nil, err
+	}
+	perak, err := u.harga.Terkini(ctx, domain.NisabPerak)
+	if err != nil {
+		return nil, err
+	}
+	return susunParameter(daftar, pilihan, preferensi, tampilan, emas, perak, konverter)
+}
+
+// susunParameter menerapkan konfigurasi di atas nilai bawaan, memilih standar
+// nisab pertama yang valid dari pilihan, preferensi, konfigurasi lalu emas, dan
+// mengisi harga logam. Harga logam yang belum ada bernilai nol sehingga nisab
+// dengan standar itu juga nol.
+func susunParameter(daftar []domain.Konfigurasi, pilihan, preferensi, tampilan string, emas, perak *domain.HargaLogamTerkini, konverter *KonverterKurs) (*domain.ParameterZakat, error) {
+	nilai := map[string]string{}
+	for _, k := range daftar {
+		// Daftar terurut dari yang terbaru; kunci ganda memakai entri terbaru.
+		if _, ada := nilai[k.Kunci]; !ada {
+			nilai[k.Kunci] = strings.TrimSpace(k.Nilai)
+		}
+	}
+
+	p := &domain.ParameterZakat{
+		GramNisabEmas:  angkaKonfigurasi(nilai[kunciGramNisabEmas], gramNisabEmas),
+		GramNisabPerak: angkaKonfigurasi(nilai[kunciGramNisabPerak], gramNisabPerak),
+		PersenZakat:    angkaKonfigurasi(nilai[kunciPersenZakat], persenZakatMaal),
+		MataUang:       tampilan,
+	}
+	if p.PersenZakat > 100 {
+		p.PersenZakat = persenZakatMaal
+	}
+	if kelipatan, err := domain.UangDariString(nilai[kunciPembulatan]); err == nil && kelipatan > 0 {
+		p.KelipatanPembulatan = kelipatan
+	}
+
+	for _, standar := range []string{pilihan, preferensi, nilai[kunciStandarNisab], domain.NisabEmas} {
+		if p.StandarNisab = normalisasiStandarNisab(standar); p.StandarNisab != "" {
+			break
+		}
+	}
+
+	var err error
+	if p.HargaEmasPerGram, err = hargaPerGram(konverter, emas, tampilan); err != nil {
+		return nil, err
+	}
+	if p.HargaPerakPerGram, err = hargaPerGram(konverter, perak, tampilan); err != nil {
+		return nil, err
+	}
+
+	acuan := emas
+	if p.StandarNisab == domain.NisabPerak {
+		acuan = perak
+	}
+	if acuan == nil {
+		p.HargaKedaluwarsa = true
+		p.PeringatanHarga = fmt.Sprintf("Harga %s belum tersedia; nisab tidak dapat dihitung", p.StandarNisab)
+		return p, nil
+	}
+	tanggal := tanggalLokal(acuan.Tanggal)
+	p.TanggalHargaNisab = &tanggal
+	p.SumberHargaNisab = acuan.Sumber
+	p.HargaKedaluwarsa = acuan.Kedaluwarsa
+	p.PeringatanHarga = acuan.Peringatan
+	return p, nil
+}
+
+// hargaPerGram mengonversi harga logam terbaru ke mata uang tampilan, atau
+// nol bila belum ada harga.
+func hargaPerGram(konverter *KonverterKurs, harga *domain.HargaLogamTerkini, tampilan string) (domain.Uang, error) {
+	if harga == nil {
+		return 0, nil
+	}
+	return konverter.Konversi(harga.HargaPerGram, domain.NormalisasiMataUang(harga.MataUang, domain.MataUangIDR), tampilan)
+}
+
+func angkaKonfigurasi(s string, bawaan float64) float64 {
+	n, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", "."), 64)
+	if err != nil || n <= 0 {
+		return bawaan
+	}
+	return n
+}
+
+// gramNisab mengembalikan berat nisab untuk standar yang dipilih.
+func gramNisab(p *domain.ParameterZakat) float64 {
+	if p.StandarNisab == domain.NisabPerak {
+		return p.GramNisabPerak
+	}
+	return p.GramNisabEmas
+}
+
+// nisabTahunan menghitung nisab zakat maal dari harga logam standar.
+func nisabTahunan(p *domain.ParameterZakat) (domain.Uang, error) {
+	harga := p.HargaEmasPerGram
+	if p.StandarNisab == domain.NisabPerak {
+		harga = p.HargaPerakPerGram
+	}
+	nisab, err := harga.Kali(gramNisab(p))
+	if err != nil {
+		return 0, fmt.Errorf("nisab: %w", err)
+	}
+	return nisab.Bulatkan(p.MataUang), nil
+}
+
+// hitungZakat menerapkan kadar dan pembulatan. Kelipatan pembulatan
+// membulatkan ke atas agar zakat yang dibayar tidak kurang.
+func hitungZakat(p *domain.ParameterZakat, dasar domain.Uang) (domain.Uang, error) {
+	zakat, err := dasar.Kali(p.PersenZakat / 100)
+	if err != nil {
+		return 0, fmt.Errorf("zakat: %w", err)
+	}
+	if k := p.KelipatanPembulatan; k > 0 && zakat > 0 {
+		return (zakat + k - 1) / k * k, nil
+	}
+	return zakat.Bulatkan(p.MataUang), nil
+}
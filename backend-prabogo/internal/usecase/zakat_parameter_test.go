@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+)
+
+func TestHitungZakat(t *testing.T) {
+	tests := []struct {
+		nama      string
+		mataUang  string
+		kelipatan string
+		dasar     string
+		ingin     string
+	}{
+		{"dibulatkan ke digit rupiah", domain.MataUangIDR, "0", "1000001", "25000"},
+		{"dibulatkan ke digit dolar", "USD", "0", "123.45", "3.09"},
+		{"kelipatan dibulatkan ke atas", domain.MataUangIDR, "1000", "1000100", "26000"},
+		{"tepat kelipatan tidak berubah", domain.MataUangIDR, "1000", "1000000", "25000"},
+		{"kelipatan pecahan", "USD", "0.5", "123.45", "3.5"},
+		{"dasar nol tetap nol", domain.MataUangIDR, "1000", "0", "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			p := &domain.ParameterZakat{PersenZakat: 2.5, KelipatanPembulatan: uangUji(t, tt.kelipatan), MataUang: tt.mataUang}
+			got, err := hitungZakat(p, uangUji(t, tt.dasar))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != uangUji(t, tt.ingin) {
+				t.Errorf("hitungZakat(%s) = %s, ingin %s", tt.dasar, got, tt.ingin)
+			}
+		})
+	}
+}
+
+func hargaLogamUji(t *testing.T, logam, harga, mataUang string) *domain.HargaLogamTerkini {
+	return &domain.HargaLogamTerkini{HargaEmas: domain.HargaEmas{
+		Tanggal:      time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local),
+		HargaPerGram: uangUji(t, harga),
+		MataUang:     mataUang,
+		Logam:        logam,
+		Sumber:       "uji",
+	}}
+}
+
+func TestSusunParameterKonfigurasi(t *testing.T) {
+	konverter := &KonverterKurs{dasar: "USD", kurs: map[[2]string]float64{{"USD", "IDR"}: 16000}}
+	emas := hargaLogamUji(t, domain.NisabEmas, "100", "USD")
+	perak := hargaLogamUji(t, domain.NisabPerak, "15000", domain.MataUangIDR)
+
+	tests := []struct {
+		nama      string
+		daftar    []domain.Konfigurasi
+		persen    float64
+		gramEmas  float64
+		kelipatan string
+	}{
+		{"tanpa konfigurasi memakai bawaan", nil, 2.5, 85, "0"},
+		{
+			"persen di atas 100 kembali ke bawaan",
+			[]domain.Konfigurasi{{Kunci: kunciPersenZakat, Nilai: "150"}},
+			2.5, 85, "0",
+		},
+		{
+			"koma desimal dan pembulatan",
+			[]domain.Konfigurasi{{Kunci: kunciPersenZakat, Nilai: "2,75"}, {Kunci: kunciGramNisabEmas, Nilai: "87.48"}, {Kunci: kunciPembulatan, Nilai: "1000"}},
+			2.75, 87.48, "1000",
+		},
+		{
+			"nilai tidak valid memakai bawaan",
+			[]domain.Konfigurasi{{Kunci: kunciPersenZakat, Nilai: "-1"}, {Kunci: kunciGramNisabEmas, Nilai: "abc"}, {Kunci: kunciPembulatan, Nilai: "-500"}},
+			2.5, 85, "0",
+		},
+		{
+			"kunci ganda memakai entri terbaru",
+			[]domain.Konfigurasi{{Kunci: kunciPersenZakat, Nilai: "3"}, {Kunci: kunciPersenZakat, Nilai: "4"}},
+			3, 85, "0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			p, err := susunParameter(tt.daftar, "", "", domain.MataUangIDR, emas, perak, konverter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.PersenZakat != tt.persen || p.GramNisabEmas != tt.gramEmas || p.GramNisabPerak != gramNisabPerak || p.KelipatanPembulatan != uangUji(t, tt.kelipatan) {
+				t.Errorf("parameter = persen %v, gram emas %v, gram perak %v, kelipatan %s", p.PersenZakat, p.GramNisabEmas, p.GramNisabPerak, p.KelipatanPembulatan)
+			}
+			if p.HargaEmasPerGram != uangUji(t, "1600000") || p.HargaPerakPerGram != uangUji(t, "15000") {
+				t.Errorf("harga emas %s, perak %s; ingin dikonversi ke rupiah", p.HargaEmasPerGram, p.HargaPerakPerGram)
+			}
+		})
+	}
+}
+
+func TestSusunParameterStandarNisab(t *testing.T) {
+	konverter := &KonverterKurs{dasar: "USD", kurs: map[[2]string]float64{}}
+	emas := hargaLogamUji(t, domain.NisabEmas, "1000000", domain.MataUangIDR)
+	perak := hargaLogamUji(t, domain.NisabPerak, "15000", domain.MataUangIDR)
+	konfigurasi := func(standar string) []domain.Konfigurasi {
+		return []domain.Konfigurasi{{Kunci: kunciStandarNisab, Nilai: standar}}
+	}
+
+	tests := []struct {
+		nama       string
+		pilihan    string
+		preferensi string
+		daftar     []domain.Konfigurasi
+		ingin      string
+	}{
+		{"permintaan mengalahkan preferensi", "perak", "emas", konfigurasi("emas"), domain.NisabPerak},
+		{"permintaan huruf besar", " Emas ", "perak", konfigurasi("perak"), domain.NisabEmas},
+		{"preferensi mengalahkan konfigurasi", "", "perak", konfigurasi("emas"), domain.NisabPerak},
+		{"konfigurasi bila tanpa preferensi", "", "", konfigurasi("perak"), domain.NisabPerak},
+		{"preferensi tidak valid dilewati", "", "tembaga", konfigurasi("perak"), domain.NisabPerak},
+		{"emas bila semuanya kosong", "", "", nil, domain.NisabEmas},
+		{"emas bila konfigurasi tidak valid", "", "", konfigurasi("tembaga"), domain.NisabEmas},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			p, err := susunParameter(tt.daftar, tt.pilihan, tt.preferensi, domain.MataUangIDR, emas, perak, konverter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.StandarNisab != tt.ingin {
+				t.Errorf("StandarNisab = %q, ingin %q", p.StandarNisab, tt.ingin)
+			}
+		})
+	}
+}
+
+func TestSusunParameterHargaPerakKosong(t *testing.T) {
+	konverter := &KonverterKurs{dasar: "USD", kurs: map[[2]string]float64{}}
+	emas := hargaLogamUji(t, domain.NisabEmas, "1000000", domain.MataUangIDR)
+
+	p, err := susunParameter(nil, domain.NisabPerak, "", domain.MataUangIDR, emas, nil, konverter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.HargaPerakPerGram != 0 || !p.HargaKedaluwarsa || p.PeringatanHarga == "" || p.TanggalHargaNisab != nil {
+		t.Errorf("parameter = %+v, ingin harga perak nol dengan peringatan", p)
+	}
+	nisab, err := nisabTahunan(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nisab != 0 {
+		t.Errorf("nisab = %s, ingin 0", nisab)
+	}
+
+	// Standar emas tetap terhitung walau harga perak kosong.
+	if p, err = susunParameter(nil, domain.NisabEmas, "", domain.MataUangIDR, emas, nil, konverter); err != nil {
+		t.Fatal(err)
+	}
+	if nisab, err = nisabTahunan(p); err != nil || nisab != uangUji(t, "85000000") || p.HargaKedaluwarsa {
+		t.Errorf("nisab emas = %s, %v, kedaluwarsa %v; ingin 85000000", nisab, err, p.HargaKedaluwarsa)
+	}
+}
@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+	"github.com/averroes/backend-prabogo/pkg/hijri"
+	"github.com/averroes/backend-prabogo/pkg/pdf"
+)
+
+var namaJenisZakat = map[string]string{
+	domain.ZakatMaal:        "Zakat Maal",
+	domain.ZakatPenghasilan: "Zakat Penghasilan",
+	domain.ZakatPerdagangan: "Zakat Perdagangan",
+	domain.ZakatFitrah:      "Zakat Fitrah",
+	domain.ZakatEmasPerak:   "Zakat Emas dan Perak",
+}
+
+var namaBulanMasehi = [...]string{"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"}
+
+// Tata letak laporan dalam titik.
+const (
+	marginLaporan     = 50.0
+	batasBawahLaporan = pdf.TinggiA4 - 60
+	jarakBaris        = 16.0
+	ukuranIsi         = 10.0
+	ukuranJudul       = 16.0
+	ukuranCatatan     = 8.0
+)
+
+// PDFRiwayat menyusun laporan PDF dari perhitungan zakat yang tersimpan.
+// Mengembalikan isi berkas dan nama berkas yang disarankan.
+func (u *ZakatUsecase) PDFRiwayat(ctx context.Context, id int64, idPengguna int64) ([]byte, string, error) {
+	riwayat, err := u.repo.AmbilRiwayatZakat(ctx, id, idPengguna)
+	if err != nil {
+		return nil, "", err
+	}
+	if riwayat == nil {
+		return nil, "", errors.New("riwayat zakat tidak ditemukan")
+	}
+	pengguna, err := u.portofolio.penggunaRepo.AmbilPenggunaByID(ctx, idPengguna)
+	if err != nil {
+		return nil, "", err
+	}
+
+	jenis := namaJenisZakat[riwayat.Jenis]
+	if jenis == "" {
+		jenis = "Zakat"
+	}
+	tanggal := tanggalLokal(riwayat.Tanggal)
+	mataUang := riwayat.MataUang
+
+	l := &penulisLaporan{dok: pdf.Baru("Laporan Perhitungan "+jenis, "Averroes")}
+	l.halamanBaru()
+	lebar := l.dok.Lebar()
+	kanan := lebar - marginLaporan
+
+	l.hal.Teks(marginLaporan, l.y, pdf.Tebal, ukuranJudul, "Laporan Perhitungan "+jenis)
+	l.y += 22
+	l.hal.Teks(marginLaporan, l.y, pdf.Reguler, ukuranIsi, fmt.Sprintf("Nomor perhitungan #%d", riwayat.ID))
+	l.y += 10
+	l.hal.Garis(marginLaporan, l.y, kanan, l.y, 1)
+	l.y += 20
+
+	if pengguna != nil {
+		l.pasangan("Nama", pengguna.Nama)
+		l.pasangan("Email", pengguna.Email)
+	}
+	l.pasangan("Tanggal perhitungan", formatTanggalIndonesia(tanggal))
+	l.pasangan("Tanggal Hijriah", hijri.DariMasehi(tanggal).String())
+	l.pasangan("Mata uang", mataUang)
+	l.y += 8
+
+	l.subjudul("Rincian harta")
+	kolomKategori := marginLaporan + 250
+	l.hal.Teks(marginLaporan, l.y, pdf.Tebal, ukuranIsi, "Uraian")
+	l.hal.Teks(kolomKategori, l.y, pdf.Tebal, ukuranIsi, "Kategori")
+	l.hal.TeksKanan(kanan, l.y, pdf.Tebal, ukuranIsi, "Nilai")
+	l.y += 6
+	l.hal.Garis(marginLaporan, l.y, kanan, l.y, 0.5)
+	l.y += jarakBaris
+
+	var totalAset, totalPengurang domain.Uang
+	if len(riwayat.Rincian) == 0 {
+		l.hal.Teks(marginLaporan, l.y, pdf.Reguler, ukuranIsi, "Tidak ada rincian tersimpan.")
+		l.y += jarakBaris
+	}
+	for _, r := range riwayat.Rincian {
+		l.cukup(jarakBaris)
+		uraian := r.NamaAset
+		if r.Simbol != "" && r.Simbol != r.NamaAset {
+			uraian = fmt.Sprintf("%s (%s)", r.NamaAset, r.Simbol)
+		}
+		if uraian == "" {
+			uraian = r.Simbol
+		}
+		if r.Jumlah != 0 {
+			uraian += " x " + strconv.FormatFloat(r.Jumlah, 'f', -1, 64)
+		}
+		if baris := pdf.PecahBaris(pdf.Reguler, ukuranIsi, uraian, kolomKategori-marginLaporan-10); len(baris) > 0 {
+			uraian = baris[0]
+			if len(baris) > 1 {
+				uraian += "..."
+			}
+		}
+		pengurang := r.Kelompok == domain.RincianPengurang || r.Nilai < 0
+		nilai := r.Nilai
+		if pengurang {
+			if nilai > 0 {
+				nilai = -nilai
+			}
+			totalPengurang -= nilai
+		} else {
+			totalAset += nilai
+		}
+		l.hal.Teks(marginLaporan, l.y, pdf.Reguler, ukuranIsi, uraian)
+		l.hal.Teks(kolomKategori, l.y, pdf.Reguler, ukuranIsi, r.Kategori)
+		l.hal.TeksKanan(kanan, l.y, pdf.Reguler, ukuranIsi, formatUangLaporan(nilai, mataUang))
+		l.y += jarakBaris
+	}
+	l.hal.Garis(marginLaporan, l.y-10, kanan, l.y-10, 0.5)
+	l.y += 4
+	if totalPengurang > 0 {
+		l.jumlah("Total aset", formatUangLaporan(totalAset, mataUang), false)
+		l.jumlah("Total pengurang", formatUangLaporan(-totalPengurang, mataUang), false)
+	}
+	l.jumlah("Harta bersih (dasar zakat)", formatUangLaporan(riwayat.TotalNilai, mataUang), true)
+	l.y += 8
+
+	l.subjudul("Nisab dan kadar")
+	p := riwayat.Parameter
+	if riwayat.Nisab > 0 {
+		l.pasangan("Nisab", formatUangLaporan(riwayat.Nisab, mataUang))
+	} else {
+		l.pasangan("Nisab", "-")
+	}
+	if p != nil {
+		gram, harga := p.GramNisabEmas, p.HargaEmasPerGram
+		if p.StandarNisab == domain.NisabPerak {
+			gram, harga = p.GramNisabPerak, p.HargaPerakPerGram
+		}
+		l.pasangan("Standar nisab", fmt.Sprintf("%s gram %s x %s/gram", strconv.FormatFloat(gram, 'f', -1, 64), p.StandarNisab, formatUangLaporan(harga, mataUang)))
+		if p.TanggalHargaNisab != nil {
+			sumber := formatTanggalIndonesia(tanggalLokal(*p.TanggalHargaNisab))
+			if p.SumberHargaNisab != "" {
+				sumber += ", sumber " + p.SumberHargaNisab
+			}
+			l.pasangan("Harga logam per", sumber)
+		}
+		if p.PeringatanHarga != "" {
+			l.pasangan("Catatan harga", p.PeringatanHarga)
+		}
+	} else if riwayat.HargaEmasPerGram > 0 {
+		l.pasangan("Harga emas", formatUangLaporan(riwayat.HargaEmasPerGram, mataUang)+"/gram")
+	}
+	l.pasangan("Kadar zakat", strconv.FormatFloat(riwayat.PersenZakat, 'f', -1, 64)+"%")
+	status := "Belum wajib zakat"
+	if riwayat.WajibZakat {
+		status = "Wajib zakat"
+	}
+	l.pasangan("Status", status)
+	l.y += 4
+	l.jumlah("Zakat yang harus dibayar", formatUangLaporan(riwayat.ZakatTerhitung, mataUang), true)
+
+	l.cukup(40)
+	l.y += 24
+	for _, baris := range pdf.PecahBaris(pdf.Reguler, ukuranCatatan, fmt.Sprintf("Dokumen ini dibuat otomatis pada %s berdasarkan data perhitungan yang tersimpan. Konsultasikan dengan lembaga amil zakat untuk penetapan akhir kewajiban zakat Anda.", time.Now().Format("02-01-2006 15:04")), lebar-2*marginLaporan) {
+		l.hal.Teks(marginLaporan, l.y, pdf.Reguler, ukuranCatatan, baris)
+		l.y += 11
+	}
+
+	isi, err := l.dok.Bytes()
+	if err != nil {
+		return nil, "", err
+	}
+	return isi, fmt.Sprintf("zakat-%s-%s.pdf", riwayat.Jenis, tanggal.Format("2006-01-02")), nil
+}
+
+// penulisLaporan melacak posisi vertikal dan membuka halaman baru bila
+// baris berikutnya melewati batas bawah.
+type penulisLaporan struct {
+	dok *pdf.Dokumen
+	hal *pdf.Halaman
+	y   float64
+}
+
+func (l *penulisLaporan) halamanBaru() {
+	l.hal = l.dok.TambahHalaman()
+	l.y = marginLaporan + 10
+}
+
+func (l *penulisLaporan) cukup(tinggi float64) {
+	if l.y+tinggi > batasBawahLaporan {
+		l.halamanBaru()
+	}
+}
+
+func (l *penulisLaporan) subjudul(s string) {
+	l.cukup(jarakBaris * 3)
+	l.hal.Teks(marginLaporan, l.y, pdf.Tebal, 12, s)
+	l.y += jarakBaris + 2
+}
+
+// pasangan menulis label dan nilai; nilai panjang dipecah ke beberapa baris.
+func (l *penulisLaporan) pasangan(label, nilai string) {
+	const kolomNilai = 150.0
+	baris := pdf.PecahBaris(pdf.Reguler, ukuranIsi, ": "+nilai, l.dok.Lebar()-2*marginLaporan-kolomNilai)
+	l.cukup(jarakBaris * float64(len(baris)))
+	l.hal.Teks(marginLaporan, l.y, pdf.Reguler, ukuranIsi, label)
+	for i, b := range baris {
+		if i > 0 {
+			b = "  " + b
+		}
+		l.hal.Teks(marginLaporan+kolomNilai, l.y, pdf.Reguler, ukuranIsi, b)
+		l.y += jarakBaris
+	}
+}
+
+func (l *penulisLaporan) jumlah(label, nilai string, tebal bool) {
+	l.cukup(jarakBaris)
+	font := pdf.Reguler
+	if tebal {
+		font = pdf.Tebal
+	}
+	l.hal.Teks(marginLaporan, l.y, font, ukuranIsi, label)
+	l.hal.TeksKanan(l.dok.Lebar()-marginLaporan, l.y, font, ukuranIsi, nilai)
+	l.y += jarakBaris
+}
+
+func formatTanggalIndonesia(t time.Time) string {
+	return fmt.Sprintf("%d %s %d", t.Day(), namaBulanMasehi[t.Month()-1], t.Year())
+}
+
+// formatUangLaporan menulis nilai dengan pemisah ribuan titik dan desimal
+// koma sesuai digit resmi mata uang, misalnya "Rp 1.250.000".
+func formatUangLaporan(u domain.Uang, mataUang string) string {
+	digit := domain.DigitMataUang(mataUang)
+	teks := u.Bulatkan(mataUang).String()
+	negatif := strings.HasPrefix(teks, "-")
+	teks = strings.TrimPrefix(teks, "-")
+	bulat, pecahan, _ := strings.Cut(teks, ".")
+	if digit > 0 {
+		pecahan = (pecahan + strings.Repeat("0", digit))[:digit]
+	} else {
+		pecahan = ""
+	}
+	var b strings.Builder
+	for i, c := range bulat {
+		if i > 0 && (len(bulat)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(c)
+	}
+	if pecahan != "" {
+		b.WriteString("," + pecahan)
+	}
+	awalan := mataUang + " "
+	if mataUang == domain.MataUangIDR {
+		awalan = "Rp "
+	}
+	if negatif {
+		return "-" + awalan + b.String()
+	}
+	return awalan + b.String()
+}
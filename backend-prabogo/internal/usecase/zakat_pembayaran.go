@@ -0,0 +1,365 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+	"github.com/averroes/backend-prabogo/pkg/hijri"
+)
+
+const catatanLaporanZakat = "Zakat yang dibayarkan kepada BAZNAS atau LAZ yang dibentuk atau disahkan pemerintah dapat dikurangkan dari penghasilan bruto (UU PPh Pasal 9 ayat (1) huruf g dan PP 60 Tahun 2010). Lampirkan bukti setor zakat pada SPT Tahunan; pembayaran langsung ke mustahik tidak dapat dikurangkan."
+
+func (u *ZakatUsecase) DaftarPembayaran(ctx context.Context, idPengguna int64, dari, sampai time.Time) ([]domain.PembayaranZakat, error) {
+	return u.repo.DaftarPembayaranZakat(ctx, idPengguna, dari, sampai)
+}
+
+func (u *ZakatUsecase) TambahPembayaran(ctx context.Context, pembayaran *domain.PembayaranZakat) (*domain.PembayaranZakat, error) {
+	if err := u.validasiPembayaran(ctx, pembayaran); err != nil {
+		return nil, err
+	}
+	if err := u.isiTahunHaul(ctx, pembayaran); err != nil {
+		return nil, err
+	}
+	if err := u.kunciJumlahIDR(ctx, pembayaran); err != nil {
+		return nil, err
+	}
+	sekarang := time.Now()
+	pembayaran.DibuatPada = sekarang
+	pembayaran.DiperbaruiPada = sekarang
+	if err := u.repo.SimpanPembayaranZakat(ctx, pembayaran); err != nil {
+		return nil, err
+	}
+	return pembayaran, nil
+}
+
+func (u *ZakatUsecase) PerbaruiPembayaran(ctx context.Context, pembayaran *domain.PembayaranZakat) (*domain.PembayaranZakat, error) {
+	lama, err := u.repo.AmbilPembayaranZakat(ctx, pembayaran.ID, pembayaran.IDPengguna)
+	if err != nil {
+		return nil, err
+	}
+	if lama == nil {
+		return nil, errors.New("pembayaran zakat tidak ditemukan")
+	}
+	if err := u.validasiPembayaran(ctx, pembayaran); err != nil {
+		return nil, err
+	}
+	if err := u.isiTahunHaul(ctx, pembayaran); err != nil {
+		return nil, err
+	}
+	// Kurs hanya dikunci ulang bila nominal atau tanggal pembayaran berubah.
+	if pembayaran.Jumlah == lama.Jumlah && pembayaran.MataUang == lama.MataUang && pembayaran.Tanggal.Equal(tanggalLokal(lama.Tanggal)) && lama.JumlahIDR != nil {
+		pembayaran.JumlahIDR = lama.JumlahIDR
+	} else if err := u.kunciJumlahIDR(ctx, pembayaran); err != nil {
+		return nil, err
+	}
+	pembayaran.DibuatPada = lama.DibuatPada
+	pembayaran.DiperbaruiPada = time.Now()
+	if err := u.repo.PerbaruiPembayaranZakat(ctx, pembayaran); err != nil {
+		return nil, err
+	}
+	return pembayaran, nil
+}
+
+func (u *ZakatUsecase) HapusPembayaran(ctx context.Context, id int64, idPengguna int64) error {
+	pembayaran, err := u.repo.AmbilPembayaranZakat(ctx, id, idPengguna)
+	if err != nil {
+		return err
+	}
+	if pembayaran == nil {
+		return errors.New("pembayaran zakat tidak ditemukan")
+	}
+	return u.repo.HapusPembayaranZakat(ctx, id, idPengguna)
+}
+
+// validasiPembayaran mengisi nilai bawaan: jenis maal, tanggal hari ini dan
+// mata uang pengguna. Tahun haul kosong diisi kemudian oleh isiTahunHaul.
+func (u *ZakatUsecase) validasiPembayaran(ctx context.Context, pembayaran *domain.PembayaranZakat) error {
+	pembayaran.Jenis = strings.ToLower(strings.TrimSpace(pembayaran.Jenis))
+	if pembayaran.Jenis == "" {
+		pembayaran.Jenis = domain.ZakatMaal
+	}
+	if !domain.JenisZakatValid(pembayaran.Jenis) {
+		return errors.New("jenis zakat tidak dikenal")
+	}
+	if pembayaran.Jumlah <= 0 {
+		return errors.New("jumlah pembayaran harus lebih dari nol")
+	}
+	hariIni := awalHari(time.Now())
+	if pembayaran.Tanggal.IsZero() {
+		pembayaran.Tanggal = hariIni
+	}
+	pembayaran.Tanggal = tanggalLokal(pembayaran.Tanggal)
+	if pembayaran.Tanggal.After(hariIni) {
+		return errors.New("tanggal pembayaran tidak boleh di masa depan")
+	}
+	if pembayaran.TahunHaul < 0 {
+		return errors.New("tahun haul tidak valid")
+	}
+
+	pembayaran.JenisPenerima = strings.ToLower(strings.TrimSpace(pembayaran.JenisPenerima))
+	if pembayaran.JenisPenerima != domain.PenerimaAmil && pembayaran.JenisPenerima != domain.PenerimaMustahik {
+		return errors.New("jenis penerima harus amil atau mustahik")
+	}
+	pembayaran.Penerima = strings.TrimSpace(pembayaran.Penerima)
+	if pembayaran.Penerima == "" {
+		return errors.New("nama penerima atau lembaga amil wajib diisi")
+	}
+	pembayaran.Asnaf = strings.ToLower(strings.TrimSpace(pembayaran.Asnaf))
+	if pembayaran.Asnaf == "" && pembayaran.JenisPenerima == domain.PenerimaMustahik {
+		return errors.New("asnaf wajib diisi untuk penyaluran langsung ke mustahik")
+	}
+	if pembayaran.Asnaf != "" && !domain.AsnafValid(pembayaran.Asnaf) {
+		return errors.New("asnaf tidak dikenal")
+	}
+	pembayaran.NomorBukti = strings.TrimSpace(pembayaran.NomorBukti)
+	pembayaran.BuktiURL = strings.TrimSpace(pembayaran.BuktiURL)
+	pembayaran.Catatan = strings.TrimSpace(pembayaran.Catatan)
+
+	if pembayaran.MataUang == "" {
+		pengguna, err := u.portofolio.penggunaRepo.AmbilPenggunaByID(ctx, pembayaran.IDPengguna)
+		if err != nil {
+			return err
+		}
+		pembayaran.MataUang = domain.MataUangIDR
+		if pengguna != nil && pengguna.MataUang != "" {
+			pembayaran.MataUang = pengguna.MataUang
+		}
+		return nil
+	}
+	pembayaran.MataUang = domain.NormalisasiMataUang(pembayaran.MataUang, "")
+	if pembayaran.MataUang == "" {
+		return errors.New("kode mata uang harus tiga huruf ISO 4217")
+	}
+	return nil
+}
+
+// isiTahunHaul mengisi tahun haul pembayaran yang tidak menyebutkannya.
+// Pembayaran maal dibandingkan dengan status pembayaran tanpa dirinya sendiri
+// agar pengeditan tidak menghitung pembayaran yang sama dua kali.
+func (u *ZakatUsecase) isiTahunHaul(ctx context.Context, pembayaran *domain.PembayaranZakat) error {
+	if pembayaran.TahunHaul > 0 {
+		return nil
+	}
+	if pembayaran.Jenis != domain.ZakatMaal {
+		pembayaran.TahunHaul = hijri.DariMasehi(pembayaran.Tanggal).Tahun
+		return nil
+	}
+	ringkasan, tercapai, err := u.ringkasan(ctx, pembayaran.IDPengguna, "", "")
+	if err != nil {
+		return err
+	}
+	konverter, err := u.kurs.Konverter(ctx)
+	if err != nil {
+		return err
+	}
+	daftar, err := u.repo.DaftarPembayaranZakat(ctx, pembayaran.IDPengguna, time.Time{}, time.Time{})
+	if err != nil {
+		return err
+	}
+	lain := make([]domain.PembayaranZakat, 0, len(daftar))
+	for _, p := range daftar {
+		if p.ID != pembayaran.ID {
+			lain = append(lain, p)
+		}
+	}
+	status, err := susunStatusPembayaran(ringkasan.Parameter, tercapai, lain, konverter, ringkasan.MataUang)
+	if err != nil {
+		return err
+	}
+	pembayaran.TahunHaul = tahunHaulPembayaran(status.TahunHaul, ringkasan.Haul, pembayaran.Tanggal)
+	return nil
+}
+
+// tahunHaulPembayaran memilih haul paling awal yang sudah jatuh tempo pada
+// atau sebelum tanggal bayar dan masih bersisa. Bila tidak ada, pembayaran
+// dianggap didahulukan untuk haul bersisa berikutnya, lalu haul yang sedang
+// berjalan, dan terakhir tahun Hijriah tanggal bayar. status harus urut
+// menurut tahun haul.
+func tahunHaulPembayaran(status []domain.StatusTahunHaul, haul *domain.StatusHaul, tanggal time.Time) int {
+	for _, s := range status {
+		if s.JatuhTempo != nil && !s.JatuhTempo.After(tanggal) && s.Sisa > 0 {
+			return s.TahunHaul
+		}
+	}
+	for _, s := range status {
+		if s.JatuhTempo != nil && s.Sisa > 0 {
+			return s.TahunHaul
+		}
+	}
+	if haul != nil && haul.JatuhTempo != nil {
+		return hijri.DariMasehi(*haul.JatuhTempo).Tahun
+	}
+	return hijri.DariMasehi(tanggal).Tahun
+}
+
+// kunciJumlahIDR menyimpan nilai rupiah pembayaran dengan kurs yang berlaku
+// pada tanggal bayar agar laporan pajak tidak bergeser mengikuti kurs hari
+// ini. Pembayaran ditolak bila kurs pada tanggal tersebut belum tercatat.
+func (u *ZakatUsecase) kunciJumlahIDR(ctx context.Context, pembayaran *domain.PembayaranZakat) error {
+	pembayaran.JumlahIDR = nil
+	if pembayaran.MataUang == domain.MataUangIDR {
+		jumlah := pembayaran.Jumlah
+		pembayaran.JumlahIDR = &jumlah
+		return nil
+	}
+	konverter, err := u.kurs.KonverterPada(ctx, pembayaran.Tanggal)
+	if err != nil {
+		return err
+	}
+	jumlah, err := konverter.Konversi(pembayaran.Jumlah, pembayaran.MataUang, domain.MataUangIDR)
+	if err != nil {
+		return fmt.Errorf("kurs %s ke IDR pada %s belum tersedia; catat kurs tanggal tersebut terlebih dahulu", pembayaran.MataUang, pembayaran.Tanggal.Format("2006-01-02"))
+	}
+	pembayaran.JumlahIDR = &jumlah
+	return nil
+}
+
+// jumlahPembayaran mengubah pembayaran ke mata uang tampilan. Laporan dalam
+// rupiah memakai nilai yang dikunci saat pembayaran dicatat; mata uang lain
+// dihitung dari nilai rupiah tersebut atau, bila kosong, dari nominal asli.
+func jumlahPembayaran(konverter *KonverterKurs, p domain.PembayaranZakat, tampilan string) (domain.Uang, error) {
+	mataUang := domain.NormalisasiMataUang(p.MataUang, tampilan)
+	if mataUang == tampilan {
+		return p.Jumlah, nil
+	}
+	if p.JumlahIDR != nil {
+		return konverter.Konversi(*p.JumlahIDR, domain.MataUangIDR, tampilan)
+	}
+	return konverter.Konversi(p.Jumlah, mataUang, tampilan)
+}
+
+// StatusPembayaran menyandingkan zakat maal setiap haul yang telah genap
+// dengan pembayaran maal per tahun haul. Kewajiban dihitung dari nilai harta
+// saat jatuh tempo dengan kadar dan pembulatan yang berlaku sekarang.
+func (u *ZakatUsecase) StatusPembayaran(ctx context.Context, idPengguna int64, mataUang, standarNisab string) (*domain.StatusPembayaranZakat, error) {
+	ringkasan, tercapai, err := u.ringkasan(ctx, idPengguna, mataUang, standarNisab)
+	if err != nil {
+		return nil, err
+	}
+	konverter, err := u.kurs.Konverter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pembayaran, err := u.repo.DaftarPembayaranZakat(ctx, idPengguna, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	return susunStatusPembayaran(ringkasan.Parameter, tercapai, pembayaran, konverter, ringkasan.MataUang)
+}
+
+// susunStatusPembayaran mengelompokkan kewajiban setiap haul yang tercapai
+// menurut tahun Hijriah jatuh temponya dan pembayaran maal menurut TahunHaul.
+// Hasil diurutkan menurut tahun haul.
+func susunStatusPembayaran(parameter *domain.ParameterZakat, tercapai []titikHaul, pembayaran []domain.PembayaranZakat, konverter *KonverterKurs, tampilan string) (*domain.StatusPembayaranZakat, error) {
+	perTahun := make(map[int]*domain.StatusTahunHaul)
+	ambil := func(tahun int) *domain.StatusTahunHaul {
+		status, ok := perTahun[tahun]
+		if !ok {
+			status = &domain.StatusTahunHaul{TahunHaul: tahun}
+			perTahun[tahun] = status
+		}
+		return status
+	}
+	for _, t := range tercapai {
+		tempo := t.tanggal
+		status := ambil(hijri.DariMasehi(tempo).Tahun)
+		status.JatuhTempo = &tempo
+		status.JatuhTempoHijriah = hijri.DariMasehi(tempo).String()
+		status.NilaiHarta = t.nilai
+		status.Nisab = t.nisab
+		wajib, err := hitungZakat(parameter, t.nilai)
+		if err != nil {
+			return nil, err
+		}
+		status.ZakatWajib = wajib
+	}
+	for _, p := range pembayaran {
+		if p.Jenis != domain.ZakatMaal {
+			continue
+		}
+		jumlah, err := jumlahPembayaran(konverter, p, tampilan)
+		if err != nil {
+			return nil, err
+		}
+		ambil(p.TahunHaul).Dibayar += jumlah
+	}
+
+	hasil := &domain.StatusPembayaranZakat{MataUang: tampilan, TahunHaul: make([]domain.StatusTahunHaul, 0, len(perTahun))}
+	for _, status := range perTahun {
+		if status.ZakatWajib > status.Dibayar {
+			status.Sisa = status.ZakatWajib - status.Dibayar
+		}
+		status.Lunas = status.Sisa == 0
+		hasil.TotalWajib += status.ZakatWajib
+		hasil.TotalDibayar += status.Dibayar
+		hasil.TotalSisa += status.Sisa
+		hasil.TahunHaul = append(hasil.TahunHaul, *status)
+	}
+	sort.Slice(hasil.TahunHaul, func(i, j int) bool {
+		return hasil.TahunHaul[i].TahunHaul < hasil.TahunHaul[j].TahunHaul
+	})
+	return hasil, nil
+}
+
+// LaporanTahunan merangkum pembayaran zakat satu tahun pajak Masehi. Tahun
+// kosong berarti tahun lalu, tahun yang dilaporkan pada SPT berjalan.
+func (u *ZakatUsecase) LaporanTahunan(ctx context.Context, idPengguna int64, tahun int, mataUang string) (*domain.LaporanZakatTahunan, error) {
+	sekarang := time.Now()
+	if tahun == 0 {
+		tahun = sekarang.Year() - 1
+	}
+	if tahun < 2000 || tahun > sekarang.Year() {
+		return nil, errors.New("tahun laporan tidak valid")
+	}
+	tampilan, err := u.portofolio.MataUangTampilan(ctx, idPengguna, mataUang)
+	if err != nil {
+		return nil, err
+	}
+	pengguna, err := u.portofolio.penggunaRepo.AmbilPenggunaByID(ctx, idPengguna)
+	if err != nil {
+		return nil, err
+	}
+	if pengguna == nil {
+		return nil, errors.New("pengguna tidak ditemukan")
+	}
+	konverter, err := u.kurs.Konverter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dari := time.Date(tahun, time.January, 1, 0, 0, 0, 0, time.Local)
+	sampai := time.Date(tahun, time.December, 31, 0, 0, 0, 0, time.Local)
+	pembayaran, err := u.repo.DaftarPembayaranZakat(ctx, idPengguna, dari, sampai)
+	if err != nil {
+		return nil, err
+	}
+
+	laporan := &domain.LaporanZakatTahunan{
+		Tahun:        tahun,
+		NamaPengguna: pengguna.Nama,
+		Email:        pengguna.Email,
+		MataUang:     tampilan,
+		Pembayaran:   pembayaran,
+		Catatan:      catatanLaporanZakat,
+		DibuatPada:   sekarang,
+	}
+	if laporan.Pembayaran == nil {
+		laporan.Pembayaran = []domain.PembayaranZakat{}
+	}
+	for _, p := range pembayaran {
+		jumlah, err := jumlahPembayaran(konverter, p, tampilan)
+		if err != nil {
+			return nil, err
+		}
+		laporan.TotalDibayar += jumlah
+		if p.JenisPenerima == domain.PenerimaAmil && p.NomorBukti != "" {
+			laporan.TotalDapatDikurangkan += jumlah
+		}
+	}
+	return laporan, nil
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/averroes/backend-prabogo/internal/domain"
+	"github.com/averroes/backend-prabogo/pkg/hijri"
+)
+
+func TestJumlahPembayaran(t *testing.T) {
+	// Kurs hari ini 16000; pembayaran USD dicatat saat kurs 15000.
+	konverter := &KonverterKurs{dasar: "USD", kurs: map[[2]string]float64{{"USD", "IDR"}: 16000}}
+	terkunci := uangUji(t, "1500000")
+	tests := []struct {
+		nama       string
+		pembayaran domain.PembayaranZakat
+		tampilan   string
+		ingin      string
+	}{
+		{"mata uang sama", domain.PembayaranZakat{Jumlah: uangUji(t, "100"), MataUang: "USD", JumlahIDR: &terkunci}, "USD", "100"},
+		{"rupiah memakai nilai terkunci", domain.PembayaranZakat{Jumlah: uangUji(t, "100"), MataUang: "USD", JumlahIDR: &terkunci}, "IDR", "1500000"},
+		{"tanpa nilai terkunci memakai kurs terkini", domain.PembayaranZakat{Jumlah: uangUji(t, "100"), MataUang: "USD"}, "IDR", "1600000"},
+		{"mata uang kosong dianggap tampilan", domain.PembayaranZakat{Jumlah: uangUji(t, "250000")}, "IDR", "250000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			got, err := jumlahPembayaran(konverter, tt.pembayaran, tt.tampilan)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != uangUji(t, tt.ingin) {
+				t.Errorf("jumlahPembayaran = %s, ingin %s", got, tt.ingin)
+			}
+		})
+	}
+}
+
+func tanggalHijriUji(tahun, bulan, hari int) time.Time {
+	return hijri.Tanggal{Tahun: tahun, Bulan: bulan, Hari: hari}.Masehi(time.Local)
+}
+
+func TestSusunStatusPembayaran(t *testing.T) {
+	konverter := &KonverterKurs{dasar: "USD", kurs: map[[2]string]float64{{"USD", "IDR"}: 16000}}
+	parameter := &domain.ParameterZakat{PersenZakat: 2.5, MataUang: "IDR"}
+	tempo1 := tanggalHijriUji(1445, 12, 20)
+	tempo2 := tanggalHijriUji(1446, 12, 20)
+	tercapai := []titikHaul{
+		{tanggal: tempo2, nilai: uangUji(t, "200000000")},
+		{tanggal: tempo1, nilai: uangUji(t, "100000000")},
+	}
+	dikunci := uangUji(t, "1500000")
+	pembayaran := []domain.PembayaranZakat{
+		{Jenis: domain.ZakatMaal, TahunHaul: 1445, Jumlah: uangUji(t, "2500000"), MataUang: "IDR"},
+		// Nilai rupiah dikunci saat bayar (kurs 15000), bukan kurs hari ini.
+		{Jenis: domain.ZakatMaal, TahunHaul: 1446, Jumlah: uangUji(t, "100"), MataUang: "USD", JumlahIDR: &dikunci},
+		{Jenis: domain.ZakatFitrah, TahunHaul: 1446, Jumlah: uangUji(t, "50000"), MataUang: "IDR"},
+		// Tahun tanpa haul tetap muncul sebagai kelebihan bayar.
+		{Jenis: domain.ZakatMaal, TahunHaul: 1447, Jumlah: uangUji(t, "100000"), MataUang: "IDR"},
+	}
+
+	status, err := susunStatusPembayaran(parameter, tercapai, pembayaran, konverter, "IDR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ingin := []struct {
+		tahun                  int
+		wajib, dibayar, sisa   string
+		lunas, punyaJatuhTempo bool
+	}{
+		{1445, "2500000", "2500000", "0", true, true},
+		{1446, "5000000", "1500000", "3500000", false, true},
+		{1447, "0", "100000", "0", true, false},
+	}
+	if len(status.TahunHaul) != len(ingin) {
+		t.Fatalf("tahun haul = %d, ingin %d", len(status.TahunHaul), len(ingin))
+	}
+	for i, w := range ingin {
+		s := status.TahunHaul[i]
+		if s.TahunHaul != w.tahun || s.ZakatWajib != uangUji(t, w.wajib) || s.Dibayar != uangUji(t, w.dibayar) || s.Sisa != uangUji(t, w.sisa) || s.Lunas != w.lunas || (s.JatuhTempo != nil) != w.punyaJatuhTempo {
+			t.Errorf("tahun ke-%d = %+v, ingin %+v", i, s, w)
+		}
+	}
+	if status.TotalWajib != uangUji(t, "7500000") || status.TotalDibayar != uangUji(t, "4100000") || status.TotalSisa != uangUji(t, "3500000") {
+		t.Errorf("total wajib %s dibayar %s sisa %s", status.TotalWajib, status.TotalDibayar, status.TotalSisa)
+	}
+}
+
+func TestTahunHaulPembayaran(t *testing.T) {
+	tempo1 := tanggalHijriUji(1445, 1, 10)
+	tempo2 := tanggalHijriUji(1446, 1, 10)
+	belumLunas := []domain.StatusTahunHaul{
+		{TahunHaul: 1445, JatuhTempo: &tempo1, Sisa: 100},
+		{TahunHaul: 1446, JatuhTempo: &tempo2, Sisa: 100},
+	}
+	haul1445Lunas := []domain.StatusTahunHaul{
+		{TahunHaul: 1445, JatuhTempo: &tempo1, Lunas: true},
+		{TahunHaul: 1446, JatuhTempo: &tempo2, Sisa: 100},
+	}
+	semuaLunas := []domain.StatusTahunHaul{{TahunHaul: 1445, JatuhTempo: &tempo1, Lunas: true}}
+	berjalan := &domain.StatusHaul{JatuhTempo: &tempo2}
+
+	tests := []struct {
+		nama    string
+		status  []domain.StatusTahunHaul
+		haul    *domain.StatusHaul
+		tanggal time.Time
+		ingin   int
+	}{
+		// Dibayar di tahun Hijriah 1446 untuk haul 1445 yang belum dilunasi.
+		{"dibayar setelah pergantian tahun", belumLunas, nil, tanggalHijriUji(1446, 1, 20), 1445},
+		{"haul terawal yang masih bersisa", haul1445Lunas, nil, tanggalHijriUji(1446, 2, 1), 1446},
+		// Dibayar di tahun 1445 sebelum haul 1446 jatuh tempo.
+		{"didahulukan untuk haul berikutnya", haul1445Lunas, nil, tanggalHijriUji(1445, 12, 25), 1446},
+		{"didahulukan untuk haul berjalan", semuaLunas, berjalan, tanggalHijriUji(1445, 12, 25), 1446},
+		{"tanpa haul memakai tahun tanggal bayar", nil, nil, tanggalHijriUji(1445, 6, 1), 1445},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nama, func(t *testing.T) {
+			if got := tahunHaulPembayaran(tt.status, tt.haul, tt.tanggal); got != tt.ingin {
+				t.Errorf("tahunHaulPembayaran = %d, ingin %d", got, tt.ingin)
+			}
+		})
+	}
+}
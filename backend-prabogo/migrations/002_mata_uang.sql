@@ -0,0 +1,20 @@
+ALTER TABLE pengguna ADD COLUMN mata_uang CHAR(3) NOT NULL DEFAULT 'IDR';
+
+ALTER TABLE pasar ADD COLUMN mata_uang CHAR(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE portofolio ADD COLUMN mata_uang CHAR(3) NOT NULL DEFAULT 'IDR';
+
+ALTER TABLE harga_emas ADD COLUMN mata_uang CHAR(3) NOT NULL DEFAULT 'IDR';
+
+ALTER TABLE zakat_riwayat ADD COLUMN mata_uang CHAR(3) NOT NULL DEFAULT 'IDR';
+
+CREATE TABLE IF NOT EXISTS kurs (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  mata_uang_asal CHAR(3) NOT NULL,
+  mata_uang_tujuan CHAR(3) NOT NULL,
+  nilai DECIMAL(24,10) NOT NULL,
+  sumber VARCHAR(100) NOT NULL,
+  tanggal DATE NOT NULL,
+  diperbarui_pada DATETIME NOT NULL,
+  UNIQUE KEY uk_kurs (mata_uang_asal, mata_uang_tujuan, tanggal)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
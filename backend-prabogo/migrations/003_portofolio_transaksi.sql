@@ -0,0 +1,45 @@
+CREATE TABLE IF NOT EXISTS portofolio_transaksi (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL,
+  jenis VARCHAR(20) NOT NULL,
+  nama_aset VARCHAR(150) NOT NULL,
+  simbol VARCHAR(20) NOT NULL,
+  kategori VARCHAR(50) NOT NULL,
+  jumlah DECIMAL(20,8) NOT NULL DEFAULT 0,
+  harga DECIMAL(20,4) NOT NULL DEFAULT 0,
+  biaya DECIMAL(20,4) NOT NULL DEFAULT 0,
+  mata_uang CHAR(3) NOT NULL DEFAULT 'IDR',
+  tanggal DATETIME NOT NULL,
+  catatan VARCHAR(255) NOT NULL DEFAULT '',
+  dibuat_pada DATETIME NOT NULL,
+  INDEX idx_transaksi_pengguna_simbol (id_pengguna, simbol, tanggal),
+  CONSTRAINT fk_transaksi_pengguna FOREIGN KEY (id_pengguna) REFERENCES pengguna(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- ID posisi yang stabil untuk /portofolio/{id}. Posisi diturunkan dari
+-- transaksi per simbol dan mata uang; tabel ini mengikat ID ke kunci tersebut
+-- agar tidak bergantung pada transaksi yang dapat dihapus. Satu posisi boleh
+-- memiliki beberapa ID; ID terkecil yang ditampilkan.
+CREATE TABLE IF NOT EXISTS portofolio_posisi (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL,
+  simbol VARCHAR(20) NOT NULL,
+  mata_uang CHAR(3) NOT NULL,
+  dibuat_pada DATETIME NOT NULL,
+  INDEX idx_posisi_pengguna (id_pengguna, simbol, mata_uang),
+  CONSTRAINT fk_posisi_pengguna FOREIGN KEY (id_pengguna) REFERENCES pengguna(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- ID lama tetap berlaku, termasuk baris lama bersimbol sama yang kini
+-- tergabung dalam satu posisi.
+INSERT INTO portofolio_posisi (id, id_pengguna, simbol, mata_uang, dibuat_pada)
+SELECT id, id_pengguna, UPPER(simbol), mata_uang, dibuat_pada
+FROM portofolio;
+
+INSERT INTO portofolio_transaksi (id, id_pengguna, jenis, nama_aset, simbol, kategori, jumlah, harga, biaya, mata_uang, tanggal, catatan, dibuat_pada)
+SELECT id, id_pengguna, 'beli', nama_aset, simbol, kategori, jumlah, harga_beli, 0, mata_uang, dibuat_pada, 'Migrasi dari portofolio', dibuat_pada
+FROM portofolio;
+
+-- Tabel lama disimpan agar nilai_saat_ini isian pengguna bisa dipindahkan ke
+-- penilaian manual pada migrasi berikutnya.
+RENAME TABLE portofolio TO portofolio_lama;
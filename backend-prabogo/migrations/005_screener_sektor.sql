@@ -0,0 +1,3 @@
+ALTER TABLE screener ADD COLUMN sektor VARCHAR(50) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_screener_simbol ON screener (simbol);
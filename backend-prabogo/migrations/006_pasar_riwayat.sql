@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS pasar_riwayat (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  simbol VARCHAR(20) NOT NULL,
+  tanggal DATE NOT NULL,
+  harga DECIMAL(20,4) NOT NULL,
+  mata_uang CHAR(3) NOT NULL,
+  UNIQUE KEY uk_pasar_riwayat (simbol, tanggal)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+INSERT INTO pasar_riwayat (simbol, tanggal, harga, mata_uang)
+SELECT simbol, DATE(diperbarui_pada), harga, mata_uang FROM pasar
+ON DUPLICATE KEY UPDATE harga = VALUES(harga), mata_uang = VALUES(mata_uang);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS portofolio_snapshot (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL,
+  tanggal DATE NOT NULL,
+  total_nilai DECIMAL(20,4) NOT NULL,
+  total_modal DECIMAL(20,4) NOT NULL,
+  mata_uang CHAR(3) NOT NULL,
+  jumlah_aset INT NOT NULL DEFAULT 0,
+  dibuat_pada DATETIME NOT NULL,
+  UNIQUE KEY uk_snapshot_pengguna_tanggal (id_pengguna, tanggal),
+  CONSTRAINT fk_snapshot_pengguna FOREIGN KEY (id_pengguna) REFERENCES pengguna(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
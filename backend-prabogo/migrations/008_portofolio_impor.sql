@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS portofolio_impor (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL,
+  format VARCHAR(30) NOT NULL,
+  nama_berkas VARCHAR(255) NOT NULL DEFAULT '',
+  status VARCHAR(20) NOT NULL,
+  jumlah_baru INT NOT NULL DEFAULT 0,
+  jumlah_duplikat INT NOT NULL DEFAULT 0,
+  jumlah_gagal INT NOT NULL DEFAULT 0,
+  baris LONGTEXT NOT NULL,
+  dibuat_pada DATETIME NOT NULL,
+  diterapkan_pada DATETIME NULL,
+  INDEX idx_impor_pengguna (id_pengguna),
+  CONSTRAINT fk_impor_pengguna FOREIGN KEY (id_pengguna) REFERENCES pengguna(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
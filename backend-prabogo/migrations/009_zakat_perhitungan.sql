@@ -0,0 +1,13 @@
+ALTER TABLE zakat_riwayat ADD COLUMN tanggal DATE NULL;
+ALTER TABLE zakat_riwayat ADD COLUMN wajib_zakat TINYINT(1) NOT NULL DEFAULT 1;
+ALTER TABLE zakat_riwayat ADD COLUMN harga_emas_per_gram DECIMAL(20,4) NOT NULL DEFAULT 0;
+ALTER TABLE zakat_riwayat ADD COLUMN rincian LONGTEXT NULL;
+
+UPDATE zakat_riwayat SET tanggal = DATE(dibuat_pada);
+
+-- Ringkasan lama mencatat riwayat setiap kali dibuka; simpan hanya yang terakhir per hari.
+DELETE z1 FROM zakat_riwayat z1
+JOIN zakat_riwayat z2 ON z1.id_pengguna = z2.id_pengguna AND z1.tanggal = z2.tanggal AND z1.id < z2.id;
+
+ALTER TABLE zakat_riwayat MODIFY tanggal DATE NOT NULL;
+ALTER TABLE zakat_riwayat ADD UNIQUE KEY uk_zakat_pengguna_tanggal (id_pengguna, tanggal);
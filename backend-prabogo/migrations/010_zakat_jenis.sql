@@ -0,0 +1,5 @@
+ALTER TABLE zakat_riwayat ADD COLUMN jenis VARCHAR(20) NOT NULL DEFAULT 'maal';
+ALTER TABLE zakat_riwayat ADD COLUMN masukan LONGTEXT NULL;
+
+ALTER TABLE zakat_riwayat DROP INDEX uk_zakat_pengguna_tanggal;
+ALTER TABLE zakat_riwayat ADD UNIQUE KEY uk_zakat_pengguna_jenis_tanggal (id_pengguna, jenis, tanggal);
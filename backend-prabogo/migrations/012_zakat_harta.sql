@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS zakat_harta (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL,
+  kelompok VARCHAR(20) NOT NULL,
+  jenis VARCHAR(20) NOT NULL,
+  nama VARCHAR(150) NOT NULL,
+  nilai DECIMAL(20,4) NOT NULL,
+  mata_uang CHAR(3) NOT NULL DEFAULT 'IDR',
+  catatan VARCHAR(255) NOT NULL DEFAULT '',
+  dibuat_pada DATETIME NOT NULL,
+  diperbarui_pada DATETIME NOT NULL,
+  INDEX idx_zakat_harta_pengguna (id_pengguna),
+  CONSTRAINT fk_zakat_harta_pengguna FOREIGN KEY (id_pengguna) REFERENCES pengguna(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
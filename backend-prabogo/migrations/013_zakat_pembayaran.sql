@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS zakat_pembayaran (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL,
+  jenis VARCHAR(20) NOT NULL DEFAULT 'maal',
+  tahun_haul INT NOT NULL,
+  tanggal DATE NOT NULL,
+  jumlah DECIMAL(20,4) NOT NULL,
+  mata_uang CHAR(3) NOT NULL DEFAULT 'IDR',
+  jenis_penerima VARCHAR(20) NOT NULL,
+  penerima VARCHAR(150) NOT NULL,
+  asnaf VARCHAR(20) NOT NULL DEFAULT '',
+  nomor_bukti VARCHAR(100) NOT NULL DEFAULT '',
+  bukti_url TEXT,
+  catatan VARCHAR(255) NOT NULL DEFAULT '',
+  dibuat_pada DATETIME NOT NULL,
+  diperbarui_pada DATETIME NOT NULL,
+  INDEX idx_zakat_pembayaran_pengguna_tanggal (id_pengguna, tanggal),
+  CONSTRAINT fk_zakat_pembayaran_pengguna FOREIGN KEY (id_pengguna) REFERENCES pengguna(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
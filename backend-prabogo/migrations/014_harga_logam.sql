@@ -0,0 +1,7 @@
+ALTER TABLE harga_emas ADD COLUMN sumber VARCHAR(50) NOT NULL DEFAULT 'manual';
+
+-- Satu harga per logam, tanggal dan mata uang; impor dan sinkronisasi menimpa harga hari yang sama.
+DELETE h1 FROM harga_emas h1
+JOIN harga_emas h2 ON h1.logam = h2.logam AND h1.tanggal = h2.tanggal AND h1.mata_uang = h2.mata_uang AND h1.id < h2.id;
+
+ALTER TABLE harga_emas ADD UNIQUE KEY uk_harga_emas_logam_tanggal_mata_uang (logam, tanggal, mata_uang);
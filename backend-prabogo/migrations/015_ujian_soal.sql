@@ -0,0 +1,45 @@
+ALTER TABLE ujian ADD COLUMN nilai_lulus INT NOT NULL DEFAULT 70;
+ALTER TABLE ujian ADD COLUMN soal_per_percobaan INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS ujian_soal (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  id_ujian BIGINT NOT NULL,
+  tipe VARCHAR(20) NOT NULL,
+  pertanyaan TEXT NOT NULL,
+  jawaban_isian VARCHAR(255) NOT NULL DEFAULT '',
+  pembahasan TEXT,
+  bobot INT NOT NULL DEFAULT 1,
+  urutan INT NOT NULL DEFAULT 0,
+  dibuat_pada DATETIME NOT NULL,
+  INDEX idx_ujian_soal_ujian (id_ujian, urutan),
+  CONSTRAINT fk_ujian_soal_ujian FOREIGN KEY (id_ujian) REFERENCES ujian(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS ujian_opsi (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  id_soal BIGINT NOT NULL,
+  teks VARCHAR(500) NOT NULL,
+  benar TINYINT(1) NOT NULL DEFAULT 0,
+  urutan INT NOT NULL DEFAULT 0,
+  INDEX idx_ujian_opsi_soal (id_soal),
+  CONSTRAINT fk_ujian_opsi_soal FOREIGN KEY (id_soal) REFERENCES ujian_soal(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Urutan soal yang diundi dan jawaban peserta disimpan sebagai JSON agar
+-- penilaian tetap memakai soal yang sama walau bank soal diubah.
+CREATE TABLE IF NOT EXISTS ujian_percobaan (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  id_ujian BIGINT NOT NULL,
+  id_pengguna BIGINT NOT NULL,
+  soal LONGTEXT NOT NULL,
+  jawaban LONGTEXT,
+  status VARCHAR(20) NOT NULL DEFAULT 'berjalan',
+  skor DECIMAL(5,2) NOT NULL DEFAULT 0,
+  lulus TINYINT(1) NOT NULL DEFAULT 0,
+  mulai_pada DATETIME NOT NULL,
+  batas_waktu DATETIME NULL,
+  selesai_pada DATETIME NULL,
+  INDEX idx_ujian_percobaan_pengguna (id_pengguna, id_ujian),
+  CONSTRAINT fk_ujian_percobaan_ujian FOREIGN KEY (id_ujian) REFERENCES ujian(id) ON DELETE CASCADE,
+  CONSTRAINT fk_ujian_percobaan_pengguna FOREIGN KEY (id_pengguna) REFERENCES pengguna(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
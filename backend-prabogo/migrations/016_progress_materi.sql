@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS progress_materi (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL,
+  id_materi BIGINT NOT NULL,
+  posisi_detik INT NOT NULL DEFAULT 0,
+  selesai TINYINT(1) NOT NULL DEFAULT 0,
+  selesai_pada DATETIME NULL,
+  diperbarui_pada DATETIME NOT NULL,
+  UNIQUE KEY uk_progress_materi (id_pengguna, id_materi),
+  CONSTRAINT fk_progress_materi_pengguna FOREIGN KEY (id_pengguna) REFERENCES pengguna(id) ON DELETE CASCADE,
+  CONSTRAINT fk_progress_materi_materi FOREIGN KEY (id_materi) REFERENCES materi(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
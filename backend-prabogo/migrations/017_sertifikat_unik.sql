@@ -0,0 +1,11 @@
+-- Satu sertifikat per peserta dan kelas; sertifikat tertua dipertahankan.
+DELETE s1 FROM sertifikat s1
+JOIN sertifikat s2 ON s1.id_pengguna = s2.id_pengguna AND s1.id_kelas = s2.id_kelas AND s1.id > s2.id;
+
+-- Kode ganda dari input manual dibedakan dengan ID agar verifikasi tidak ambigu.
+UPDATE sertifikat s1
+JOIN sertifikat s2 ON s1.kode = s2.kode AND s1.id > s2.id
+SET s1.kode = CONCAT(s1.kode, '-', s1.id);
+
+ALTER TABLE sertifikat ADD UNIQUE KEY uk_sertifikat_pengguna_kelas (id_pengguna, id_kelas);
+ALTER TABLE sertifikat ADD UNIQUE KEY uk_sertifikat_kode (kode);
@@ -0,0 +1,18 @@
+-- Alur terbit konten edukasi: draf -> review -> terbit -> arsip. Konten hanya
+-- tampil di endpoint publik bila berstatus terbit dan terbit_pada (bila diisi)
+-- sudah lewat.
+UPDATE kelas SET status = 'terbit' WHERE status IN ('publik', 'published', 'aktif');
+UPDATE kelas SET status = 'draf' WHERE status NOT IN ('draf', 'review', 'terbit', 'arsip');
+ALTER TABLE kelas ADD COLUMN terbit_pada DATETIME NULL;
+ALTER TABLE kelas ADD COLUMN token_pratinjau VARCHAR(64) NULL;
+ALTER TABLE kelas ADD COLUMN pratinjau_kadaluarsa DATETIME NULL;
+ALTER TABLE kelas ADD UNIQUE KEY uk_kelas_token_pratinjau (token_pratinjau);
+
+-- Modul dan materi yang sudah ada tetap tampil; konten baru dimulai dari draf.
+ALTER TABLE modul ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'terbit';
+ALTER TABLE modul ADD COLUMN terbit_pada DATETIME NULL;
+ALTER TABLE modul ALTER COLUMN status SET DEFAULT 'draf';
+
+ALTER TABLE materi ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'terbit';
+ALTER TABLE materi ADD COLUMN terbit_pada DATETIME NULL;
+ALTER TABLE materi ALTER COLUMN status SET DEFAULT 'draf';
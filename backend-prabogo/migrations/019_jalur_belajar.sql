@@ -0,0 +1,30 @@
+-- Prasyarat antarkelas: id_prasyarat harus selesai sebelum id_kelas dimulai.
+CREATE TABLE IF NOT EXISTS kelas_prasyarat (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  id_kelas BIGINT NOT NULL,
+  id_prasyarat BIGINT NOT NULL,
+  UNIQUE KEY uk_kelas_prasyarat (id_kelas, id_prasyarat),
+  CONSTRAINT fk_prasyarat_kelas FOREIGN KEY (id_kelas) REFERENCES kelas(id) ON DELETE CASCADE,
+  CONSTRAINT fk_prasyarat_syarat FOREIGN KEY (id_prasyarat) REFERENCES kelas(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Jalur belajar kurasi berisi kelas berurutan; mengikuti alur terbit yang sama
+-- dengan kelas.
+CREATE TABLE IF NOT EXISTS jalur_belajar (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  judul VARCHAR(200) NOT NULL,
+  deskripsi TEXT NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'draf',
+  terbit_pada DATETIME NULL,
+  dibuat_pada DATETIME NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS jalur_kelas (
+  id BIGINT AUTO_INCREMENT PRIMARY KEY,
+  id_jalur BIGINT NOT NULL,
+  id_kelas BIGINT NOT NULL,
+  urutan INT NOT NULL,
+  UNIQUE KEY uk_jalur_kelas (id_jalur, id_kelas),
+  CONSTRAINT fk_jalur_kelas_jalur FOREIGN KEY (id_jalur) REFERENCES jalur_belajar(id) ON DELETE CASCADE,
+  CONSTRAINT fk_jalur_kelas_kelas FOREIGN KEY (id_kelas) REFERENCES kelas(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,5 @@
+-- Jumlah modul dan durasi kelas, durasi modul serta jumlah soal ujian kini
+-- dihitung dari baris anaknya saat dibaca sehingga kolom isian manualnya dihapus.
+ALTER TABLE kelas DROP COLUMN jumlah_modul, DROP COLUMN durasi_menit;
+ALTER TABLE modul DROP COLUMN durasi_menit;
+ALTER TABLE ujian DROP COLUMN jumlah_soal;
@@ -0,0 +1,2 @@
+-- Batas jumlah percobaan (0 berarti tanpa batas) dan jeda menit antarpercobaan.
+ALTER TABLE ujian ADD COLUMN maks_percobaan INT NOT NULL DEFAULT 0, ADD COLUMN jeda_menit INT NOT NULL DEFAULT 0;
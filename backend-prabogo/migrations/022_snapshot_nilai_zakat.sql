@@ -0,0 +1,3 @@
+-- Harta bersih zakat harian (portofolio ditambah harta di luar portofolio
+-- dikurangi utang) agar haul ditelusuri dari dasar yang sama dengan zakat.
+ALTER TABLE portofolio_snapshot ADD COLUMN nilai_zakat DECIMAL(20,4) NULL AFTER total_modal;
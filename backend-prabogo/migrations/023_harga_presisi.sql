@@ -0,0 +1,6 @@
+-- Harga per unit memakai 8 digit desimal agar harga token yang sangat kecil
+-- tidak terbulatkan menjadi nol; nilai uang tetap DECIMAL(20,4).
+ALTER TABLE pasar MODIFY harga DECIMAL(28,8) NOT NULL;
+ALTER TABLE pasar_riwayat MODIFY harga DECIMAL(28,8) NOT NULL;
+ALTER TABLE portofolio_transaksi MODIFY harga DECIMAL(28,8) NOT NULL DEFAULT 0;
+ALTER TABLE portofolio_nilai_manual MODIFY harga DECIMAL(28,8) NOT NULL;
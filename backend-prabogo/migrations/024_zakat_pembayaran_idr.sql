@@ -0,0 +1,5 @@
+-- Nilai rupiah pembayaran dikunci dengan kurs saat dicatat agar laporan pajak
+-- tahunan tidak berubah mengikuti kurs hari ini. Pembayaran non-rupiah lama
+-- dibiarkan kosong dan tetap dihitung dengan kurs terkini.
+ALTER TABLE zakat_pembayaran ADD COLUMN jumlah_idr DECIMAL(20,4) NULL AFTER mata_uang;
+UPDATE zakat_pembayaran SET jumlah_idr = jumlah WHERE mata_uang = 'IDR';
@@ -0,0 +1,4 @@
+-- Kelas aset pada data pasar agar simbol yang belum disaring tetap mendapat
+-- alternatif halal dari kelas aset yang sama. Seluruh data pasar yang sudah
+-- ada adalah token kripto.
+ALTER TABLE pasar ADD COLUMN kategori_aset VARCHAR(30) NOT NULL DEFAULT 'kripto' AFTER simbol;
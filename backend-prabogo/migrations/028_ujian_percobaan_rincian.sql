@@ -0,0 +1,3 @@
+-- Rincian penilaian per soal disimpan saat percobaan dikirim sehingga hasil
+-- yang ditampilkan kemudian tetap sesuai skor walau kunci jawaban diubah.
+ALTER TABLE ujian_percobaan ADD COLUMN rincian LONGTEXT NULL AFTER jawaban;
@@ -0,0 +1,227 @@
+CREATE TABLE IF NOT EXISTS pengguna (
+  id BIGSERIAL PRIMARY KEY,
+  nama VARCHAR(150) NOT NULL,
+  email VARCHAR(150) NOT NULL UNIQUE,
+  kata_sandi_hash VARCHAR(255) NOT NULL,
+  peran VARCHAR(50) NOT NULL,
+  status VARCHAR(50) NOT NULL,
+  sudah_verifikasi BOOLEAN NOT NULL DEFAULT FALSE,
+  dibuat_pada TIMESTAMP NOT NULL,
+  diubah_pada TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS otp_verifikasi (
+  id BIGSERIAL PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL REFERENCES pengguna(id) ON DELETE CASCADE,
+  kode VARCHAR(10) NOT NULL,
+  kadaluarsa_pada TIMESTAMP NOT NULL,
+  terakhir_kirim_pada TIMESTAMP NOT NULL,
+  jumlah_kirim INT NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS idx_otp_pengguna ON otp_verifikasi (id_pengguna);
+
+CREATE TABLE IF NOT EXISTS screener (
+  id BIGSERIAL PRIMARY KEY,
+  nama_aset VARCHAR(150) NOT NULL,
+  simbol VARCHAR(20) NOT NULL,
+  kategori VARCHAR(50) NOT NULL,
+  skor_syariah NUMERIC(5,2) NOT NULL,
+  keterangan TEXT NOT NULL,
+  harga_terakhir NUMERIC(20,4) NOT NULL,
+  perubahan_24j NUMERIC(8,2) NOT NULL,
+  dibuat_pada TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS screener_catatan (
+  id BIGSERIAL PRIMARY KEY,
+  id_screener BIGINT NOT NULL REFERENCES screener(id) ON DELETE CASCADE,
+  judul VARCHAR(150) NOT NULL,
+  isi TEXT NOT NULL,
+  dibuat_pada TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_catatan_screener ON screener_catatan (id_screener);
+
+CREATE TABLE IF NOT EXISTS pasar (
+  id BIGSERIAL PRIMARY KEY,
+  nama_aset VARCHAR(150) NOT NULL,
+  simbol VARCHAR(20) NOT NULL,
+  harga NUMERIC(20,4) NOT NULL,
+  volume_24j NUMERIC(20,4) NOT NULL,
+  perubahan_24j NUMERIC(8,2) NOT NULL,
+  kapitalisasi_pasar NUMERIC(20,4) NOT NULL,
+  diperbarui_pada TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS kelas (
+  id BIGSERIAL PRIMARY KEY,
+  judul VARCHAR(150) NOT NULL,
+  deskripsi TEXT NOT NULL,
+  level VARCHAR(50) NOT NULL,
+  jumlah_modul INT NOT NULL DEFAULT 0,
+  durasi_menit INT NOT NULL DEFAULT 0,
+  thumbnail_url TEXT NOT NULL,
+  status VARCHAR(50) NOT NULL,
+  dibuat_pada TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS modul (
+  id BIGSERIAL PRIMARY KEY,
+  id_kelas BIGINT NOT NULL REFERENCES kelas(id) ON DELETE CASCADE,
+  judul VARCHAR(150) NOT NULL,
+  urutan INT NOT NULL,
+  ringkasan TEXT NOT NULL,
+  durasi_menit INT NOT NULL DEFAULT 0,
+  dibuat_pada TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_modul_kelas ON modul (id_kelas);
+
+CREATE TABLE IF NOT EXISTS materi (
+  id BIGSERIAL PRIMARY KEY,
+  id_modul BIGINT NOT NULL REFERENCES modul(id) ON DELETE CASCADE,
+  judul VARCHAR(150) NOT NULL,
+  tipe VARCHAR(50) NOT NULL,
+  konten TEXT NOT NULL,
+  url_video TEXT NOT NULL,
+  durasi_menit INT NOT NULL DEFAULT 0,
+  dibuat_pada TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_materi_modul ON materi (id_modul);
+
+CREATE TABLE IF NOT EXISTS ujian (
+  id BIGSERIAL PRIMARY KEY,
+  id_kelas BIGINT NOT NULL REFERENCES kelas(id) ON DELETE CASCADE,
+  judul VARCHAR(150) NOT NULL,
+  deskripsi TEXT NOT NULL,
+  durasi_menit INT NOT NULL DEFAULT 0,
+  jumlah_soal INT NOT NULL DEFAULT 0,
+  dibuat_pada TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_ujian_kelas ON ujian (id_kelas);
+
+CREATE TABLE IF NOT EXISTS progress_kelas (
+  id BIGSERIAL PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL REFERENCES pengguna(id) ON DELETE CASCADE,
+  id_kelas BIGINT NOT NULL REFERENCES kelas(id) ON DELETE CASCADE,
+  persentase NUMERIC(5,2) NOT NULL DEFAULT 0,
+  status VARCHAR(50) NOT NULL,
+  terakhir_diakses_pada TIMESTAMP NOT NULL,
+  CONSTRAINT uk_progress UNIQUE (id_pengguna, id_kelas)
+);
+
+CREATE TABLE IF NOT EXISTS sertifikat (
+  id BIGSERIAL PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL REFERENCES pengguna(id) ON DELETE CASCADE,
+  id_kelas BIGINT NOT NULL REFERENCES kelas(id) ON DELETE CASCADE,
+  kode VARCHAR(100) NOT NULL,
+  tanggal_terbit TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_sertifikat_pengguna ON sertifikat (id_pengguna);
+
+CREATE TABLE IF NOT EXISTS pustaka (
+  id BIGSERIAL PRIMARY KEY,
+  judul_tampil VARCHAR(200) NOT NULL,
+  judul_asli VARCHAR(200) NOT NULL,
+  penulis VARCHAR(150) NOT NULL,
+  kategori VARCHAR(100) NOT NULL,
+  bahasa VARCHAR(100) NOT NULL,
+  jumlah_halaman INT NOT NULL,
+  deskripsi TEXT NOT NULL,
+  tautan_file TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS berita (
+  id BIGSERIAL PRIMARY KEY,
+  judul VARCHAR(200) NOT NULL,
+  ringkasan TEXT NOT NULL,
+  isi TEXT NOT NULL,
+  kategori VARCHAR(100) NOT NULL,
+  sumber VARCHAR(150) NOT NULL,
+  gambar_url TEXT NOT NULL,
+  diterbitkan_pada TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS diskusi (
+  id BIGSERIAL PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL REFERENCES pengguna(id) ON DELETE CASCADE,
+  judul VARCHAR(200) NOT NULL,
+  isi TEXT NOT NULL,
+  status VARCHAR(50) NOT NULL,
+  dibuat_pada TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_diskusi_pengguna ON diskusi (id_pengguna);
+
+CREATE TABLE IF NOT EXISTS diskusi_balas (
+  id BIGSERIAL PRIMARY KEY,
+  id_diskusi BIGINT NOT NULL REFERENCES diskusi(id) ON DELETE CASCADE,
+  id_pengguna BIGINT NOT NULL REFERENCES pengguna(id) ON DELETE CASCADE,
+  isi TEXT NOT NULL,
+  dibuat_pada TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_diskusi_balas ON diskusi_balas (id_diskusi);
+
+CREATE TABLE IF NOT EXISTS diskusi_laporan (
+  id BIGSERIAL PRIMARY KEY,
+  id_diskusi BIGINT NOT NULL REFERENCES diskusi(id) ON DELETE CASCADE,
+  id_pengguna BIGINT NOT NULL REFERENCES pengguna(id) ON DELETE CASCADE,
+  alasan TEXT NOT NULL,
+  dibuat_pada TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_laporan_diskusi ON diskusi_laporan (id_diskusi);
+
+CREATE TABLE IF NOT EXISTS portofolio (
+  id BIGSERIAL PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL REFERENCES pengguna(id) ON DELETE CASCADE,
+  nama_aset VARCHAR(150) NOT NULL,
+  simbol VARCHAR(20) NOT NULL,
+  jumlah NUMERIC(20,8) NOT NULL,
+  harga_beli NUMERIC(20,4) NOT NULL,
+  nilai_saat_ini NUMERIC(20,4) NOT NULL,
+  kategori VARCHAR(50) NOT NULL,
+  dibuat_pada TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_portofolio_pengguna ON portofolio (id_pengguna);
+
+CREATE TABLE IF NOT EXISTS zakat_riwayat (
+  id BIGSERIAL PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL REFERENCES pengguna(id) ON DELETE CASCADE,
+  total_nilai NUMERIC(20,4) NOT NULL,
+  nisab NUMERIC(20,4) NOT NULL,
+  persen_zakat NUMERIC(6,2) NOT NULL,
+  zakat_terhitung NUMERIC(20,4) NOT NULL,
+  dibuat_pada TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_zakat_pengguna ON zakat_riwayat (id_pengguna);
+
+CREATE TABLE IF NOT EXISTS harga_emas (
+  id BIGSERIAL PRIMARY KEY,
+  tanggal DATE NOT NULL,
+  harga_per_gram NUMERIC(20,4) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS reels (
+  id BIGSERIAL PRIMARY KEY,
+  judul VARCHAR(200) NOT NULL,
+  tema VARCHAR(100) NOT NULL,
+  kutipan TEXT NOT NULL,
+  sumber VARCHAR(150) NOT NULL,
+  url_video TEXT NOT NULL,
+  thumbnail_url TEXT NOT NULL,
+  dibuat_pada TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS tadabbur (
+  id BIGSERIAL PRIMARY KEY,
+  judul VARCHAR(200) NOT NULL,
+  tema VARCHAR(100) NOT NULL,
+  ringkasan TEXT NOT NULL,
+  isi TEXT NOT NULL,
+  sumber VARCHAR(150) NOT NULL,
+  dibuat_pada TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS konfigurasi (
+  id BIGSERIAL PRIMARY KEY,
+  kunci VARCHAR(100) NOT NULL,
+  nilai TEXT NOT NULL,
+  deskripsi TEXT NOT NULL
+);
@@ -0,0 +1,20 @@
+ALTER TABLE pengguna ADD COLUMN IF NOT EXISTS mata_uang CHAR(3) NOT NULL DEFAULT 'IDR';
+
+ALTER TABLE pasar ADD COLUMN IF NOT EXISTS mata_uang CHAR(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE portofolio ADD COLUMN IF NOT EXISTS mata_uang CHAR(3) NOT NULL DEFAULT 'IDR';
+
+ALTER TABLE harga_emas ADD COLUMN IF NOT EXISTS mata_uang CHAR(3) NOT NULL DEFAULT 'IDR';
+
+ALTER TABLE zakat_riwayat ADD COLUMN IF NOT EXISTS mata_uang CHAR(3) NOT NULL DEFAULT 'IDR';
+
+CREATE TABLE IF NOT EXISTS kurs (
+  id BIGSERIAL PRIMARY KEY,
+  mata_uang_asal CHAR(3) NOT NULL,
+  mata_uang_tujuan CHAR(3) NOT NULL,
+  nilai NUMERIC(24,10) NOT NULL,
+  sumber VARCHAR(100) NOT NULL,
+  tanggal DATE NOT NULL,
+  diperbarui_pada TIMESTAMP NOT NULL,
+  CONSTRAINT uk_kurs UNIQUE (mata_uang_asal, mata_uang_tujuan, tanggal)
+);
@@ -0,0 +1,47 @@
+CREATE TABLE IF NOT EXISTS portofolio_transaksi (
+  id BIGSERIAL PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL REFERENCES pengguna(id) ON DELETE CASCADE,
+  jenis VARCHAR(20) NOT NULL,
+  nama_aset VARCHAR(150) NOT NULL,
+  simbol VARCHAR(20) NOT NULL,
+  kategori VARCHAR(50) NOT NULL,
+  jumlah NUMERIC(20,8) NOT NULL DEFAULT 0,
+  harga NUMERIC(20,4) NOT NULL DEFAULT 0,
+  biaya NUMERIC(20,4) NOT NULL DEFAULT 0,
+  mata_uang CHAR(3) NOT NULL DEFAULT 'IDR',
+  tanggal TIMESTAMP NOT NULL,
+  catatan VARCHAR(255) NOT NULL DEFAULT '',
+  dibuat_pada TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_transaksi_pengguna_simbol ON portofolio_transaksi (id_pengguna, simbol, tanggal);
+
+-- ID posisi yang stabil untuk /portofolio/{id}. Posisi diturunkan dari
+-- transaksi per simbol dan mata uang; tabel ini mengikat ID ke kunci tersebut
+-- agar tidak bergantung pada transaksi yang dapat dihapus. Satu posisi boleh
+-- memiliki beberapa ID; ID terkecil yang ditampilkan.
+CREATE TABLE IF NOT EXISTS portofolio_posisi (
+  id BIGSERIAL PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL REFERENCES pengguna(id) ON DELETE CASCADE,
+  simbol VARCHAR(20) NOT NULL,
+  mata_uang CHAR(3) NOT NULL,
+  dibuat_pada TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_posisi_pengguna ON portofolio_posisi (id_pengguna, simbol, mata_uang);
+
+-- ID lama tetap berlaku, termasuk baris lama bersimbol sama yang kini
+-- tergabung dalam satu posisi.
+INSERT INTO portofolio_posisi (id, id_pengguna, simbol, mata_uang, dibuat_pada)
+SELECT id, id_pengguna, UPPER(simbol), mata_uang, dibuat_pada
+FROM portofolio;
+
+INSERT INTO portofolio_transaksi (id, id_pengguna, jenis, nama_aset, simbol, kategori, jumlah, harga, biaya, mata_uang, tanggal, catatan, dibuat_pada)
+SELECT id, id_pengguna, 'beli', nama_aset, simbol, kategori, jumlah, harga_beli, 0, mata_uang, dibuat_pada, 'Migrasi dari portofolio', dibuat_pada
+FROM portofolio;
+
+-- Sekuens dilanjutkan setelah ID yang disalin.
+SELECT setval(pg_get_serial_sequence('portofolio_posisi', 'id'), COALESCE((SELECT MAX(id) FROM portofolio_posisi), 0) + 1, false);
+SELECT setval(pg_get_serial_sequence('portofolio_transaksi', 'id'), COALESCE((SELECT MAX(id) FROM portofolio_transaksi), 0) + 1, false);
+
+-- Tabel lama disimpan agar nilai_saat_ini isian pengguna bisa dipindahkan ke
+-- penilaian manual pada migrasi berikutnya.
+ALTER TABLE IF EXISTS portofolio RENAME TO portofolio_lama;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS portofolio_nilai_manual (
+  id BIGSERIAL PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL REFERENCES pengguna(id) ON DELETE CASCADE,
+  simbol VARCHAR(20) NOT NULL,
+  mata_uang CHAR(3) NOT NULL,
+  harga NUMERIC(20,4) NOT NULL,
+  catatan VARCHAR(255) NOT NULL DEFAULT '',
+  diperbarui_pada TIMESTAMP NOT NULL,
+  CONSTRAINT uk_nilai_manual UNIQUE (id_pengguna, simbol, mata_uang)
+);
+
+-- Nilai saat ini yang dulu diisi manual pada tabel portofolio dipindahkan
+-- sebagai harga per unit sehingga aset tanpa harga pasar tetap bernilai.
+INSERT INTO portofolio_nilai_manual (id_pengguna, simbol, mata_uang, harga, catatan, diperbarui_pada)
+SELECT id_pengguna, simbol, mata_uang, SUM(nilai_saat_ini) / SUM(jumlah), 'Migrasi dari portofolio', MAX(dibuat_pada)
+FROM portofolio_lama
+WHERE jumlah > 0 AND nilai_saat_ini > 0
+GROUP BY id_pengguna, simbol, mata_uang;
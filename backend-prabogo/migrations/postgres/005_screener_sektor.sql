@@ -0,0 +1,3 @@
+ALTER TABLE screener ADD COLUMN IF NOT EXISTS sektor VARCHAR(50) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_screener_simbol ON screener (simbol);
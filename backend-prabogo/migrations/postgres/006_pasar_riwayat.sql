@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS pasar_riwayat (
+  id BIGSERIAL PRIMARY KEY,
+  simbol VARCHAR(20) NOT NULL,
+  tanggal DATE NOT NULL,
+  harga NUMERIC(20,4) NOT NULL,
+  mata_uang CHAR(3) NOT NULL,
+  CONSTRAINT uk_pasar_riwayat UNIQUE (simbol, tanggal)
+);
+
+INSERT INTO pasar_riwayat (simbol, tanggal, harga, mata_uang)
+SELECT DISTINCT ON (simbol, CAST(diperbarui_pada AS DATE)) simbol, CAST(diperbarui_pada AS DATE), harga, mata_uang FROM pasar
+ORDER BY simbol, CAST(diperbarui_pada AS DATE), diperbarui_pada DESC
+ON CONFLICT (simbol, tanggal) DO UPDATE SET harga = EXCLUDED.harga, mata_uang = EXCLUDED.mata_uang;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS portofolio_snapshot (
+  id BIGSERIAL PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL REFERENCES pengguna(id) ON DELETE CASCADE,
+  tanggal DATE NOT NULL,
+  total_nilai NUMERIC(20,4) NOT NULL,
+  total_modal NUMERIC(20,4) NOT NULL,
+  mata_uang CHAR(3) NOT NULL,
+  jumlah_aset INT NOT NULL DEFAULT 0,
+  dibuat_pada TIMESTAMP NOT NULL,
+  CONSTRAINT uk_snapshot_pengguna_tanggal UNIQUE (id_pengguna, tanggal)
+);
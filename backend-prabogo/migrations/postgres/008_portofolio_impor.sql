@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS portofolio_impor (
+  id BIGSERIAL PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL REFERENCES pengguna(id) ON DELETE CASCADE,
+  format VARCHAR(30) NOT NULL,
+  nama_berkas VARCHAR(255) NOT NULL DEFAULT '',
+  status VARCHAR(20) NOT NULL,
+  jumlah_baru INT NOT NULL DEFAULT 0,
+  jumlah_duplikat INT NOT NULL DEFAULT 0,
+  jumlah_gagal INT NOT NULL DEFAULT 0,
+  baris TEXT NOT NULL,
+  dibuat_pada TIMESTAMP NOT NULL,
+  diterapkan_pada TIMESTAMP NULL
+);
+CREATE INDEX IF NOT EXISTS idx_impor_pengguna ON portofolio_impor (id_pengguna);
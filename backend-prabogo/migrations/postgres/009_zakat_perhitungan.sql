@@ -0,0 +1,13 @@
+ALTER TABLE zakat_riwayat ADD COLUMN IF NOT EXISTS tanggal DATE NULL;
+ALTER TABLE zakat_riwayat ADD COLUMN IF NOT EXISTS wajib_zakat BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE zakat_riwayat ADD COLUMN IF NOT EXISTS harga_emas_per_gram NUMERIC(20,4) NOT NULL DEFAULT 0;
+ALTER TABLE zakat_riwayat ADD COLUMN IF NOT EXISTS rincian TEXT NULL;
+
+UPDATE zakat_riwayat SET tanggal = CAST(dibuat_pada AS DATE) WHERE tanggal IS NULL;
+
+-- Ringkasan lama mencatat riwayat setiap kali dibuka; simpan hanya yang terakhir per hari.
+DELETE FROM zakat_riwayat z1 USING zakat_riwayat z2
+WHERE z1.id_pengguna = z2.id_pengguna AND z1.tanggal = z2.tanggal AND z1.id < z2.id;
+
+ALTER TABLE zakat_riwayat ALTER COLUMN tanggal SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uk_zakat_pengguna_tanggal ON zakat_riwayat (id_pengguna, tanggal);
@@ -0,0 +1,5 @@
+ALTER TABLE zakat_riwayat ADD COLUMN IF NOT EXISTS jenis VARCHAR(20) NOT NULL DEFAULT 'maal';
+ALTER TABLE zakat_riwayat ADD COLUMN IF NOT EXISTS masukan TEXT NULL;
+
+DROP INDEX IF EXISTS uk_zakat_pengguna_tanggal;
+CREATE UNIQUE INDEX IF NOT EXISTS uk_zakat_pengguna_jenis_tanggal ON zakat_riwayat (id_pengguna, jenis, tanggal);
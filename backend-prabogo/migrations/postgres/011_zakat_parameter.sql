@@ -0,0 +1,22 @@
+ALTER TABLE harga_emas ADD COLUMN IF NOT EXISTS logam VARCHAR(10) NOT NULL DEFAULT 'emas';
+CREATE INDEX IF NOT EXISTS idx_harga_emas_logam_tanggal ON harga_emas (logam, tanggal);
+
+ALTER TABLE pengguna ADD COLUMN IF NOT EXISTS standar_nisab VARCHAR(10) NOT NULL DEFAULT '';
+
+ALTER TABLE zakat_riwayat ADD COLUMN IF NOT EXISTS parameter TEXT NULL;
+
+INSERT INTO konfigurasi (kunci, nilai, deskripsi)
+SELECT 'zakat_nisab_gram_emas', '85', 'Nisab zakat maal dalam gram emas'
+WHERE NOT EXISTS (SELECT 1 FROM konfigurasi WHERE kunci = 'zakat_nisab_gram_emas');
+INSERT INTO konfigurasi (kunci, nilai, deskripsi)
+SELECT 'zakat_nisab_gram_perak', '595', 'Nisab zakat maal dalam gram perak'
+WHERE NOT EXISTS (SELECT 1 FROM konfigurasi WHERE kunci = 'zakat_nisab_gram_perak');
+INSERT INTO konfigurasi (kunci, nilai, deskripsi)
+SELECT 'zakat_persen', '2.5', 'Kadar zakat maal dalam persen'
+WHERE NOT EXISTS (SELECT 1 FROM konfigurasi WHERE kunci = 'zakat_persen');
+INSERT INTO konfigurasi (kunci, nilai, deskripsi)
+SELECT 'zakat_standar_nisab', 'emas', 'Standar nisab bawaan: emas atau perak'
+WHERE NOT EXISTS (SELECT 1 FROM konfigurasi WHERE kunci = 'zakat_standar_nisab');
+INSERT INTO konfigurasi (kunci, nilai, deskripsi)
+SELECT 'zakat_pembulatan', '0', 'Kelipatan pembulatan ke atas nilai zakat; 0 mengikuti digit mata uang'
+WHERE NOT EXISTS (SELECT 1 FROM konfigurasi WHERE kunci = 'zakat_pembulatan');
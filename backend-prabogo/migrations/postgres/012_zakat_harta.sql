@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS zakat_harta (
+  id BIGSERIAL PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL REFERENCES pengguna(id) ON DELETE CASCADE,
+  kelompok VARCHAR(20) NOT NULL,
+  jenis VARCHAR(20) NOT NULL,
+  nama VARCHAR(150) NOT NULL,
+  nilai NUMERIC(20,4) NOT NULL,
+  mata_uang CHAR(3) NOT NULL DEFAULT 'IDR',
+  catatan VARCHAR(255) NOT NULL DEFAULT '',
+  dibuat_pada TIMESTAMP NOT NULL,
+  diperbarui_pada TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_zakat_harta_pengguna ON zakat_harta (id_pengguna);
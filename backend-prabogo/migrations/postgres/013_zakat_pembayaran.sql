@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS zakat_pembayaran (
+  id BIGSERIAL PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL REFERENCES pengguna(id) ON DELETE CASCADE,
+  jenis VARCHAR(20) NOT NULL DEFAULT 'maal',
+  tahun_haul INT NOT NULL,
+  tanggal DATE NOT NULL,
+  jumlah NUMERIC(20,4) NOT NULL,
+  mata_uang CHAR(3) NOT NULL DEFAULT 'IDR',
+  jenis_penerima VARCHAR(20) NOT NULL,
+  penerima VARCHAR(150) NOT NULL,
+  asnaf VARCHAR(20) NOT NULL DEFAULT '',
+  nomor_bukti VARCHAR(100) NOT NULL DEFAULT '',
+  bukti_url TEXT,
+  catatan VARCHAR(255) NOT NULL DEFAULT '',
+  dibuat_pada TIMESTAMP NOT NULL,
+  diperbarui_pada TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_zakat_pembayaran_pengguna_tanggal ON zakat_pembayaran (id_pengguna, tanggal);
@@ -0,0 +1,7 @@
+ALTER TABLE harga_emas ADD COLUMN IF NOT EXISTS sumber VARCHAR(50) NOT NULL DEFAULT 'manual';
+
+-- Satu harga per logam, tanggal dan mata uang; impor dan sinkronisasi menimpa harga hari yang sama.
+DELETE FROM harga_emas h1 USING harga_emas h2
+WHERE h1.logam = h2.logam AND h1.tanggal = h2.tanggal AND h1.mata_uang = h2.mata_uang AND h1.id < h2.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uk_harga_emas_logam_tanggal_mata_uang ON harga_emas (logam, tanggal, mata_uang);
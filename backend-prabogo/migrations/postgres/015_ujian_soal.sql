@@ -0,0 +1,39 @@
+ALTER TABLE ujian ADD COLUMN IF NOT EXISTS nilai_lulus INT NOT NULL DEFAULT 70;
+ALTER TABLE ujian ADD COLUMN IF NOT EXISTS soal_per_percobaan INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS ujian_soal (
+  id BIGSERIAL PRIMARY KEY,
+  id_ujian BIGINT NOT NULL REFERENCES ujian(id) ON DELETE CASCADE,
+  tipe VARCHAR(20) NOT NULL,
+  pertanyaan TEXT NOT NULL,
+  jawaban_isian VARCHAR(255) NOT NULL DEFAULT '',
+  pembahasan TEXT,
+  bobot INT NOT NULL DEFAULT 1,
+  urutan INT NOT NULL DEFAULT 0,
+  dibuat_pada TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_ujian_soal_ujian ON ujian_soal (id_ujian, urutan);
+
+CREATE TABLE IF NOT EXISTS ujian_opsi (
+  id BIGSERIAL PRIMARY KEY,
+  id_soal BIGINT NOT NULL REFERENCES ujian_soal(id) ON DELETE CASCADE,
+  teks VARCHAR(500) NOT NULL,
+  benar BOOLEAN NOT NULL DEFAULT FALSE,
+  urutan INT NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS idx_ujian_opsi_soal ON ujian_opsi (id_soal);
+
+CREATE TABLE IF NOT EXISTS ujian_percobaan (
+  id BIGSERIAL PRIMARY KEY,
+  id_ujian BIGINT NOT NULL REFERENCES ujian(id) ON DELETE CASCADE,
+  id_pengguna BIGINT NOT NULL REFERENCES pengguna(id) ON DELETE CASCADE,
+  soal TEXT NOT NULL,
+  jawaban TEXT,
+  status VARCHAR(20) NOT NULL DEFAULT 'berjalan',
+  skor NUMERIC(5,2) NOT NULL DEFAULT 0,
+  lulus BOOLEAN NOT NULL DEFAULT FALSE,
+  mulai_pada TIMESTAMP NOT NULL,
+  batas_waktu TIMESTAMP NULL,
+  selesai_pada TIMESTAMP NULL
+);
+CREATE INDEX IF NOT EXISTS idx_ujian_percobaan_pengguna ON ujian_percobaan (id_pengguna, id_ujian);
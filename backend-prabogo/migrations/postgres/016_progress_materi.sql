@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS progress_materi (
+  id BIGSERIAL PRIMARY KEY,
+  id_pengguna BIGINT NOT NULL REFERENCES pengguna(id) ON DELETE CASCADE,
+  id_materi BIGINT NOT NULL REFERENCES materi(id) ON DELETE CASCADE,
+  posisi_detik INT NOT NULL DEFAULT 0,
+  selesai BOOLEAN NOT NULL DEFAULT FALSE,
+  selesai_pada TIMESTAMP NULL,
+  diperbarui_pada TIMESTAMP NOT NULL,
+  CONSTRAINT uk_progress_materi UNIQUE (id_pengguna, id_materi)
+);
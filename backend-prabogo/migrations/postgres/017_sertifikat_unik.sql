@@ -0,0 +1,11 @@
+-- Satu sertifikat per peserta dan kelas; sertifikat tertua dipertahankan.
+DELETE FROM sertifikat s1 USING sertifikat s2
+WHERE s1.id_pengguna = s2.id_pengguna AND s1.id_kelas = s2.id_kelas AND s1.id > s2.id;
+
+-- Kode ganda dari input manual dibedakan dengan ID agar verifikasi tidak ambigu.
+UPDATE sertifikat s1 SET kode = s1.kode || '-' || s1.id
+FROM sertifikat s2
+WHERE s1.kode = s2.kode AND s1.id > s2.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uk_sertifikat_pengguna_kelas ON sertifikat (id_pengguna, id_kelas);
+CREATE UNIQUE INDEX IF NOT EXISTS uk_sertifikat_kode ON sertifikat (kode);
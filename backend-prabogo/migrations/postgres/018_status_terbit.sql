@@ -0,0 +1,18 @@
+-- Alur terbit konten edukasi: draf -> review -> terbit -> arsip. Konten hanya
+-- tampil di endpoint publik bila berstatus terbit dan terbit_pada (bila diisi)
+-- sudah lewat.
+UPDATE kelas SET status = 'terbit' WHERE status IN ('publik', 'published', 'aktif');
+UPDATE kelas SET status = 'draf' WHERE status NOT IN ('draf', 'review', 'terbit', 'arsip');
+ALTER TABLE kelas ADD COLUMN IF NOT EXISTS terbit_pada TIMESTAMP NULL;
+ALTER TABLE kelas ADD COLUMN IF NOT EXISTS token_pratinjau VARCHAR(64) NULL;
+ALTER TABLE kelas ADD COLUMN IF NOT EXISTS pratinjau_kadaluarsa TIMESTAMP NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uk_kelas_token_pratinjau ON kelas (token_pratinjau);
+
+-- Modul dan materi yang sudah ada tetap tampil; konten baru dimulai dari draf.
+ALTER TABLE modul ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'terbit';
+ALTER TABLE modul ADD COLUMN IF NOT EXISTS terbit_pada TIMESTAMP NULL;
+ALTER TABLE modul ALTER COLUMN status SET DEFAULT 'draf';
+
+ALTER TABLE materi ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'terbit';
+ALTER TABLE materi ADD COLUMN IF NOT EXISTS terbit_pada TIMESTAMP NULL;
+ALTER TABLE materi ALTER COLUMN status SET DEFAULT 'draf';
@@ -0,0 +1,26 @@
+-- Prasyarat antarkelas: id_prasyarat harus selesai sebelum id_kelas dimulai.
+CREATE TABLE IF NOT EXISTS kelas_prasyarat (
+  id BIGSERIAL PRIMARY KEY,
+  id_kelas BIGINT NOT NULL REFERENCES kelas(id) ON DELETE CASCADE,
+  id_prasyarat BIGINT NOT NULL REFERENCES kelas(id) ON DELETE CASCADE,
+  CONSTRAINT uk_kelas_prasyarat UNIQUE (id_kelas, id_prasyarat)
+);
+
+-- Jalur belajar kurasi berisi kelas berurutan; mengikuti alur terbit yang sama
+-- dengan kelas.
+CREATE TABLE IF NOT EXISTS jalur_belajar (
+  id BIGSERIAL PRIMARY KEY,
+  judul VARCHAR(200) NOT NULL,
+  deskripsi TEXT NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'draf',
+  terbit_pada TIMESTAMP NULL,
+  dibuat_pada TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS jalur_kelas (
+  id BIGSERIAL PRIMARY KEY,
+  id_jalur BIGINT NOT NULL REFERENCES jalur_belajar(id) ON DELETE CASCADE,
+  id_kelas BIGINT NOT NULL REFERENCES kelas(id) ON DELETE CASCADE,
+  urutan INT NOT NULL,
+  CONSTRAINT uk_jalur_kelas UNIQUE (id_jalur, id_kelas)
+);
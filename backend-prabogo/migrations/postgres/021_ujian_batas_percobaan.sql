@@ -0,0 +1,2 @@
+-- Batas jumlah percobaan (0 berarti tanpa batas) dan jeda menit antarpercobaan.
+ALTER TABLE ujian ADD COLUMN IF NOT EXISTS maks_percobaan INT NOT NULL DEFAULT 0, ADD COLUMN IF NOT EXISTS jeda_menit INT NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Harta bersih zakat harian (portofolio ditambah harta di luar portofolio
+-- dikurangi utang) agar haul ditelusuri dari dasar yang sama dengan zakat.
+ALTER TABLE portofolio_snapshot ADD COLUMN IF NOT EXISTS nilai_zakat NUMERIC(20,4);
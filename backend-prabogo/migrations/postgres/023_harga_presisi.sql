@@ -0,0 +1,6 @@
+-- Harga per unit memakai 8 digit desimal agar harga token yang sangat kecil
+-- tidak terbulatkan menjadi nol; nilai uang tetap NUMERIC(20,4).
+ALTER TABLE pasar ALTER COLUMN harga TYPE NUMERIC(28,8);
+ALTER TABLE pasar_riwayat ALTER COLUMN harga TYPE NUMERIC(28,8);
+ALTER TABLE portofolio_transaksi ALTER COLUMN harga TYPE NUMERIC(28,8);
+ALTER TABLE portofolio_nilai_manual ALTER COLUMN harga TYPE NUMERIC(28,8);
@@ -0,0 +1,5 @@
+-- Nilai rupiah pembayaran dikunci dengan kurs saat dicatat agar laporan pajak
+-- tahunan tidak berubah mengikuti kurs hari ini. Pembayaran non-rupiah lama
+-- dibiarkan kosong dan tetap dihitung dengan kurs terkini.
+ALTER TABLE zakat_pembayaran ADD COLUMN IF NOT EXISTS jumlah_idr NUMERIC(20,4);
+UPDATE zakat_pembayaran SET jumlah_idr = jumlah WHERE mata_uang = 'IDR' AND jumlah_idr IS NULL;
@@ -0,0 +1,4 @@
+-- Kelas aset screener (kripto, saham, ...) agar alternatif halal pada laporan
+-- kepatuhan berasal dari kelas aset yang sama dengan kepemilikan. Seluruh
+-- screener yang sudah ada adalah token kripto.
+ALTER TABLE screener ADD COLUMN IF NOT EXISTS kategori_aset VARCHAR(30) NOT NULL DEFAULT 'kripto';
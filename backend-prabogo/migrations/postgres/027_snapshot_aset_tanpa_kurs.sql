@@ -0,0 +1,4 @@
+-- Jumlah aset yang tidak ikut dinilai pada snapshot karena kurs ke mata uang
+-- snapshot belum tersedia. Snapshot tetap disimpan agar riwayat dan haul tidak
+-- berhenti hanya karena satu aset berdenominasi asing.
+ALTER TABLE portofolio_snapshot ADD COLUMN IF NOT EXISTS aset_tanpa_kurs INTEGER NOT NULL DEFAULT 0;
@@ -2,14 +2,19 @@ package config
 
 import (
 	"os"
+	"strings"
 	"time"
 )
 
 // Config represents the application configuration
 type Config struct {
-	Server ServerConfig
-	DB     DBConfig
-	JWT    JWTConfig
+	Server     ServerConfig
+	DB         DBConfig
+	JWT        JWTConfig
+	Kurs       KursConfig
+	HargaLogam HargaLogamConfig
+	HargaPasar HargaPasarConfig
+	Portofolio PortofolioConfig
 }
 
 // ServerConfig holds server-related configurations
@@ -17,6 +22,10 @@ type ServerConfig struct {
 	Port       string
 	Host       string
 	TimeFormat string
+	// Base URL printed in certificate verification links (URL_PUBLIK)
+	URLPublik string
+	// Extra origins allowed to open the WebSocket price stream (STREAM_ORIGIN)
+	OriginStream []string
 }
 
 // DBConfig holds database-related configurations
@@ -45,13 +54,44 @@ type JWTConfig struct {
 	Secret string
 }
 
+// KursConfig holds exchange-rate ingestion configurations
+type KursConfig struct {
+	URL      string
+	Dasar    string
+	MataUang []string
+	Interval time.Duration
+}
+
+// HargaLogamConfig holds gold/silver price ingestion configurations
+type HargaLogamConfig struct {
+	URL       string
+	Token     string
+	MataUang  string
+	Interval  time.Duration
+	BatasUsia time.Duration
+}
+
+// HargaPasarConfig holds market quote ingestion configurations
+type HargaPasarConfig struct {
+	URL      string
+	Interval time.Duration
+}
+
+// PortofolioConfig holds portfolio valuation configurations
+type PortofolioConfig struct {
+	BatasUsiaHarga   time.Duration
+	IntervalSnapshot time.Duration
+}
+
 // NewConfig creates a new configuration instance
 func NewConfig() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Port:       getEnvOrDefault("SERVER_PORT", "8080"),
-			Host:       getEnvOrDefault("SERVER_HOST", "localhost"),
-			TimeFormat: time.Now().Format(time.RFC3339),
+			Port:         getEnvOrDefault("SERVER_PORT", "8080"),
+			Host:         getEnvOrDefault("SERVER_HOST", "localhost"),
+			TimeFormat:   time.Now().Format(time.RFC3339),
+			URLPublik:    getEnvOrDefault("URL_PUBLIK", ""),
+			OriginStream: getListOrDefault("STREAM_ORIGIN", ""),
 		},
 		DB: DBConfig{
 			SawitDBPath: getEnvOrDefault("SAWIT_DB_PATH", "./data.sawit"),
@@ -75,6 +115,27 @@ func NewConfig() *Config {
 		JWT: JWTConfig{
 			Secret: getEnvOrDefault("JWT_SECRET", "default_secret_key_for_development"),
 		},
+		Kurs: KursConfig{
+			URL:      getEnvOrDefault("KURS_URL", ""),
+			Dasar:    getEnvOrDefault("KURS_DASAR", "USD"),
+			MataUang: strings.Split(getEnvOrDefault("KURS_MATA_UANG", "IDR,SGD,MYR,SAR,EUR"), ","),
+			Interval: getDurationOrDefault("KURS_INTERVAL", time.Hour),
+		},
+		HargaLogam: HargaLogamConfig{
+			URL:       getEnvOrDefault("HARGA_LOGAM_URL", ""),
+			Token:     getEnvOrDefault("HARGA_LOGAM_TOKEN", ""),
+			MataUang:  getEnvOrDefault("HARGA_LOGAM_MATA_UANG", "IDR"),
+			Interval:  getDurationOrDefault("HARGA_LOGAM_INTERVAL", 6*time.Hour),
+			BatasUsia: getDurationOrDefault("HARGA_LOGAM_BATAS_USIA", 72*time.Hour),
+		},
+		HargaPasar: HargaPasarConfig{
+			URL:      getEnvOrDefault("HARGA_PASAR_URL", ""),
+			Interval: getDurationOrDefault("HARGA_PASAR_INTERVAL", time.Minute),
+		},
+		Portofolio: PortofolioConfig{
+			BatasUsiaHarga:   getDurationOrDefault("PORTOFOLIO_BATAS_USIA_HARGA", 24*time.Hour),
+			IntervalSnapshot: getDurationOrDefault("PORTOFOLIO_INTERVAL_SNAPSHOT", time.Hour),
+		},
 	}
 }
 
@@ -91,6 +152,27 @@ func (db DBConfig) PostgresDSN() string {
 		" sslmode=" + db.PostgresSSLMode
 }
 
+// getDurationOrDefault parses a duration environment variable such as "30m"
+func getDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			return d
+		}
+	}
+	return defaultValue
+}
+
+// getListOrDefault parses a comma-separated environment variable, skipping empty items
+func getListOrDefault(key, defaultValue string) []string {
+	var items []string
+	for _, item := range strings.Split(getEnvOrDefault(key, defaultValue), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // getEnvOrDefault retrieves environment variable or returns a default value
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
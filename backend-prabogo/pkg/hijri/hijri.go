@@ -0,0 +1,116 @@
+// Package hijri mengonversi tanggal Masehi dan Hijriah memakai kalender
+// Hijriah tabular (aritmetis) dengan siklus kabisat 30 tahun. Hasilnya bisa
+// berbeda satu hari dari penetapan rukyat, cukup untuk perhitungan haul.
+package hijri
+
+import (
+	"fmt"
+	"time"
+)
+
+// epoch adalah 1 Muharram 1 H (16 Juli 622 M Julian) dalam hari sejak 1 Januari 1970.
+const epoch = -492148
+
+var NamaBulan = [12]string{
+	"Muharram", "Safar", "Rabiul Awal", "Rabiul Akhir", "Jumadil Awal", "Jumadil Akhir",
+	"Rajab", "Syaban", "Ramadan", "Syawal", "Zulkaidah", "Zulhijah",
+}
+
+type Tanggal struct {
+	Tahun int `json:"tahun"`
+	Bulan int `json:"bulan"`
+	Hari  int `json:"hari"`
+}
+
+// Kabisat melaporkan apakah tahun memiliki 355 hari (Zulhijah 30 hari).
+func Kabisat(tahun int) bool {
+	return modPositif(14+11*tahun, 30) < 11
+}
+
+// PanjangBulan mengembalikan 30 untuk bulan ganjil dan 29 untuk bulan genap,
+// kecuali Zulhijah pada tahun kabisat.
+func PanjangBulan(tahun, bulan int) int {
+	if bulan%2 == 1 || (bulan == 12 && Kabisat(tahun)) {
+		return 30
+	}
+	return 29
+}
+
+// DariMasehi mengonversi tanggal kalender t (jam diabaikan) ke Hijriah.
+func DariMasehi(t time.Time) Tanggal {
+	n := hariSejakEpochUnix(t)
+	tahun := bagiBawah(30*(n-epoch)+10646, 10631)
+	bulan := bagiAtas(2*(n-29-hariDariHijri(tahun, 1, 1)), 59) + 1
+	if bulan > 12 {
+		bulan = 12
+	}
+	if bulan < 1 {
+		bulan = 1
+	}
+	return Tanggal{Tahun: tahun, Bulan: bulan, Hari: n - hariDariHijri(tahun, bulan, 1) + 1}
+}
+
+// Masehi mengembalikan tengah malam tanggal tersebut di lokasi loc.
+func (d Tanggal) Masehi(loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.Local
+	}
+	n := hariDariHijri(d.Tahun, d.Bulan, d.Hari)
+	u := time.Unix(int64(n)*86400, 0).UTC()
+	return time.Date(u.Year(), u.Month(), u.Day(), 0, 0, 0, 0, loc)
+}
+
+// TambahTahun memajukan n tahun Hijriah. Tanggal 30 pada bulan yang hanya
+// 29 hari di tahun tujuan dipotong ke hari terakhir bulan itu.
+func (d Tanggal) TambahTahun(n int) Tanggal {
+	d.Tahun += n
+	if maks := PanjangBulan(d.Tahun, d.Bulan); d.Hari > maks {
+		d.Hari = maks
+	}
+	return d
+}
+
+func (d Tanggal) String() string {
+	if d.Bulan < 1 || d.Bulan > 12 {
+		return fmt.Sprintf("%d/%d/%d H", d.Hari, d.Bulan, d.Tahun)
+	}
+	return fmt.Sprintf("%d %s %d H", d.Hari, NamaBulan[d.Bulan-1], d.Tahun)
+}
+
+// SatuTahunSetelah mengembalikan tanggal Masehi tepat satu tahun Hijriah setelah t.
+func SatuTahunSetelah(t time.Time) time.Time {
+	return DariMasehi(t).TambahTahun(1).Masehi(t.Location())
+}
+
+func hariDariHijri(tahun, bulan, hari int) int {
+	return hari + (59*(bulan-1)+1)/2 + (tahun-1)*354 + bagiBawah(3+11*tahun, 30) + epoch - 1
+}
+
+func hariSejakEpochUnix(t time.Time) int {
+	u := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return int(bagiBawah64(u.Unix(), 86400))
+}
+
+func bagiBawah(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+func bagiBawah64(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+func bagiAtas(a, b int) int {
+	return -bagiBawah(-a, b)
+}
+
+func modPositif(a, b int) int {
+	return a - bagiBawah(a, b)*b
+}
@@ -0,0 +1,83 @@
+package hijri
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDariMasehiTanggalDikenal(t *testing.T) {
+	tests := []struct {
+		masehi time.Time
+		hijri  Tanggal
+	}{
+		{time.Date(622, 7, 19, 0, 0, 0, 0, time.UTC), Tanggal{Tahun: 1, Bulan: 1, Hari: 1}},
+		{time.Date(2023, 7, 19, 0, 0, 0, 0, time.UTC), Tanggal{Tahun: 1445, Bulan: 1, Hari: 1}},
+		{time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), Tanggal{Tahun: 1445, Bulan: 9, Hari: 1}},
+	}
+	for _, tt := range tests {
+		if got := DariMasehi(tt.masehi); got != tt.hijri {
+			t.Errorf("DariMasehi(%s) = %s, ingin %s", tt.masehi.Format("2006-01-02"), got, tt.hijri)
+		}
+		if got := tt.hijri.Masehi(time.UTC); !got.Equal(tt.masehi) {
+			t.Errorf("%s.Masehi() = %s, ingin %s", tt.hijri, got.Format("2006-01-02"), tt.masehi.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestKonversiBolakBalik(t *testing.T) {
+	awal := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	akhir := time.Date(2080, 1, 1, 0, 0, 0, 0, time.UTC)
+	var sebelumnya Tanggal
+	for d := awal; d.Before(akhir); d = d.AddDate(0, 0, 1) {
+		h := DariMasehi(d)
+		if h.Bulan < 1 || h.Bulan > 12 || h.Hari < 1 || h.Hari > PanjangBulan(h.Tahun, h.Bulan) {
+			t.Fatalf("DariMasehi(%s) = %+v di luar rentang", d.Format("2006-01-02"), h)
+		}
+		if got := h.Masehi(time.UTC); !got.Equal(d) {
+			t.Fatalf("%s -> %s -> %s", d.Format("2006-01-02"), h, got.Format("2006-01-02"))
+		}
+		if !d.Equal(awal) && !berurutan(sebelumnya, h) {
+			t.Fatalf("%s tidak tepat satu hari setelah %s", h, sebelumnya)
+		}
+		sebelumnya = h
+	}
+}
+
+func berurutan(a, b Tanggal) bool {
+	switch {
+	case b.Tahun == a.Tahun && b.Bulan == a.Bulan:
+		return b.Hari == a.Hari+1
+	case b.Tahun == a.Tahun && b.Bulan == a.Bulan+1:
+		return b.Hari == 1 && a.Hari == PanjangBulan(a.Tahun, a.Bulan)
+	case b.Tahun == a.Tahun+1:
+		return a.Bulan == 12 && b.Bulan == 1 && b.Hari == 1 && a.Hari == PanjangBulan(a.Tahun, 12)
+	}
+	return false
+}
+
+func TestSatuTahunSetelah(t *testing.T) {
+	tests := []struct {
+		dari, ingin time.Time
+	}{
+		{time.Date(2023, 7, 19, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := SatuTahunSetelah(tt.dari); !got.Equal(tt.ingin) {
+			t.Errorf("SatuTahunSetelah(%s) = %s, ingin %s", tt.dari.Format("2006-01-02"), got.Format("2006-01-02"), tt.ingin.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestTambahTahunMemotongHari30(t *testing.T) {
+	for tahun := 1440; tahun < 1470; tahun++ {
+		if Kabisat(tahun) && !Kabisat(tahun+1) {
+			got := Tanggal{Tahun: tahun, Bulan: 12, Hari: 30}.TambahTahun(1)
+			if ingin := (Tanggal{Tahun: tahun + 1, Bulan: 12, Hari: 29}); got != ingin {
+				t.Errorf("TambahTahun = %s, ingin %s", got, ingin)
+			}
+			return
+		}
+	}
+	t.Fatal("tidak menemukan tahun kabisat yang diikuti tahun biasa")
+}
@@ -0,0 +1,22 @@
+package jadwal
+
+import (
+	"context"
+	"time"
+)
+
+// Setiap menjalankan fn sekali saat dipanggil lalu berulang setiap interval
+// sampai ctx dibatalkan. Dijalankan di goroutine tersendiri oleh pemanggil.
+func Setiap(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) {
+	fn(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fn(ctx)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package pdf
+
+import "strings"
+
+// Lebar glyf ASCII 32-126 dalam seperseribu em, dari metrik AFM standar
+// Helvetica dan Helvetica-Bold.
+var lebarGlyf = [...][95]int{
+	Reguler: {
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	},
+	Tebal: {
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	},
+}
+
+// lebarBawaan dipakai untuk karakter di luar ASCII yang lebarnya tidak ditabelkan.
+const lebarBawaan = 556
+
+// LebarTeks mengembalikan lebar teks dalam titik.
+func LebarTeks(font Font, ukuran float64, s string) float64 {
+	total := 0
+	for _, r := range s {
+		if r >= 32 && r < 127 {
+			total += lebarGlyf[font][r-32]
+		} else {
+			total += lebarBawaan
+		}
+	}
+	return float64(total) * ukuran / 1000
+}
+
+// PecahBaris memecah teks per kata agar setiap baris tidak melebihi lebar.
+// Kata yang lebih panjang dari lebar dibiarkan utuh di barisnya sendiri.
+func PecahBaris(font Font, ukuran float64, s string, lebar float64) []string {
+	var baris []string
+	var sekarang string
+	for _, kata := range strings.Fields(s) {
+		calon := kata
+		if sekarang != "" {
+			calon = sekarang + " " + kata
+		}
+		if sekarang != "" && LebarTeks(font, ukuran, calon) > lebar {
+			baris = append(baris, sekarang)
+			calon = kata
+		}
+		sekarang = calon
+	}
+	if sekarang != "" {
+		baris = append(baris, sekarang)
+	}
+	return baris
+}
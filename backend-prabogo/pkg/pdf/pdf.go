@@ -0,0 +1,183 @@
+// Package pdf menulis dokumen PDF sederhana tanpa dependensi luar: teks
+// dengan font standar Helvetica, garis, dan kotak berisi. Koordinat memakai
+// titik (1/72 inci) dengan titik asal di pojok kiri atas halaman.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Ukuran kertas A4 dalam titik.
+const (
+	LebarA4  = 595.28
+	TinggiA4 = 841.89
+)
+
+type Font int
+
+const (
+	Reguler Font = iota
+	Tebal
+)
+
+var namaFont = [...]string{Reguler: "Helvetica", Tebal: "Helvetica-Bold"}
+
+type Dokumen struct {
+	judul      string
+	pembuat    string
+	lebar      float64
+	tinggi     float64
+	halaman    []*Halaman
+	dibuatPada time.Time
+}
+
+// Baru membuat dokumen A4 tegak tanpa halaman.
+func Baru(judul, pembuat string) *Dokumen {
+	return &Dokumen{judul: judul, pembuat: pembuat, lebar: LebarA4, tinggi: TinggiA4, dibuatPada: time.Now()}
+}
+
+// BaruLanskap membuat dokumen A4 mendatar tanpa halaman.
+func BaruLanskap(judul, pembuat string) *Dokumen {
+	d := Baru(judul, pembuat)
+	d.lebar, d.tinggi = TinggiA4, LebarA4
+	return d
+}
+
+func (d *Dokumen) Lebar() float64  { return d.lebar }
+func (d *Dokumen) Tinggi() float64 { return d.tinggi }
+
+// TambahHalaman menambah halaman kosong di akhir dokumen.
+func (d *Dokumen) TambahHalaman() *Halaman {
+	h := &Halaman{tinggi: d.tinggi}
+	d.halaman = append(d.halaman, h)
+	return h
+}
+
+type Halaman struct {
+	tinggi float64
+	isi    bytes.Buffer
+}
+
+// Teks menulis satu baris teks dengan garis dasar di y.
+func (h *Halaman) Teks(x, y float64, font Font, ukuran float64, s string) {
+	fmt.Fprintf(&h.isi, "BT /F%d %s Tf %s %s Td (%s) Tj ET\n", font+1, angka(ukuran), angka(x), angka(h.tinggi-y), escape(s))
+}
+
+// TeksKanan menulis teks yang rata kanan terhadap x.
+func (h *Halaman) TeksKanan(x, y float64, font Font, ukuran float64, s string) {
+	h.Teks(x-LebarTeks(font, ukuran, s), y, font, ukuran, s)
+}
+
+// TeksTengah menulis teks yang berpusat di x.
+func (h *Halaman) TeksTengah(x, y float64, font Font, ukuran float64, s string) {
+	h.Teks(x-LebarTeks(font, ukuran, s)/2, y, font, ukuran, s)
+}
+
+// Garis menggambar garis lurus dengan ketebalan dalam titik.
+func (h *Halaman) Garis(x1, y1, x2, y2, tebal float64) {
+	fmt.Fprintf(&h.isi, "%s w %s %s m %s %s l S\n", angka(tebal), angka(x1), angka(h.tinggi-y1), angka(x2), angka(h.tinggi-y2))
+}
+
+// Kotak menggambar persegi dengan pojok kiri atas (x, y). Isi true mengisi
+// kotak dengan warna isi; false hanya menggambar tepinya.
+func (h *Halaman) Kotak(x, y, lebar, tinggi float64, isi bool) {
+	op := "S"
+	if isi {
+		op = "f"
+	}
+	fmt.Fprintf(&h.isi, "%s %s %s %s re %s\n", angka(x), angka(h.tinggi-y-tinggi), angka(lebar), angka(tinggi), op)
+}
+
+// Warna mengatur warna isi dan garis dalam RGB 0-1 untuk gambar berikutnya.
+func (h *Halaman) Warna(r, g, b float64) {
+	fmt.Fprintf(&h.isi, "%s %s %s rg %s %s %s RG\n", angka(r), angka(g), angka(b), angka(r), angka(g), angka(b))
+}
+
+// Bytes menyusun dokumen menjadi berkas PDF.
+func (d *Dokumen) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := d.Tulis(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Tulis menulis dokumen ke w. Dokumen tanpa halaman tetap diberi satu
+// halaman kosong agar berkas valid.
+func (d *Dokumen) Tulis(w io.Writer) error {
+	if len(d.halaman) == 0 {
+		d.TambahHalaman()
+	}
+	var buf bytes.Buffer
+	var offset []int
+	objek := func(isi string) {
+		offset = append(offset, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offset), isi)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	// Urutan objek: 1 katalog, 2 pohon halaman, 3-4 font, 5 info, lalu
+	// pasangan halaman dan isi untuk setiap halaman.
+	const objekPertamaHalaman = 6
+	anak := make([]string, len(d.halaman))
+	for i := range d.halaman {
+		anak[i] = fmt.Sprintf("%d 0 R", objekPertamaHalaman+i*2)
+	}
+	objek("<< /Type /Catalog /Pages 2 0 R >>")
+	objek(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d /MediaBox [0 0 %s %s] >>", strings.Join(anak, " "), len(d.halaman), angka(d.lebar), angka(d.tinggi)))
+	for _, nama := range namaFont {
+		objek(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", nama))
+	}
+	objek(fmt.Sprintf("<< /Title (%s) /Producer (%s) /CreationDate (D:%s) >>", escape(d.judul), escape(d.pembuat), d.dibuatPada.UTC().Format("20060102150405Z")))
+	for i, h := range d.halaman {
+		objek(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", objekPertamaHalaman+i*2+1))
+		objek(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", h.isi.Len(), h.isi.String()))
+	}
+
+	awalXref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offset)+1)
+	for _, o := range offset {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", o)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offset)+1, awalXref)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// angka menulis bilangan dengan paling banyak dua desimal tanpa nol berlebih.
+func angka(f float64) string {
+	s := strings.TrimRight(fmt.Sprintf("%.2f", f), "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// winAnsi memetakan tanda baca tipografis ke kode WinAnsi 0x80-0x9F.
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// escape mengubah teks UTF-8 ke WinAnsi dan meloloskan karakter khusus string
+// PDF. Karakter yang tidak ada di WinAnsi diganti tanda tanya.
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 32 && r < 127:
+			b.WriteRune(r)
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&b, "\\%03o", r)
+		case winAnsi[r] != 0:
+			fmt.Fprintf(&b, "\\%03o", winAnsi[r])
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,120 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+var (
+	polaStartxref = regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`)
+	polaSubbagian = regexp.MustCompile(`^xref\n0 (\d+)\n`)
+	polaEntri     = regexp.MustCompile(`^(\d{10}) (\d{5}) ([nf]) \n$`)
+	polaTrailer   = regexp.MustCompile(`^trailer\n<< /Size (\d+) /Root (\d+) 0 R /Info (\d+) 0 R >>\n`)
+	polaStream    = regexp.MustCompile(`/Length (\d+) >>\nstream\n`)
+)
+
+// TestXrefTerbaca mengurai berkas seperti pembaca PDF: startxref menunjuk ke
+// tabel xref, setiap entri tepat 20 byte dan menunjuk ke objek yang benar,
+// serta panjang setiap stream sesuai isinya.
+func TestXrefTerbaca(t *testing.T) {
+	dok := Baru("Laporan (uji) – Zakat", "Averroes")
+	h := dok.TambahHalaman()
+	h.Teks(40, 40, Tebal, 14, "Ringkasan zakat “maal”")
+	h.Kotak(40, 60, 100, 20, true)
+	dok.TambahHalaman().TeksTengah(dok.Lebar()/2, 100, Reguler, 10, "Halaman dua \\ akhir")
+
+	isi, err := dok.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(isi, []byte("%PDF-1.4\n")) {
+		t.Fatalf("header = %q", isi[:min(len(isi), 9)])
+	}
+
+	m := polaStartxref.FindSubmatch(isi)
+	if m == nil {
+		t.Fatal("startxref tidak ditemukan di akhir berkas")
+	}
+	awalXref, _ := strconv.Atoi(string(m[1]))
+	if awalXref >= len(isi) {
+		t.Fatalf("startxref %d di luar berkas (%d byte)", awalXref, len(isi))
+	}
+	xref := isi[awalXref:]
+	m = polaSubbagian.FindSubmatch(xref)
+	if m == nil {
+		t.Fatalf("startxref tidak menunjuk ke tabel xref: %q", xref[:min(len(xref), 20)])
+	}
+	jumlah, _ := strconv.Atoi(string(m[1]))
+	xref = xref[len(m[0]):]
+	if len(xref) < jumlah*20 {
+		t.Fatalf("tabel xref terpotong")
+	}
+
+	for i := 0; i < jumlah; i++ {
+		entri := xref[i*20 : (i+1)*20]
+		e := polaEntri.FindSubmatch(entri)
+		if e == nil {
+			t.Fatalf("entri xref %d tidak 20 byte sesuai format: %q", i, entri)
+		}
+		if i == 0 {
+			if string(e[2]) != "65535" || string(e[3]) != "f" {
+				t.Errorf("entri 0 = %q, ingin objek bebas 65535", entri)
+			}
+			continue
+		}
+		offset, _ := strconv.Atoi(string(e[1]))
+		kepala := fmt.Sprintf("%d 0 obj\n", i)
+		if offset >= len(isi) || !bytes.HasPrefix(isi[offset:], []byte(kepala)) {
+			t.Errorf("entri %d menunjuk ke offset %d yang bukan %q", i, offset, kepala)
+		}
+	}
+
+	tr := polaTrailer.FindSubmatch(xref[jumlah*20:])
+	if tr == nil {
+		t.Fatalf("trailer tidak valid: %q", xref[jumlah*20:])
+	}
+	if ukuran, _ := strconv.Atoi(string(tr[1])); ukuran != jumlah {
+		t.Errorf("trailer /Size %d, xref berisi %d entri", ukuran, jumlah)
+	}
+	// Katalog dan info harus menunjuk objek yang tercantum di xref.
+	for _, ref := range [][]byte{tr[2], tr[3]} {
+		if n, _ := strconv.Atoi(string(ref)); n <= 0 || n >= jumlah {
+			t.Errorf("trailer merujuk objek %d di luar xref", n)
+		}
+	}
+	if !bytes.Contains(isi, []byte("1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>")) {
+		t.Error("objek 1 bukan katalog")
+	}
+	if !bytes.Contains(isi, []byte("/Count 2 ")) {
+		t.Error("pohon halaman tidak berisi dua halaman")
+	}
+
+	streams := polaStream.FindAllSubmatchIndex(isi, -1)
+	if len(streams) != 2 {
+		t.Fatalf("stream = %d, ingin 2", len(streams))
+	}
+	for _, s := range streams {
+		panjang, _ := strconv.Atoi(string(isi[s[2]:s[3]]))
+		akhir := s[1] + panjang
+		if !bytes.HasPrefix(isi[akhir:], []byte("endstream\nendobj\n")) {
+			t.Errorf("stream di %d: /Length %d tidak berakhir di endstream", s[0], panjang)
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := map[string]string{
+		"a (b) c\\":       `a \(b\) c\\`,
+		"Rp 1.000 – “ok”": `Rp 1.000 \226 \223ok\224`,
+		"café":            `caf\351`,
+		"漢":               "?",
+	}
+	for masukan, ingin := range tests {
+		if got := escape(masukan); got != ingin {
+			t.Errorf("escape(%q) = %q, ingin %q", masukan, got, ingin)
+		}
+	}
+}
@@ -0,0 +1,459 @@
+// Package qrcode membuat kode QR (ISO/IEC 18004) mode byte untuk versi 1
+// sampai 10 tanpa dependensi luar. Hasilnya berupa matriks modul yang dapat
+// digambar oleh pemanggil, misalnya sebagai kotak-kotak di halaman PDF.
+package qrcode
+
+import (
+	"errors"
+	"math"
+)
+
+// TingkatKoreksi menentukan porsi kode yang dapat dipulihkan bila rusak:
+// L sekitar 7%, M 15%, Q 25% dan H 30%.
+type TingkatKoreksi int
+
+const (
+	KoreksiL TingkatKoreksi = iota
+	KoreksiM
+	KoreksiQ
+	KoreksiH
+)
+
+// bitFormat adalah dua bit tingkat koreksi pada informasi format.
+var bitFormat = [...]int{KoreksiL: 1, KoreksiM: 0, KoreksiQ: 3, KoreksiH: 2}
+
+const versiMaksimal = 10
+
+// ErrTerlaluPanjang dikembalikan bila data tidak muat di versi terbesar.
+var ErrTerlaluPanjang = errors.New("qrcode: data terlalu panjang")
+
+// blok menjelaskan pembagian codeword satu versi dan tingkat koreksi:
+// jumlah codeword koreksi per blok, lalu dua grup (jumlah blok, codeword data).
+type blok struct {
+	koreksi      int
+	blok1, data1 int
+	blok2, data2 int
+}
+
+var tabelBlok = [versiMaksimal + 1][4]blok{
+	1:  {{7, 1, 19, 0, 0}, {10, 1, 16, 0, 0}, {13, 1, 13, 0, 0}, {17, 1, 9, 0, 0}},
+	2:  {{10, 1, 34, 0, 0}, {16, 1, 28, 0, 0}, {22, 1, 22, 0, 0}, {28, 1, 16, 0, 0}},
+	3:  {{15, 1, 55, 0, 0}, {26, 1, 44, 0, 0}, {18, 2, 17, 0, 0}, {22, 2, 13, 0, 0}},
+	4:  {{20, 1, 80, 0, 0}, {18, 2, 32, 0, 0}, {26, 2, 24, 0, 0}, {16, 4, 9, 0, 0}},
+	5:  {{26, 1, 108, 0, 0}, {24, 2, 43, 0, 0}, {18, 2, 15, 2, 16}, {22, 2, 11, 2, 12}},
+	6:  {{18, 2, 68, 0, 0}, {16, 4, 27, 0, 0}, {24, 4, 19, 0, 0}, {28, 4, 15, 0, 0}},
+	7:  {{20, 2, 78, 0, 0}, {18, 4, 31, 0, 0}, {18, 2, 14, 4, 15}, {26, 4, 13, 1, 14}},
+	8:  {{24, 2, 97, 0, 0}, {22, 2, 38, 2, 39}, {22, 4, 18, 2, 19}, {26, 4, 14, 2, 15}},
+	9:  {{30, 2, 116, 0, 0}, {22, 3, 36, 2, 37}, {20, 4, 16, 4, 17}, {24, 4, 12, 4, 13}},
+	10: {{18, 2, 68, 2, 69}, {26, 4, 43, 1, 44}, {24, 6, 19, 2, 20}, {28, 6, 15, 2, 16}},
+}
+
+// posisiAlignment adalah koordinat pusat pola alignment per versi.
+var posisiAlignment = [versiMaksimal + 1][]int{
+	2: {6, 18}, 3: {6, 22}, 4: {6, 26}, 5: {6, 30}, 6: {6, 34},
+	7: {6, 22, 38}, 8: {6, 24, 42}, 9: {6, 26, 46}, 10: {6, 28, 50},
+}
+
+func (b blok) kapasitasData() int {
+	return b.blok1*b.data1 + b.blok2*b.data2
+}
+
+// Kode adalah matriks modul QR; true berarti modul gelap.
+type Kode struct {
+	Versi  int
+	ukuran int
+	modul  [][]bool
+	fungsi [][]bool
+}
+
+// Ukuran mengembalikan jumlah modul per sisi tanpa zona tenang.
+func (k *Kode) Ukuran() int { return k.ukuran }
+
+// Gelap melaporkan apakah modul di kolom x dan baris y berwarna gelap.
+func (k *Kode) Gelap(x, y int) bool {
+	if x < 0 || y < 0 || x >= k.ukuran || y >= k.ukuran {
+		return false
+	}
+	return k.modul[y][x]
+}
+
+// Buat menyandikan data sebagai mode byte dengan versi terkecil yang muat
+// dan mask dengan penalti terendah.
+func Buat(data string, tingkat TingkatKoreksi) (*Kode, error) {
+	if tingkat < KoreksiL || tingkat > KoreksiH {
+		return nil, errors.New("qrcode: tingkat koreksi tidak dikenal")
+	}
+	versi := 0
+	for v := 1; v <= versiMaksimal; v++ {
+		if 4+bitPanjang(v)+len(data)*8 <= tabelBlok[v][tingkat].kapasitasData()*8 {
+			versi = v
+			break
+		}
+	}
+	if versi == 0 {
+		return nil, ErrTerlaluPanjang
+	}
+
+	k := baru(versi)
+	k.gambarPolaFungsi()
+	k.tempatkan(k.codeword([]byte(data), tingkat))
+
+	mask, terbaik := 0, math.MaxInt
+	for m := 0; m < 8; m++ {
+		k.terapkanMask(m)
+		k.gambarFormat(tingkat, m)
+		if p := k.penalti(); p < terbaik {
+			mask, terbaik = m, p
+		}
+		k.terapkanMask(m)
+	}
+	k.terapkanMask(mask)
+	k.gambarFormat(tingkat, mask)
+	return k, nil
+}
+
+func bitPanjang(versi int) int {
+	if versi <= 9 {
+		return 8
+	}
+	return 16
+}
+
+func baru(versi int) *Kode {
+	ukuran := versi*4 + 17
+	k := &Kode{Versi: versi, ukuran: ukuran, modul: make([][]bool, ukuran), fungsi: make([][]bool, ukuran)}
+	for i := range k.modul {
+		k.modul[i] = make([]bool, ukuran)
+		k.fungsi[i] = make([]bool, ukuran)
+	}
+	return k
+}
+
+func (k *Kode) atur(x, y int, gelap bool) {
+	k.modul[y][x] = gelap
+	k.fungsi[y][x] = true
+}
+
+func (k *Kode) gambarPolaFungsi() {
+	for i := 0; i < k.ukuran; i++ {
+		k.atur(6, i, i%2 == 0)
+		k.atur(i, 6, i%2 == 0)
+	}
+	k.gambarFinder(3, 3)
+	k.gambarFinder(k.ukuran-4, 3)
+	k.gambarFinder(3, k.ukuran-4)
+
+	pos := posisiAlignment[k.Versi]
+	for i, x := range pos {
+		for j, y := range pos {
+			akhir := len(pos) - 1
+			if (i == 0 && j == 0) || (i == 0 && j == akhir) || (i == akhir && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					k.atur(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	// Cadangkan area format; isinya ditulis setelah mask dipilih.
+	k.gambarFormat(KoreksiL, 0)
+	k.gambarVersi()
+}
+
+// gambarFinder menggambar pola pencari 7x7 beserta pemisah putihnya.
+func (k *Kode) gambarFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || y < 0 || x >= k.ukuran || y >= k.ukuran {
+				continue
+			}
+			jarak := max(abs(dx), abs(dy))
+			k.atur(x, y, jarak != 2 && jarak != 4)
+		}
+	}
+}
+
+// gambarFormat menulis 15 bit informasi format (tingkat koreksi dan mask
+// dengan BCH(15,5)) di dua salinan, ditambah modul gelap tetap.
+func (k *Kode) gambarFormat(tingkat TingkatKoreksi, mask int) {
+	data := bitFormat[tingkat]<<3 | mask
+	sisa := data
+	for i := 0; i < 10; i++ {
+		sisa = sisa<<1 ^ (sisa>>9)*0x537
+	}
+	bits := (data<<10 | sisa) ^ 0x5412
+
+	for i := 0; i <= 5; i++ {
+		k.atur(8, i, bit(bits, i))
+	}
+	k.atur(8, 7, bit(bits, 6))
+	k.atur(8, 8, bit(bits, 7))
+	k.atur(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		k.atur(14-i, 8, bit(bits, i))
+	}
+	for i := 0; i < 8; i++ {
+		k.atur(k.ukuran-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		k.atur(8, k.ukuran-15+i, bit(bits, i))
+	}
+	k.atur(8, k.ukuran-8, true)
+}
+
+// gambarVersi menulis 18 bit informasi versi untuk versi 7 ke atas.
+func (k *Kode) gambarVersi() {
+	if k.Versi < 7 {
+		return
+	}
+	sisa := k.Versi
+	for i := 0; i < 12; i++ {
+		sisa = sisa<<1 ^ (sisa>>11)*0x1F25
+	}
+	bits := k.Versi<<12 | sisa
+	for i := 0; i < 18; i++ {
+		a, b := k.ukuran-11+i%3, i/3
+		k.atur(a, b, bit(bits, i))
+		k.atur(b, a, bit(bits, i))
+	}
+}
+
+// codeword menyusun bit data mode byte, menambah padding, membagi ke blok,
+// menghitung koreksi Reed-Solomon, lalu menyelang-nyeling semua blok.
+func (k *Kode) codeword(data []byte, tingkat TingkatKoreksi) []byte {
+	b := tabelBlok[k.Versi][tingkat]
+	kapasitas := b.kapasitasData() * 8
+
+	var bb penulisBit
+	bb.tulis(0b0100, 4)
+	bb.tulis(len(data), bitPanjang(k.Versi))
+	for _, c := range data {
+		bb.tulis(int(c), 8)
+	}
+	bb.tulis(0, min(4, kapasitas-bb.n))
+	bb.tulis(0, (8-bb.n%8)%8)
+	for pad := 0xEC; bb.n < kapasitas; pad ^= 0xEC ^ 0x11 {
+		bb.tulis(pad, 8)
+	}
+
+	pembagi := pembagiRS(b.koreksi)
+	var blokData, blokKoreksi [][]byte
+	awal := 0
+	for i := 0; i < b.blok1+b.blok2; i++ {
+		panjang := b.data1
+		if i >= b.blok1 {
+			panjang = b.data2
+		}
+		isi := bb.data[awal : awal+panjang]
+		awal += panjang
+		blokData = append(blokData, isi)
+		blokKoreksi = append(blokKoreksi, sisaRS(isi, pembagi))
+	}
+
+	var hasil []byte
+	for i := 0; i < max(b.data1, b.data2); i++ {
+		for _, isi := range blokData {
+			if i < len(isi) {
+				hasil = append(hasil, isi[i])
+			}
+		}
+	}
+	for i := 0; i < b.koreksi; i++ {
+		for _, isi := range blokKoreksi {
+			hasil = append(hasil, isi[i])
+		}
+	}
+	return hasil
+}
+
+// tempatkan mengisi modul non-fungsi secara zig-zag dari pojok kanan bawah
+// per dua kolom, melewati kolom timing vertikal.
+func (k *Kode) tempatkan(data []byte) {
+	i := 0
+	for kanan := k.ukuran - 1; kanan >= 1; kanan -= 2 {
+		if kanan == 6 {
+			kanan = 5
+		}
+		for v := 0; v < k.ukuran; v++ {
+			for j := 0; j < 2; j++ {
+				x := kanan - j
+				y := v
+				if (kanan+1)&2 == 0 {
+					y = k.ukuran - 1 - v
+				}
+				if !k.fungsi[y][x] && i < len(data)*8 {
+					k.modul[y][x] = bit(int(data[i>>3]), 7-i&7)
+					i++
+				}
+			}
+		}
+	}
+}
+
+func (k *Kode) terapkanMask(mask int) {
+	for y := 0; y < k.ukuran; y++ {
+		for x := 0; x < k.ukuran; x++ {
+			if k.fungsi[y][x] {
+				continue
+			}
+			var balik bool
+			switch mask {
+			case 0:
+				balik = (x+y)%2 == 0
+			case 1:
+				balik = y%2 == 0
+			case 2:
+				balik = x%3 == 0
+			case 3:
+				balik = (x+y)%3 == 0
+			case 4:
+				balik = (x/3+y/2)%2 == 0
+			case 5:
+				balik = x*y%2+x*y%3 == 0
+			case 6:
+				balik = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				balik = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if balik {
+				k.modul[y][x] = !k.modul[y][x]
+			}
+		}
+	}
+}
+
+// penalti menghitung skor empat aturan evaluasi mask; makin kecil makin baik.
+func (k *Kode) penalti() int {
+	n := k.ukuran
+	hasil := 0
+	for y := 0; y < n; y++ {
+		hasil += penaltiBaris(func(i int) bool { return k.modul[y][i] }, n)
+	}
+	for x := 0; x < n; x++ {
+		hasil += penaltiBaris(func(i int) bool { return k.modul[i][x] }, n)
+	}
+	gelap := 0
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			if k.modul[y][x] {
+				gelap++
+			}
+			if x < n-1 && y < n-1 {
+				c := k.modul[y][x]
+				if c == k.modul[y][x+1] && c == k.modul[y+1][x] && c == k.modul[y+1][x+1] {
+					hasil += 3
+				}
+			}
+		}
+	}
+	persen := gelap * 100 / (n * n)
+	hasil += abs(persen-50) / 5 * 10
+	return hasil
+}
+
+// polaFinder adalah urutan 1:1:3:1:1 dengan empat modul terang di satu sisi.
+var polaFinder = [...][11]bool{
+	{true, false, true, true, true, false, true, false, false, false, false},
+	{false, false, false, false, true, false, true, true, true, false, true},
+}
+
+func penaltiBaris(modul func(int) bool, n int) int {
+	hasil := 0
+	deret := 1
+	for i := 1; i <= n; i++ {
+		if i < n && modul(i) == modul(i-1) {
+			deret++
+			continue
+		}
+		if deret >= 5 {
+			hasil += 3 + deret - 5
+		}
+		deret = 1
+	}
+	for i := 0; i+11 <= n; i++ {
+		for _, pola := range polaFinder {
+			cocok := true
+			for j, p := range pola {
+				if modul(i+j) != p {
+					cocok = false
+					break
+				}
+			}
+			if cocok {
+				hasil += 40
+			}
+		}
+	}
+	return hasil
+}
+
+type penulisBit struct {
+	data []byte
+	n    int
+}
+
+func (p *penulisBit) tulis(nilai, panjang int) {
+	for i := panjang - 1; i >= 0; i-- {
+		if p.n%8 == 0 {
+			p.data = append(p.data, 0)
+		}
+		if nilai>>i&1 == 1 {
+			p.data[p.n/8] |= 0x80 >> (p.n % 8)
+		}
+		p.n++
+	}
+}
+
+// pembagiRS membuat polinom generator Reed-Solomon berderajat n di GF(256)
+// dengan akar α^0 sampai α^(n-1), tanpa koefisien pangkat tertinggi.
+func pembagiRS(n int) []byte {
+	hasil := make([]byte, n)
+	hasil[n-1] = 1
+	akar := byte(1)
+	for i := 0; i < n; i++ {
+		for j := range hasil {
+			hasil[j] = kaliGF(hasil[j], akar)
+			if j+1 < n {
+				hasil[j] ^= hasil[j+1]
+			}
+		}
+		akar = kaliGF(akar, 0x02)
+	}
+	return hasil
+}
+
+func sisaRS(data, pembagi []byte) []byte {
+	hasil := make([]byte, len(pembagi))
+	for _, b := range data {
+		faktor := b ^ hasil[0]
+		copy(hasil, hasil[1:])
+		hasil[len(hasil)-1] = 0
+		for i := range hasil {
+			hasil[i] ^= kaliGF(pembagi[i], faktor)
+		}
+	}
+	return hasil
+}
+
+// kaliGF mengalikan dua elemen GF(256) modulo x^8+x^4+x^3+x^2+1.
+func kaliGF(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = z<<1 ^ (z>>7)*0x11D
+		z ^= int(y>>i&1) * int(x)
+	}
+	return byte(z)
+}
+
+func bit(x, i int) bool { return x>>i&1 != 0 }
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
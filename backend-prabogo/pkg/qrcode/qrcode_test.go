@@ -0,0 +1,251 @@
+package qrcode
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var perbarui = flag.Bool("perbarui", false, "tulis ulang berkas golden di testdata")
+
+// Contoh "HELLO WORLD" 1-M dari ISO/IEC 18004 (lihat juga tutorial Thonky):
+// 16 codeword data menghasilkan 10 codeword koreksi berikut.
+func TestSisaRSContohStandar(t *testing.T) {
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	ingin := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	got := sisaRS(data, pembagiRS(len(ingin)))
+	if string(got) != string(ingin) {
+		t.Errorf("sisaRS = %v, ingin %v", got, ingin)
+	}
+}
+
+// bacaFormat membaca salinan pertama informasi format, bit 14 lebih dulu,
+// mengikuti tata letak ISO/IEC 18004 7.9.1.
+func bacaFormat(k *Kode) string {
+	var b strings.Builder
+	tulis := func(x, y int) {
+		if k.Gelap(x, y) {
+			b.WriteByte('1')
+		} else {
+			b.WriteByte('0')
+		}
+	}
+	for x := 0; x <= 5; x++ {
+		tulis(x, 8)
+	}
+	tulis(7, 8)
+	tulis(8, 8)
+	tulis(8, 7)
+	for y := 5; y >= 0; y-- {
+		tulis(8, y)
+	}
+	return b.String()
+}
+
+// Nilai dari tabel informasi format ISO/IEC 18004 untuk mask 0.
+func TestInformasiFormat(t *testing.T) {
+	for tingkat, ingin := range map[TingkatKoreksi]string{
+		KoreksiL: "111011111000100",
+		KoreksiM: "101010000010010",
+		KoreksiQ: "011010101011111",
+		KoreksiH: "001011010001001",
+	} {
+		k := baru(1)
+		k.gambarFormat(tingkat, 0)
+		if got := bacaFormat(k); got != ingin {
+			t.Errorf("format tingkat %d mask 0 = %s, ingin %s", tingkat, got, ingin)
+		}
+	}
+}
+
+const dataGolden = "https://averroes.id/v/AB12"
+
+func matriksTeks(k *Kode) string {
+	var b strings.Builder
+	for y := 0; y < k.Ukuran(); y++ {
+		for x := 0; x < k.Ukuran(); x++ {
+			if k.Gelap(x, y) {
+				b.WriteByte('#')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Berkas golden dipastikan benar oleh TestGoldenTerbaca; test ini menjaga
+// keluaran Buat tidak berubah diam-diam.
+func TestBuatGolden(t *testing.T) {
+	k, err := Buat(dataGolden, KoreksiM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	berkas := filepath.Join("testdata", "v2m.txt")
+	got := matriksTeks(k)
+	if *perbarui {
+		if err := os.WriteFile(berkas, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ingin, err := os.ReadFile(berkas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.Versi != 2 {
+		t.Errorf("versi = %d, ingin 2", k.Versi)
+	}
+	if got != string(ingin) {
+		t.Errorf("matriks berbeda dari %s:\n%s", berkas, got)
+	}
+}
+
+// TestGoldenTerbaca mendekode berkas golden tanpa memakai fungsi penyandi:
+// informasi format, pembacaan zig-zag, sindrom Reed-Solomon dan isi data.
+func TestGoldenTerbaca(t *testing.T) {
+	isi, err := os.ReadFile(filepath.Join("testdata", "v2m.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	baris := strings.Split(strings.TrimSpace(string(isi)), "\n")
+	const ukuran = 25
+	if len(baris) != ukuran {
+		t.Fatalf("golden berisi %d baris, ingin %d", len(baris), ukuran)
+	}
+	gelap := func(x, y int) bool { return baris[y][x] == '#' }
+
+	// Format: 15 bit dengan mask 0x5412; lima bit teratas berisi tingkat dan mask.
+	format := 0
+	for _, p := range [][2]int{{0, 8}, {1, 8}, {2, 8}, {3, 8}, {4, 8}, {5, 8}, {7, 8}, {8, 8}, {8, 7}, {8, 5}, {8, 4}, {8, 3}, {8, 2}, {8, 1}, {8, 0}} {
+		format <<= 1
+		if gelap(p[0], p[1]) {
+			format |= 1
+		}
+	}
+	format ^= 0x5412
+	if bchSisa(format) != 0 {
+		t.Fatalf("informasi format %015b bukan kode BCH(15,5) yang valid", format^0x5412)
+	}
+	if tingkat := format >> 13; tingkat != 0 {
+		t.Fatalf("bit tingkat koreksi = %02b, ingin 00 (M)", tingkat)
+	}
+	mask := format >> 10 & 7
+
+	// Modul fungsi versi 2: finder beserta pemisah dan area format, timing,
+	// satu alignment berpusat di (18, 18).
+	fungsi := func(x, y int) bool {
+		switch {
+		case x <= 8 && y <= 8, x >= ukuran-8 && y <= 8, x <= 8 && y >= ukuran-8:
+			return true
+		case x == 6 || y == 6:
+			return true
+		case x >= 16 && x <= 20 && y >= 16 && y <= 20:
+			return true
+		}
+		return false
+	}
+	var rumusMask = [8]func(x, y int) bool{
+		func(x, y int) bool { return (x+y)%2 == 0 },
+		func(x, y int) bool { return y%2 == 0 },
+		func(x, y int) bool { return x%3 == 0 },
+		func(x, y int) bool { return (x+y)%3 == 0 },
+		func(x, y int) bool { return (x/3+y/2)%2 == 0 },
+		func(x, y int) bool { return x*y%2+x*y%3 == 0 },
+		func(x, y int) bool { return (x*y%2+x*y%3)%2 == 0 },
+		func(x, y int) bool { return ((x+y)%2+x*y%3)%2 == 0 },
+	}
+
+	var bits []bool
+	naik := true
+	for kanan := ukuran - 1; kanan > 0; kanan -= 2 {
+		if kanan == 6 {
+			kanan--
+		}
+		for i := 0; i < ukuran; i++ {
+			y := i
+			if naik {
+				y = ukuran - 1 - i
+			}
+			for _, x := range []int{kanan, kanan - 1} {
+				if !fungsi(x, y) {
+					bits = append(bits, gelap(x, y) != rumusMask[mask](x, y))
+				}
+			}
+		}
+		naik = !naik
+	}
+	// Versi 2-M: 44 codeword (28 data + 16 koreksi) dalam satu blok, sisa 7 bit.
+	if len(bits) != 44*8+7 {
+		t.Fatalf("modul data = %d, ingin %d", len(bits), 44*8+7)
+	}
+	codeword := make([]byte, 44)
+	for i := range codeword {
+		for j := 0; j < 8; j++ {
+			codeword[i] <<= 1
+			if bits[i*8+j] {
+				codeword[i] |= 1
+			}
+		}
+	}
+	for i := 0; i < 16; i++ {
+		if s := sindrom(codeword, i); s != 0 {
+			t.Fatalf("sindrom S%d = %d, ingin 0", i, s)
+		}
+	}
+
+	if codeword[0]>>4 != 0b0100 {
+		t.Fatalf("mode = %04b, ingin 0100 (byte)", codeword[0]>>4)
+	}
+	panjang := int(codeword[0]&0x0F)<<4 | int(codeword[1]>>4)
+	data := make([]byte, panjang)
+	for i := range data {
+		data[i] = codeword[1+i]<<4 | codeword[2+i]>>4
+	}
+	if string(data) != dataGolden {
+		t.Errorf("data = %q, ingin %q", data, dataGolden)
+	}
+}
+
+// bchSisa menghitung sisa pembagian 15 bit oleh polinom generator format
+// x^10+x^8+x^5+x^4+x^2+x+1.
+func bchSisa(v int) int {
+	for i := 14; i >= 10; i-- {
+		if v>>i&1 == 1 {
+			v ^= 0x537 << (i - 10)
+		}
+	}
+	return v
+}
+
+// sindrom mengevaluasi codeword sebagai polinom (koefisien pertama berpangkat
+// tertinggi) di α^i pada GF(256) dengan polinom 0x11D.
+func sindrom(codeword []byte, i int) byte {
+	alfa := byte(1)
+	for j := 0; j < i; j++ {
+		alfa = kaliGFUji(alfa, 2)
+	}
+	var hasil byte
+	for _, c := range codeword {
+		hasil = kaliGFUji(hasil, alfa) ^ c
+	}
+	return hasil
+}
+
+func kaliGFUji(a, b byte) byte {
+	var hasil byte
+	for b > 0 {
+		if b&1 == 1 {
+			hasil ^= a
+		}
+		b >>= 1
+		if a&0x80 != 0 {
+			a = a<<1 ^ 0x1D
+		} else {
+			a <<= 1
+		}
+	}
+	return hasil
+}
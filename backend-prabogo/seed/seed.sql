@@ -4,38 +4,77 @@ INSERT INTO pengguna (nama, email, kata_sandi_hash, peran, status, sudah_verifik
 ('Moderator Forum', 'moderator@averroes.id', '$2a$10$EnXpqPlOl0FrAKp6byW6OurHv5G.rWp5Wm8MJhsqCEIt2RxL0lTaG', 'moderator', 'aktif', 1, NOW(), NOW()),
 ('Ahmad Fahri', 'ahmad@averroes.id', '$2a$10$17w8CCEGflAGDTO.uLryNeazD37ULpLDdaz3cEN7XEdS8C2SnzHBa', 'user', 'aktif', 1, NOW(), NOW());
 
-INSERT INTO screener (nama_aset, simbol, kategori, skor_syariah, keterangan, harga_terakhir, perubahan_24j, dibuat_pada) VALUES
-('Amanah Coin', 'AMAN', 'halal', 88.50, 'Struktur token utilitas dan transparansi syariah terverifikasi', 1.2450, 2.10, NOW()),
-('Mizan Token', 'MZN', 'proses', 72.30, 'Sedang proses kajian dewan pengawas syariah', 0.8420, -1.25, NOW()),
-('RibaX', 'RBX', 'tidak_direkomendasikan', 35.10, 'Model bisnis mengandung unsur spekulatif tinggi', 0.1200, -4.80, NOW());
+INSERT INTO screener (nama_aset, simbol, kategori, sektor, skor_syariah, keterangan, harga_terakhir, perubahan_24j, dibuat_pada) VALUES
+('Amanah Coin', 'AMAN', 'halal', 'pembayaran', 88.50, 'Struktur token utilitas dan transparansi syariah terverifikasi', 1.2450, 2.10, NOW()),
+('Mizan Token', 'MZN', 'proses', 'keuangan', 72.30, 'Sedang proses kajian dewan pengawas syariah', 0.8420, -1.25, NOW()),
+('RibaX', 'RBX', 'tidak_direkomendasikan', 'keuangan', 35.10, 'Model bisnis mengandung unsur spekulatif tinggi', 0.1200, -4.80, NOW()),
+('Sukuk Chain', 'SKC', 'halal', 'keuangan', 91.20, 'Tokenisasi sukuk dengan akad ijarah yang diaudit', 2.1500, 3.45, NOW());
 
 INSERT INTO screener_catatan (id_screener, judul, isi, dibuat_pada) VALUES
 (1, 'Catatan Kepatuhan', 'Audit internal menyatakan kepatuhan muamalah pada lapis transaksi utama.', NOW()),
 (2, 'Status Kajian', 'Menunggu publikasi ringkasan keputusan dewan syariah.', NOW());
 
-INSERT INTO pasar (nama_aset, simbol, harga, volume_24j, perubahan_24j, kapitalisasi_pasar, diperbarui_pada) VALUES
-('Amanah Coin', 'AMAN', 1.2450, 1250000, 2.10, 55000000, NOW()),
-('Mizan Token', 'MZN', 0.8420, 980000, -1.25, 32000000, NOW()),
-('Sukuk Chain', 'SKC', 2.1500, 1870000, 3.45, 76000000, NOW());
-
-INSERT INTO kelas (judul, deskripsi, level, jumlah_modul, durasi_menit, thumbnail_url, status, dibuat_pada) VALUES
-('Fiqh Muamalah Aset Digital', 'Memahami prinsip muamalah dalam aset digital dan kripto syariah.', 'pemula', 4, 180, 'https://picsum.photos/seed/kelas1/600/400', 'publik', NOW()),
-('Analisis Risiko Syariah', 'Membedah risiko dan mitigasi syariah pada investasi aset digital.', 'menengah', 3, 150, 'https://picsum.photos/seed/kelas2/600/400', 'publik', NOW());
-
-INSERT INTO modul (id_kelas, judul, urutan, ringkasan, durasi_menit, dibuat_pada) VALUES
-(1, 'Pengantar Aset Digital Syariah', 1, 'Dasar konsep aset digital dalam perspektif muamalah.', 45, NOW()),
-(1, 'Kaidah Muamalah Terapan', 2, 'Prinsip halal-haram dan gharar dalam aset digital.', 45, NOW()),
-(1, 'Studi Kasus Proyek Kripto', 3, 'Menganalisis proyek nyata dari sisi kepatuhan.', 50, NOW()),
-(2, 'Kerangka Risiko Syariah', 1, 'Model identifikasi risiko syariah pada investasi.', 50, NOW());
-
-INSERT INTO materi (id_modul, judul, tipe, konten, url_video, durasi_menit, dibuat_pada) VALUES
-(1, 'Definisi Aset Digital', 'teks', 'Aset digital adalah representasi nilai berbasis teknologi yang harus memenuhi prinsip muamalah.', '', 10, NOW()),
-(1, 'Video Pengantar', 'video', 'Ringkasan prinsip utama aset digital syariah.', 'https://www.example.com/video1', 15, NOW()),
-(2, 'Kaidah Dasar', 'teks', 'Larangan riba, maysir, dan gharar menjadi pagar utama.', '', 15, NOW());
-
-INSERT INTO ujian (id_kelas, judul, deskripsi, durasi_menit, jumlah_soal, dibuat_pada) VALUES
-(1, 'Ujian Dasar Muamalah', 'Ujian pemahaman dasar aset digital syariah.', 30, 10, NOW()),
-(2, 'Ujian Risiko Syariah', 'Evaluasi risiko dan mitigasi syariah.', 25, 8, NOW());
+INSERT INTO pasar (nama_aset, simbol, harga, volume_24j, perubahan_24j, kapitalisasi_pasar, diperbarui_pada, mata_uang) VALUES
+('Amanah Coin', 'AMAN', 1.2450, 1250000, 2.10, 55000000, NOW(), 'USD'),
+('Mizan Token', 'MZN', 0.8420, 980000, -1.25, 32000000, NOW(), 'USD'),
+('Sukuk Chain', 'SKC', 2.1500, 1870000, 3.45, 76000000, NOW(), 'USD');
+
+INSERT INTO pasar_riwayat (simbol, tanggal, harga, mata_uang) VALUES
+('AMAN', CURDATE() - INTERVAL 60 DAY, 1.0000, 'USD'),
+('AMAN', CURDATE() - INTERVAL 30 DAY, 1.1200, 'USD'),
+('AMAN', CURDATE(), 1.2450, 'USD'),
+('MZN', CURDATE() - INTERVAL 45 DAY, 0.9000, 'USD'),
+('MZN', CURDATE() - INTERVAL 10 DAY, 0.8420, 'USD'),
+('MZN', CURDATE(), 0.8420, 'USD'),
+('SKC', CURDATE(), 2.1500, 'USD');
+
+INSERT INTO kelas (judul, deskripsi, level, thumbnail_url, status, dibuat_pada) VALUES
+('Fiqh Muamalah Aset Digital', 'Memahami prinsip muamalah dalam aset digital dan kripto syariah.', 'pemula', 'https://picsum.photos/seed/kelas1/600/400', 'terbit', NOW()),
+('Analisis Risiko Syariah', 'Membedah risiko dan mitigasi syariah pada investasi aset digital.', 'menengah', 'https://picsum.photos/seed/kelas2/600/400', 'terbit', NOW());
+
+INSERT INTO modul (id_kelas, judul, urutan, ringkasan, status, dibuat_pada) VALUES
+(1, 'Pengantar Aset Digital Syariah', 1, 'Dasar konsep aset digital dalam perspektif muamalah.', 'terbit', NOW()),
+(1, 'Kaidah Muamalah Terapan', 2, 'Prinsip halal-haram dan gharar dalam aset digital.', 'terbit', NOW()),
+(1, 'Studi Kasus Proyek Kripto', 3, 'Menganalisis proyek nyata dari sisi kepatuhan.', 'terbit', NOW()),
+(2, 'Kerangka Risiko Syariah', 1, 'Model identifikasi risiko syariah pada investasi.', 'terbit', NOW());
+
+INSERT INTO materi (id_modul, judul, tipe, konten, url_video, durasi_menit, status, dibuat_pada) VALUES
+(1, 'Definisi Aset Digital', 'teks', 'Aset digital adalah representasi nilai berbasis teknologi yang harus memenuhi prinsip muamalah.', '', 10, 'terbit', NOW()),
+(1, 'Video Pengantar', 'video', 'Ringkasan prinsip utama aset digital syariah.', 'https://www.example.com/video1', 15, 'terbit', NOW()),
+(2, 'Kaidah Dasar', 'teks', 'Larangan riba, maysir, dan gharar menjadi pagar utama.', '', 15, 'terbit', NOW());
+
+INSERT INTO ujian (id_kelas, judul, deskripsi, durasi_menit, nilai_lulus, soal_per_percobaan, dibuat_pada) VALUES
+(1, 'Ujian Dasar Muamalah', 'Ujian pemahaman dasar aset digital syariah.', 30, 70, 0, NOW()),
+(2, 'Ujian Risiko Syariah', 'Evaluasi risiko dan mitigasi syariah.', 25, 70, 0, NOW());
+
+INSERT INTO ujian_soal (id_ujian, tipe, pertanyaan, jawaban_isian, pembahasan, bobot, urutan, dibuat_pada) VALUES
+(1, 'pilihan_ganda', 'Unsur ketidakjelasan berlebihan dalam akad disebut?', '', 'Gharar adalah ketidakjelasan yang dapat merugikan salah satu pihak.', 1, 1, NOW()),
+(1, 'benar_salah', 'Tambahan yang disyaratkan atas pinjaman termasuk riba.', '', 'Setiap tambahan yang disyaratkan dalam utang piutang adalah riba.', 1, 2, NOW()),
+(1, 'isian', 'Sebutkan istilah untuk spekulasi untung-untungan yang dilarang.', 'maysir|maisir', 'Maysir adalah perjudian atau spekulasi murni.', 1, 3, NOW());
+
+INSERT INTO ujian_opsi (id_soal, teks, benar, urutan) VALUES
+(1, 'Riba', 0, 1),
+(1, 'Gharar', 1, 2),
+(1, 'Maysir', 0, 3),
+(2, 'Benar', 1, 1),
+(2, 'Salah', 0, 2);
+
+INSERT INTO progress_materi (id_pengguna, id_materi, posisi_detik, selesai, selesai_pada, diperbarui_pada) VALUES
+(4, 1, 0, 1, NOW(), NOW()),
+(4, 2, 420, 0, NULL, NOW());
+
+INSERT INTO progress_kelas (id_pengguna, id_kelas, persentase, status, terakhir_diakses_pada) VALUES
+(4, 1, 25.00, 'berjalan', NOW());
+
+INSERT INTO kelas_prasyarat (id_kelas, id_prasyarat) VALUES
+(2, 1);
+
+INSERT INTO jalur_belajar (judul, deskripsi, status, dibuat_pada) VALUES
+('Investor Aset Digital Syariah', 'Mulai dari fiqh muamalah aset digital lalu lanjut ke analisis risiko syariah.', 'terbit', NOW());
+
+INSERT INTO jalur_kelas (id_jalur, id_kelas, urutan) VALUES
+(1, 1, 1),
+(1, 2, 2);
 
 INSERT INTO pustaka (judul_tampil, judul_asli, penulis, kategori, bahasa, jumlah_halaman, deskripsi, tautan_file) VALUES
 ('Hukum Fikih tentang Uang Kertas (Fiat)', 'Hukum Fiqih terhadap Uang Kertas (Fiat)', 'Penulis Terkenal', 'Ekonomi Syariah', 'Indonesia', 250, 'Kajian fikih mengenai uang kertas dan muamalah modern.', 'https://drive.google.com/file/d/1234567890abcdefg/view?usp=sharing'),
@@ -55,15 +94,36 @@ INSERT INTO diskusi_balas (id_diskusi, id_pengguna, isi, dibuat_pada) VALUES
 (1, 3, 'Fokus pada utility, transparansi, dan tidak ada skema riba.', NOW()),
 (2, 2, 'Gunakan nilai rata-rata tahunan dan bandingkan dengan nisab.', NOW());
 
-INSERT INTO portofolio (id_pengguna, nama_aset, simbol, jumlah, harga_beli, nilai_saat_ini, kategori, dibuat_pada) VALUES
-(4, 'Amanah Coin', 'AMAN', 1000, 1.0000, 1245.00, 'halal', NOW()),
-(4, 'Mizan Token', 'MZN', 500, 0.9000, 421.00, 'proses', NOW());
+INSERT INTO portofolio_transaksi (id_pengguna, jenis, nama_aset, simbol, kategori, jumlah, harga, biaya, mata_uang, tanggal, catatan, dibuat_pada) VALUES
+(4, 'beli', 'Amanah Coin', 'AMAN', 'halal', 1000, 1.0000, 0, 'USD', NOW() - INTERVAL 60 DAY, '', NOW()),
+(4, 'beli', 'Mizan Token', 'MZN', 'proses', 600, 0.9000, 0, 'USD', NOW() - INTERVAL 45 DAY, '', NOW()),
+(4, 'jual', 'Mizan Token', 'MZN', 'proses', 100, 0.8420, 0, 'USD', NOW() - INTERVAL 10 DAY, '', NOW()),
+(4, 'dividen', 'Amanah Coin', 'AMAN', 'halal', 0, 12.5000, 0, 'USD', NOW() - INTERVAL 5 DAY, 'Bagi hasil staking', NOW());
+
+INSERT INTO portofolio_snapshot (id_pengguna, tanggal, total_nilai, total_modal, mata_uang, jumlah_aset, dibuat_pada) VALUES
+(4, CURDATE() - INTERVAL 30 DAY, 25420000.00, 24952500.00, 'IDR', 2, NOW()),
+(4, CURDATE() - INTERVAL 10 DAY, 26300000.00, 23556250.00, 'IDR', 2, NOW()),
+(4, CURDATE() - INTERVAL 1 DAY, 27072500.00, 23556250.00, 'IDR', 2, NOW());
+
+INSERT INTO zakat_riwayat (id_pengguna, tanggal, total_nilai, nisab, persen_zakat, zakat_terhitung, wajib_zakat, harga_emas_per_gram, dibuat_pada) VALUES
+(4, CURDATE() - INTERVAL 1 DAY, 1666.00, 85000000.00, 2.50, 41.65, 0, 1000000.00, NOW());
+
+INSERT INTO zakat_harta (id_pengguna, kelompok, jenis, nama, nilai, mata_uang, catatan, dibuat_pada, diperbarui_pada) VALUES
+(4, 'aset', 'tabungan', 'Tabungan BSI', 15000000.00, 'IDR', '', NOW(), NOW()),
+(4, 'pengurang', 'cicilan', 'Cicilan KPR jatuh tempo bulan ini', 3500000.00, 'IDR', 'Hanya cicilan yang jatuh tempo', NOW(), NOW());
+
+INSERT INTO zakat_pembayaran (id_pengguna, jenis, tahun_haul, tanggal, jumlah, mata_uang, jumlah_idr, jenis_penerima, penerima, asnaf, nomor_bukti, bukti_url, catatan, dibuat_pada, diperbarui_pada) VALUES
+(4, 'fitrah', 1446, '2025-03-28', 150000.00, 'IDR', 150000.00, 'amil', 'BAZNAS', '', 'BSZ-2025-000123', NULL, 'Zakat fitrah 3 jiwa', NOW(), NOW());
 
-INSERT INTO zakat_riwayat (id_pengguna, total_nilai, nisab, persen_zakat, zakat_terhitung, dibuat_pada) VALUES
-(4, 1666.00, 85000000.00, 2.50, 41.65, NOW());
+INSERT INTO harga_emas (tanggal, harga_per_gram, mata_uang, logam) VALUES
+(CURDATE(), 1000000.00, 'IDR', 'emas'),
+(CURDATE(), 12500.00, 'IDR', 'perak');
 
-INSERT INTO harga_emas (tanggal, harga_per_gram) VALUES
-(CURDATE(), 1000000.00);
+INSERT INTO kurs (mata_uang_asal, mata_uang_tujuan, nilai, sumber, tanggal, diperbarui_pada) VALUES
+('USD', 'IDR', 16250.0000000000, 'seed', CURDATE(), NOW()),
+('USD', 'SGD', 1.3400000000, 'seed', CURDATE(), NOW()),
+('USD', 'MYR', 4.4500000000, 'seed', CURDATE(), NOW()),
+('USD', 'SAR', 3.7500000000, 'seed', CURDATE(), NOW());
 
 INSERT INTO reels (judul, tema, kutipan, sumber, url_video, thumbnail_url, dibuat_pada) VALUES
 ('Transaksi Amanah', 'muamalah', 'Amanah adalah pondasi muamalah.', 'QS Al-Muminun', 'https://www.example.com/reels1', 'https://picsum.photos/seed/reels1/400/600', NOW()),